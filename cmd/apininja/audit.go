@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/manifest"
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+func runAudit(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+	target := ""
+	strict := false
+	format := "text"
+	helm := manifest.HelmOptions{}
+	kustomizations := []string{}
+
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.StringVar(&target, "target", target, "The Kubernetes release the manifests will be applied to (e.g. \"1.29\").")
+	fs.BoolVar(&strict, "strict", strict, "Also fail if APIs are deprecated or superseded by a stable version.")
+	fs.StringVar(&format, "format", format, "Output format, \"text\", \"github\" (GitHub Actions annotations on the affected files) or \"sarif\" (for code scanning tools).")
+	fs.StringVar(&helm.Binary, "helm", "helm", "The helm binary used to render charts (directories containing a Chart.yaml).")
+	fs.Var((*stringList)(&helm.ValuesFiles), "values", "Values file for rendering charts (can be given multiple times).")
+	fs.Var((*stringList)(&helm.Values), "set", "Value for rendering charts, like \"ingress.enabled=true\" (can be given multiple times).")
+	fs.Var((*stringList)(&kustomizations), "kustomize", "Kustomization directory to build and audit, like \"./overlays/prod\" (can be given multiple times).")
+	fs.Parse(args)
+
+	// allow flags after the paths, like "audit ./manifests -target 1.29"
+	paths := []string{}
+	for fs.NArg() > 0 {
+		// directories are always searched recursively, so accept Go-style patterns
+		paths = append(paths, strings.TrimSuffix(fs.Arg(0), "/..."))
+		fs.Parse(fs.Args()[1:])
+	}
+
+	if (len(paths) == 0 && len(kustomizations) == 0) || target == "" {
+		return errors.New("usage: audit -target RELEASE [FLAGS] [-kustomize DIR] PATH [PATH…] (files or directories containing YAML/JSON manifests)")
+	}
+
+	if format != "text" && format != "github" && format != "sarif" {
+		return fmt.Errorf("invalid format %q, must be \"text\", \"github\" or \"sarif\"", format)
+	}
+
+	tl, err := opts.Timeline(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !tl.HasRelease(target) {
+		return fmt.Errorf("%w %q", timeline.ErrUnknownRelease, target)
+	}
+
+	// render charts like they would be installed into the target release
+	helm.KubeVersion = target
+	helm.APIVersions = tl.ServedAPIVersions(target)
+
+	objects, err := manifest.ParseFilesAndCharts(ctx, helm, paths...)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range kustomizations {
+		built, err := manifest.BuildKustomization(dir)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, built...)
+	}
+
+	report, err := tl.Audit(objects, target)
+	if err != nil {
+		return err
+	}
+
+	if format == "sarif" {
+		if err := report.WriteSARIF(os.Stdout, BuildTag, strict); err != nil {
+			return fmt.Errorf("failed to write SARIF log: %w", err)
+		}
+
+		return auditResult(report, strict)
+	}
+
+	fmt.Printf("Audited %d objects against Kubernetes %s\n\n", len(objects), report.Target)
+
+	if len(report.Findings) == 0 {
+		fmt.Println("  ✓ all APIs are available")
+	}
+
+	for _, finding := range report.Findings {
+		failed := finding.Status == timeline.AuditRemoved || finding.Status == timeline.AuditUnavailable
+
+		if format == "github" {
+			level := "warning"
+			if failed || strict {
+				level = "error"
+			}
+
+			fmt.Println(githubAnnotation(level, finding.Object.Source, fmt.Sprintf("%s is not ready for Kubernetes %s", finding.Object, report.Target), finding.String()))
+			continue
+		}
+
+		marker := "!"
+		if failed {
+			marker = "✗"
+		}
+
+		fmt.Printf("  %s %s: %s (%s)\n", marker, finding.Object.Source, finding.Object, finding)
+	}
+
+	if len(report.Unknown) > 0 {
+		fmt.Printf("\n%d objects do not use Kubernetes APIs (e.g. custom resources) and were not checked.\n", len(report.Unknown))
+	}
+
+	return auditResult(report, strict)
+}
+
+func auditResult(report *timeline.AuditReport, strict bool) error {
+	if report.Failed() {
+		return fmt.Errorf("manifests use APIs that are not available in %s", report.Target)
+	}
+
+	if strict && len(report.Findings) > 0 {
+		return fmt.Errorf("manifests use deprecated APIs")
+	}
+
+	return nil
+}
+
+// githubAnnotation returns a workflow command that makes GitHub Actions show
+// the message on the given file, e.g. in the diff of a pull request.
+func githubAnnotation(level string, file string, title string, message string) string {
+	escapeData := strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	escapeProperty := strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+
+	return fmt.Sprintf("::%s file=%s,title=%s::%s", level, escapeProperty.Replace(file), escapeProperty.Replace(title), escapeData.Replace(message))
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+)
+
+func runClients(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+
+	fs := flag.NewFlagSet("clients", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: clients [FLAGS] RELEASE (e.g. \"clients 1.29\")")
+	}
+
+	db, err := opts.Database()
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	release, err := db.Release(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	clients, err := release.ClientVersions()
+	if err != nil {
+		return fmt.Errorf("failed to read client versions: %w", err)
+	}
+
+	if clients == nil {
+		return fmt.Errorf("no client versions known for Kubernetes %s", release.Version())
+	}
+
+	controllerRuntime := clients.ControllerRuntime
+	if controllerRuntime == "" {
+		controllerRuntime = "(unknown)"
+	}
+
+	fmt.Printf("Kubernetes %s\n", release.Version())
+	fmt.Printf("  client-go:          %s\n", clients.ClientGo)
+	fmt.Printf("  controller-runtime: %s\n", controllerRuntime)
+
+	return nil
+}
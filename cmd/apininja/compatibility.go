@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"go.xrstf.de/kube-api.ninja/pkg/manifest"
+)
+
+func runCompatibility(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+	helm := manifest.HelmOptions{}
+	kustomizations := []string{}
+
+	fs := flag.NewFlagSet("compatibility", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.StringVar(&helm.Binary, "helm", "helm", "The helm binary used to render charts (directories containing a Chart.yaml).")
+	fs.Var((*stringList)(&helm.ValuesFiles), "values", "Values file for rendering charts (can be given multiple times).")
+	fs.Var((*stringList)(&helm.Values), "set", "Value for rendering charts, like \"ingress.enabled=true\" (can be given multiple times).")
+	fs.Var((*stringList)(&kustomizations), "kustomize", "Kustomization directory to build and check, like \"./overlays/prod\" (can be given multiple times).")
+	fs.Parse(args)
+
+	// allow flags after the paths, like "compatibility ./manifests -data ..."
+	paths := []string{}
+	for fs.NArg() > 0 {
+		paths = append(paths, strings.TrimSuffix(fs.Arg(0), "/..."))
+		fs.Parse(fs.Args()[1:])
+	}
+
+	if len(paths) == 0 && len(kustomizations) == 0 {
+		return errors.New("usage: compatibility [FLAGS] [-kustomize DIR] PATH [PATH…] (files or directories containing YAML/JSON manifests)")
+	}
+
+	tl, err := opts.Timeline(ctx)
+	if err != nil {
+		return err
+	}
+
+	objects, err := manifest.ParseFilesAndCharts(ctx, helm, paths...)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range kustomizations {
+		built, err := manifest.BuildKustomization(dir)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, built...)
+	}
+
+	report := tl.Compatibility(objects)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "API\tRELEASES")
+
+	for _, constraint := range report.Constraints {
+		fmt.Fprintf(w, "%s %s\t%s\n", constraint.APIVersion, constraint.Kind, releaseRange(constraint.Releases))
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(report.Unknown) > 0 {
+		fmt.Printf("\n%d objects do not use Kubernetes APIs (e.g. custom resources) and were not checked.\n", len(report.Unknown))
+	}
+
+	fmt.Println()
+
+	first, last := report.Range()
+	if first == "" {
+		return errors.New("no Kubernetes release serves all APIs used by the manifests")
+	}
+
+	msg := fmt.Sprintf("These manifests work on Kubernetes %s through %s", first, last)
+	if first == last {
+		msg = fmt.Sprintf("These manifests only work on Kubernetes %s", first)
+	}
+
+	if len(report.Gaps) > 0 {
+		msg += fmt.Sprintf(" (except %s)", strings.Join(report.Gaps, ", "))
+	}
+
+	fmt.Println(msg + ".")
+
+	return nil
+}
+
+// releaseRange formats a list of releases like "1.16 – 1.29".
+func releaseRange(releases []string) string {
+	switch len(releases) {
+	case 0:
+		return "-"
+	case 1:
+		return releases[0]
+	default:
+		return fmt.Sprintf("%s – %s", releases[0], releases[len(releases)-1])
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+func runCVEs(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+
+	fs := flag.NewFlagSet("cves", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: cves [FLAGS] RELEASE (e.g. \"cves 1.28\")")
+	}
+
+	db, err := opts.Database()
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	release, err := db.Release(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	advisories, err := release.Advisories()
+	if err != nil {
+		return fmt.Errorf("failed to read security advisories: %w", err)
+	}
+
+	if len(advisories) == 0 {
+		fmt.Printf("No known security advisories for Kubernetes %s.\n", release.Version())
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSEVERITY\tFIXED IN\tSUMMARY")
+
+	for _, advisory := range advisories {
+		fixedIn := advisory.FixedIn
+		if fixedIn == "" {
+			fixedIn = "(unfixed)"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", advisory.ID, advisory.Severity, fixedIn, advisory.Summary)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+)
+
+func runData(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "merge":
+			return runDataMerge(ctx, args[1:])
+		case "index":
+			return runDataIndex(ctx, args[1:])
+		case "sqlite":
+			return runDataSQLite(ctx, args[1:])
+		case "feature-gates":
+			return runDataFeatureGates(ctx, args[1:])
+		case "release-notes":
+			return runDataReleaseNotes(ctx, args[1:])
+		}
+	}
+
+	return errors.New("usage: data merge [FLAGS] SOURCE SOURCE [SOURCE…] | data index [FLAGS] | data sqlite [FLAGS] | data feature-gates [FLAGS] DIRECTORY | data release-notes [FLAGS] CHANGELOG [CHANGELOG…]")
+}
+
+// runDataIndex writes the list of releases into the database, so that it can
+// be published on any web server and used via the HTTP data source.
+func runDataIndex(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+
+	fs := flag.NewFlagSet("data index", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.Parse(args)
+
+	db, err := database.NewReleaseDatabase(opts.dataDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	releases, err := db.Releases()
+	if err != nil {
+		return fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	index := strings.Join(releases, "\n") + "\n"
+
+	return os.WriteFile(filepath.Join(opts.dataDirectory, database.ReleaseIndexFile), []byte(index), 0644)
+}
+
+// runDataSQLite compiles the API data of all releases into a SQLite file, so
+// that server mode can look up single resources without the timeline.
+func runDataSQLite(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+	output := "resources.sqlite"
+
+	fs := flag.NewFlagSet("data sqlite", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.StringVar(&output, "output", output, "SQLite file to create (an existing file is replaced).")
+	fs.Parse(args)
+
+	db, err := database.NewReleaseDatabase(opts.dataDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := database.BuildResourceIndex(ctx, db, output); err != nil {
+		return fmt.Errorf("failed to build resource index: %w", err)
+	}
+
+	return nil
+}
+
+func runDataMerge(ctx context.Context, args []string) error {
+	output := ""
+	strict := false
+
+	fs := flag.NewFlagSet("data merge", flag.ExitOnError)
+	fs.StringVar(&output, "output", output, "Write the merged API data to this file (e.g. data/releases/1.29/api.json) instead of stdout.")
+	fs.BoolVar(&strict, "strict", strict, "Fail if the sources contain conflicting data instead of applying the precedence rules.")
+	fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		return errors.New("usage: data merge [FLAGS] SOURCE SOURCE [SOURCE…] (sources are given in order of precedence, the first source wins conflicts)")
+	}
+
+	sources := []database.MergeSource{}
+	for _, filename := range fs.Args() {
+		api, err := loadAPI(filename)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+
+		sources = append(sources, database.MergeSource{Name: filename, API: api})
+	}
+
+	merged, conflicts, err := database.MergeAPIs(sources)
+	if err != nil {
+		return err
+	}
+
+	for _, conflict := range conflicts {
+		fmt.Fprintf(os.Stderr, "Conflict: %s\n", conflict)
+	}
+
+	if strict && len(conflicts) > 0 {
+		return fmt.Errorf("sources contain %d conflicts", len(conflicts))
+	}
+
+	if merged.Release == "" {
+		fmt.Fprintln(os.Stderr, "Warning: no source specified the release, make sure to add it manually.")
+	}
+
+	encoded, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode merged data: %w", err)
+	}
+
+	encoded = append(encoded, '\n')
+
+	if output == "" {
+		_, err = os.Stdout.Write(encoded)
+		return err
+	}
+
+	return os.WriteFile(output, encoded, 0644)
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+func runDiff(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		return errors.New("usage: diff [FLAGS] FROM TO (e.g. \"diff 1.24 1.29\")")
+	}
+
+	tl, err := opts.Timeline(ctx)
+	if err != nil {
+		return err
+	}
+
+	diff, err := tl.Diff(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	if diff.Empty() {
+		fmt.Printf("Kubernetes %s and %s offer the same APIs.\n", diff.From, diff.To)
+		return nil
+	}
+
+	printDiffEntries("API Groups", diff.Groups)
+	printDiffEntries("API Versions", diff.Versions)
+	printDiffEntries("Resources", diff.Resources)
+
+	return nil
+}
+
+func printDiffEntries(title string, entries []timeline.DiffEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	fmt.Printf("%s:\n", title)
+
+	for _, entry := range entries {
+		marker := "~"
+		switch entry.Type {
+		case timeline.DiffAdded:
+			marker = "+"
+		case timeline.DiffRemoved:
+			marker = "-"
+		}
+
+		fmt.Printf("  %s %s\n", marker, entry)
+	}
+
+	fmt.Println()
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+func runExport(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+	format := "csv"
+	wide := false
+
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.StringVar(&format, "format", format, "The output format, one of \"csv\" or \"tsv\".")
+	fs.BoolVar(&wide, "wide", wide, "Include archived releases.")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("usage: export [-format csv|tsv] [-wide] [FLAGS]")
+	}
+
+	var separator rune
+
+	switch format {
+	case "csv":
+		separator = ','
+	case "tsv":
+		separator = '\t'
+	default:
+		return fmt.Errorf("unknown format %q, must be one of \"csv\" or \"tsv\"", format)
+	}
+
+	tl, err := opts.Timeline(ctx, timeline.WithArchived(wide))
+	if err != nil {
+		return fmt.Errorf("failed to create timeline: %w", err)
+	}
+
+	return tl.WriteAvailabilityCSV(os.Stdout, separator)
+}
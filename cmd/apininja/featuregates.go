@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+
+	"sigs.k8s.io/yaml"
+)
+
+const featureGatesHeader = `# SPDX-FileCopyrightText: 2023 Christoph Mewes
+# SPDX-License-Identifier: MIT
+
+# Feature gates that guard APIs. The stages follow the upstream feature gate
+# reference (https://kubernetes.io/docs/reference/command-line-tools-reference/feature-gates/)
+# and can be refreshed via ` + "`apininja data feature-gates`" + `; the APIs are curated
+# by hand. Entries without a kind apply to all resources of the API version.
+
+`
+
+// featureGateDocument is the front matter of a page in the upstream feature
+// gate reference.
+type featureGateDocument struct {
+	Title  string                   `json:"title"`
+	Stages []types.FeatureGateStage `json:"stages"`
+}
+
+// runDataFeatureGates updates the stages of all feature gates in the
+// database from a checkout of the Kubernetes website, keeping the curated
+// API mappings.
+func runDataFeatureGates(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+
+	fs := flag.NewFlagSet("data feature-gates", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: data feature-gates [FLAGS] DIRECTORY (e.g. website/content/en/docs/reference/command-line-tools-reference/feature-gates)")
+	}
+
+	db, err := database.NewReleaseDatabase(opts.dataDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	existing, err := db.FeatureGates()
+	if err != nil {
+		return err
+	}
+
+	upstream, err := loadFeatureGateDocuments(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	gates := map[string]types.FeatureGate{}
+	for _, gate := range existing {
+		gates[gate.Name] = gate
+	}
+
+	for _, doc := range upstream {
+		gate := gates[doc.Title]
+		gate.Name = doc.Title
+		gate.Stages = doc.Stages
+		gates[doc.Title] = gate
+	}
+
+	merged := []types.FeatureGate{}
+	for _, gate := range gates {
+		merged = append(merged, gate)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Name < merged[j].Name
+	})
+
+	fmt.Fprintf(os.Stderr, "Updated %d feature gates from upstream, %d in total.\n", len(upstream), len(merged))
+
+	return os.WriteFile(filepath.Join(opts.dataDirectory, "featuregates.yaml"), encodeFeatureGates(merged), 0644)
+}
+
+// encodeFeatureGates writes the feature gates in the same layout as the
+// hand-written file; marshalling them would sort all keys alphabetically.
+func encodeFeatureGates(gates []types.FeatureGate) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(featureGatesHeader)
+
+	for i, gate := range gates {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		fmt.Fprintf(&buf, "- name: %s\n", gate.Name)
+		buf.WriteString("  stages:\n")
+
+		for _, stage := range gate.Stages {
+			fmt.Fprintf(&buf, "    - stage: %s\n", stage.Stage)
+			fmt.Fprintf(&buf, "      defaultValue: %v\n", stage.DefaultValue)
+			fmt.Fprintf(&buf, "      fromVersion: %q\n", stage.FromVersion)
+
+			if stage.ToVersion != "" {
+				fmt.Fprintf(&buf, "      toVersion: %q\n", stage.ToVersion)
+			}
+		}
+
+		if len(gate.APIs) > 0 {
+			buf.WriteString("  apis:\n")
+		}
+
+		for _, api := range gate.APIs {
+			fmt.Fprintf(&buf, "    - group: %s\n", api.Group)
+			fmt.Fprintf(&buf, "      version: %s\n", api.Version)
+
+			if api.Kind != "" {
+				fmt.Fprintf(&buf, "      kind: %s\n", api.Kind)
+			}
+		}
+	}
+
+	return buf.Bytes()
+}
+
+func loadFeatureGateDocuments(directory string) ([]featureGateDocument, error) {
+	filenames, err := filepath.Glob(filepath.Join(directory, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+
+	docs := []featureGateDocument{}
+	for _, filename := range filenames {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		frontMatter, ok := extractFrontMatter(content)
+		if !ok {
+			continue
+		}
+
+		doc := featureGateDocument{}
+		if err := yaml.Unmarshal(frontMatter, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+
+		// index pages and the like have no stages
+		if doc.Title == "" || len(doc.Stages) == 0 {
+			continue
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+// extractFrontMatter returns the YAML between the leading "---" lines of a
+// Markdown document.
+func extractFrontMatter(content []byte) ([]byte, bool) {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+
+	rest, found := bytes.CutPrefix(content, []byte("---\n"))
+	if !found {
+		return nil, false
+	}
+
+	frontMatter, _, found := bytes.Cut(rest, []byte("\n---"))
+	if !found {
+		return nil, false
+	}
+
+	return []byte(strings.TrimSpace(string(frontMatter))), true
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.xrstf.de/kube-api.ninja/pkg/graph"
+)
+
+func runGraph(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+	format := "dot"
+
+	fs := flag.NewFlagSet("graph", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.StringVar(&format, "format", format, "Output format, one of \"dot\" (Graphviz) or \"cytoscape\" (Cytoscape.js JSON).")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("usage: graph [-format dot|cytoscape] [FLAGS]")
+	}
+
+	if format != "dot" && format != "cytoscape" {
+		return fmt.Errorf("invalid format %q", format)
+	}
+
+	tl, err := opts.Timeline(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create timeline: %w", err)
+	}
+
+	g, err := graph.Build(tl)
+	if err != nil {
+		return fmt.Errorf("failed to build graph: %w", err)
+	}
+
+	if format == "cytoscape" {
+		return g.WriteCytoscape(os.Stdout)
+	}
+
+	return g.WriteDOT(os.Stdout)
+}
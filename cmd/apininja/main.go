@@ -0,0 +1,240 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/telemetry"
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+type command struct {
+	description string
+	run         func(ctx context.Context, args []string) error
+}
+
+var commands = map[string]command{
+	"clients": {
+		description: "show the client-go/controller-runtime versions for a Kubernetes release",
+		run:         runClients,
+	},
+	"audit": {
+		description: "check Kubernetes manifests for APIs that are unavailable or deprecated in a release",
+		run:         runAudit,
+	},
+	"compatibility": {
+		description: "print the range of releases that serve all APIs used by a set of manifests",
+		run:         runCompatibility,
+	},
+	"data": {
+		description: "maintain the release database (\"data merge\" combines multiple sources for a release, \"data index\" prepares it for HTTP hosting, \"data sqlite\" compiles a resource index for server mode, \"data feature-gates\" imports the upstream feature gates, \"data release-notes\" imports the API changes from the upstream changelogs)",
+		run:         runData,
+	},
+	"diff": {
+		description: "list the API groups, versions and resources that differ between two releases",
+		run:         runDiff,
+	},
+	"export": {
+		description: "export the availability matrix as one CSV/TSV row per resource, API version and release",
+		run:         runExport,
+	},
+	"graph": {
+		description: "export the evolution of API versions as a Graphviz or Cytoscape graph",
+		run:         runGraph,
+	},
+	"matrix": {
+		description: "print the availability matrix of API groups as a table",
+		run:         runMatrix,
+	},
+	"cves": {
+		description: "list the known security advisories for a Kubernetes release",
+		run:         runCVEs,
+	},
+	"report": {
+		description: "render the API changes between two releases as a Markdown or PDF document, grouped by API group",
+		run:         runReport,
+	},
+	"snapshot-diff": {
+		description: "list the API removals a cluster snapshot would face when upgrading",
+		run:         runSnapshotDiff,
+	},
+	"storage-versions": {
+		description: "list the releases in which resources started to be persisted in another API version",
+		run:         runStorageVersions,
+	},
+	"upgrade-path": {
+		description: "validate a multi-hop upgrade plan and list the API removals along the way",
+		run:         runUpgradePath,
+	},
+	"validate": {
+		description: "check the release database for schema violations and inconsistencies, like preferred versions that do not exist",
+		run:         runValidate,
+	},
+	"webhook": {
+		description: "run a validating admission webhook that warns about (or denies) APIs removed in an upcoming release",
+		run:         runWebhook,
+	},
+}
+
+type globalOptions struct {
+	dataDirectory string
+	verbose       bool
+}
+
+func (opts *globalOptions) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&opts.dataDirectory, "data", "data", "The directory (or http(s) URL) containing the release database.")
+	fs.BoolVar(&opts.verbose, "verbose", false, "Log progress while loading the database.")
+}
+
+func (opts *globalOptions) Logger() *slog.Logger {
+	level := slog.LevelWarn
+	if opts.verbose {
+		level = slog.LevelDebug
+	}
+
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+}
+
+func (opts *globalOptions) Database() (*database.ReleaseDatabase, error) {
+	if strings.HasPrefix(opts.dataDirectory, "http://") || strings.HasPrefix(opts.dataDirectory, "https://") {
+		return database.OpenSource(database.SourceConfig{
+			Kind:    "http",
+			Options: map[string]string{"url": opts.dataDirectory},
+		})
+	}
+
+	return database.NewReleaseDatabase(opts.dataDirectory)
+}
+
+func (opts *globalOptions) Timeline(ctx context.Context, extraOpts ...timeline.Option) (*timeline.Timeline, error) {
+	db, err := opts.Database()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	releases, err := db.LoadReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keps, err := db.KEPs()
+	if err != nil {
+		return nil, err
+	}
+
+	logger := opts.Logger()
+
+	return timeline.CreateTimeline(ctx, releases, append([]timeline.Option{
+		timeline.WithLogger(logger),
+		timeline.WithKEPs(keps...),
+		timeline.WithProgress(func(done int, total int, release string) {
+			logger.Debug("Merged release.", "release", release, "progress", fmt.Sprintf("%d/%d", done, total))
+		}),
+	}, extraOpts...)...)
+}
+
+// stringList is a flag that can be given multiple times.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// BuildTag is set by the Makefile.
+var BuildTag = "dev"
+
+func main() {
+	log.SetFlags(0)
+
+	enableTelemetry := false
+	telemetryEndpoint := os.Getenv("APININJA_TELEMETRY_ENDPOINT")
+	flag.BoolVar(&enableTelemetry, "telemetry", os.Getenv("APININJA_TELEMETRY") == "true", "Send anonymous usage statistics (command name and Kubernetes releases only; can also be enabled via $APININJA_TELEMETRY=true).")
+	flag.StringVar(&telemetryEndpoint, "telemetry-endpoint", telemetryEndpoint, "URL to send the usage statistics to (required with -telemetry; can also be set via $APININJA_TELEMETRY_ENDPOINT).")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	name := flag.Arg(0)
+	args := flag.Args()[1:]
+
+	cmd, exists := commands[name]
+	if !exists {
+		printUsage()
+		os.Exit(1)
+	}
+
+	reporter, err := telemetry.NewReporter(enableTelemetry, telemetryEndpoint)
+	if err != nil {
+		log.Printf("Error: %v (set -telemetry-endpoint or $APININJA_TELEMETRY_ENDPOINT)", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	err = cmd.run(ctx, args)
+
+	if terr := reporter.Report(ctx, telemetry.NewEvent(name, args, BuildTag)); terr != nil {
+		// never fail because of telemetry
+		log.Printf("Warning: failed to send usage statistics: %v", terr)
+	}
+
+	if err != nil {
+		log.Printf("Error: %v", err)
+		os.Exit(exitCode(err))
+	}
+}
+
+// exitCode maps well-known errors to distinct exit codes, so that
+// scripts can react to them.
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, database.ErrUnknownRelease):
+		return 2
+	case errors.Is(err, database.ErrMalformedVersion):
+		return 3
+	case errors.Is(err, database.ErrMissingEOL):
+		return 4
+	case errors.Is(err, database.ErrMalformedDate):
+		return 5
+	default:
+		return 1
+	}
+}
+
+func printUsage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [-telemetry -telemetry-endpoint URL] COMMAND [FLAGS] [ARGS]\n\nAvailable commands:\n\n", os.Args[0])
+
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-15s %s\n", name, commands[name].description)
+	}
+
+	fmt.Fprintln(os.Stderr, "\nExit codes: 1 = general error, 2 = unknown release, 3 = malformed version, 4 = missing EOL date, 5 = malformed date")
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+const (
+	cellPreferred = "■"
+	cellAvailable = "□"
+	cellMissing   = "·"
+)
+
+func runMatrix(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+	groups := ""
+	wide := false
+
+	fs := flag.NewFlagSet("matrix", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.StringVar(&groups, "group", groups, "Comma-separated list of API groups to show (e.g. \"apps,networking.k8s.io\"; default: all).")
+	fs.BoolVar(&wide, "wide", wide, "Include archived releases and list every resource.")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("usage: matrix [-group GROUPS] [-wide] [FLAGS]")
+	}
+
+	tl, err := opts.Timeline(ctx, timeline.WithArchived(wide))
+	if err != nil {
+		return fmt.Errorf("failed to create timeline: %w", err)
+	}
+
+	selected := map[string]bool{}
+	for _, group := range strings.Split(groups, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			selected[group] = true
+		}
+	}
+
+	for group := range selected {
+		found := false
+		for _, apiGroup := range tl.APIGroups {
+			found = found || apiGroup.Name == group
+		}
+
+		if !found {
+			return fmt.Errorf("unknown API group %q", group)
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+
+	fmt.Fprint(w, "API\t")
+	for _, rel := range tl.Releases {
+		fmt.Fprintf(w, "%s\t", rel.Version)
+	}
+	fmt.Fprintln(w)
+
+	for _, apiGroup := range tl.APIGroups {
+		if len(selected) > 0 && !selected[apiGroup.Name] {
+			continue
+		}
+
+		printMatrixRow(w, tl, apiGroup.Name, func(release string) string {
+			if apiGroup.PreferredVersion(release) != "" {
+				return cellPreferred
+			}
+			return cellMissing
+		})
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			printMatrixRow(w, tl, "  "+apiVersion.Version, func(release string) string {
+				switch {
+				case apiGroup.PreferredVersion(release) == apiVersion.Version:
+					return cellPreferred
+				case apiVersion.HasRelease(release):
+					return cellAvailable
+				default:
+					return cellMissing
+				}
+			})
+
+			if !wide {
+				continue
+			}
+
+			for _, apiResource := range apiVersion.Resources {
+				printMatrixRow(w, tl, "    "+apiResource.Kind, func(release string) string {
+					if apiResource.HasRelease(release) {
+						return cellAvailable
+					}
+					return cellMissing
+				})
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%s preferred version   %s available   %s not available\n", cellPreferred, cellAvailable, cellMissing)
+
+	return nil
+}
+
+func printMatrixRow(w io.Writer, tl *timeline.Timeline, name string, cell func(release string) string) {
+	fmt.Fprintf(w, "%s\t", name)
+	for _, rel := range tl.Releases {
+		fmt.Fprintf(w, "%s\t", cell(rel.Version))
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/releasenotes"
+)
+
+// runDataReleaseNotes imports the API-related entries of the upstream
+// changelogs into the releases they belong to.
+func runDataReleaseNotes(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+
+	fs := flag.NewFlagSet("data release-notes", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return errors.New("usage: data release-notes [FLAGS] CHANGELOG [CHANGELOG…] (e.g. kubernetes/CHANGELOG/CHANGELOG-1.29.md)")
+	}
+
+	db, err := database.NewReleaseDatabase(opts.dataDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	for _, filename := range fs.Args() {
+		release := releasenotes.ReleaseFromFilename(filename)
+		if release == "" {
+			return fmt.Errorf("cannot determine release of %s, expected a file named like CHANGELOG-1.29.md", filename)
+		}
+
+		if _, err := db.Release(release); err != nil {
+			return err
+		}
+
+		f, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+
+		notes, err := releasenotes.Parse(f, release)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+
+		encoded, err := json.MarshalIndent(notes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode release notes: %w", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(opts.dataDirectory, "releases", release, "releasenotes.json"), append(encoded, '\n'), 0644); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Imported %d release notes for %s.\n", len(notes), release)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/pdf"
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+func runReport(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+	format := "markdown"
+
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.StringVar(&format, "format", format, "The output format, one of \"markdown\" or \"pdf\".")
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		return errors.New("usage: report [-format markdown|pdf] [FLAGS] FROM TO (e.g. \"report 1.27 1.30\")")
+	}
+
+	if format != "markdown" && format != "pdf" {
+		return fmt.Errorf("unknown format %q, must be one of \"markdown\" or \"pdf\"", format)
+	}
+
+	tl, err := opts.Timeline(ctx)
+	if err != nil {
+		return err
+	}
+
+	diff, err := tl.Diff(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	if format == "pdf" {
+		return writePDFReport(os.Stdout, diff, time.Now())
+	}
+
+	return diff.WriteMarkdown(os.Stdout)
+}
+
+// writePDFReport renders a printable upgrade impact assessment, which can
+// be attached to change-management tickets.
+func writePDFReport(w io.Writer, diff *timeline.ReleaseDiff, now time.Time) error {
+	doc := pdf.New(fmt.Sprintf("Kubernetes API changes from %s to %s", diff.From, diff.To))
+
+	doc.Heading(fmt.Sprintf("Kubernetes API changes from %s to %s", diff.From, diff.To))
+	doc.Text(fmt.Sprintf("Generated on %s by kube-api.ninja.", now.Format("2006-01-02")))
+
+	if diff.Empty() {
+		doc.Text(fmt.Sprintf("Kubernetes %s and %s offer the same APIs.", diff.From, diff.To))
+		return doc.Write(w)
+	}
+
+	groups := diff.ByGroup()
+	added, removed, changed := diff.Count(timeline.DiffAdded), diff.Count(timeline.DiffRemoved), diff.Count(timeline.DiffChanged)
+
+	doc.Subheading("Summary")
+	doc.Text(fmt.Sprintf("%d changes in %d API groups (%d added, %d removed, %d changed).", added+removed+changed, len(groups), added, removed, changed))
+
+	doc.Subheading("Action required")
+
+	removals := []string{}
+	for _, entry := range diff.Resources {
+		if entry.Type == timeline.DiffRemoved {
+			removals = append(removals, fmt.Sprintf("[ ] migrate away from %s %s", entry.APIVersion(), entry.Kind))
+		}
+	}
+
+	if len(removals) == 0 {
+		doc.Text(fmt.Sprintf("No API resources are removed between %s and %s.", diff.From, diff.To))
+	} else {
+		doc.Code(strings.Join(removals, "\n"))
+	}
+
+	for _, group := range groups {
+		doc.Subheading(group.Name)
+
+		for _, entry := range group.Entries {
+			name := entry.Group
+			if entry.Version != "" {
+				name = strings.TrimSpace(entry.APIVersion() + " " + entry.Kind)
+			}
+
+			doc.Code(strings.TrimSpace(fmt.Sprintf("%-8s %-50s %s", entry.Type, name, strings.Join(entry.Details, ", "))))
+		}
+	}
+
+	return doc.Write(w)
+}
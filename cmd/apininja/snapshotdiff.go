@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.xrstf.de/kube-api.ninja/pkg/kubectldumper"
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+func runSnapshotDiff(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+	target := ""
+	release := ""
+
+	fs := flag.NewFlagSet("snapshot-diff", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.StringVar(&target, "target", target, "The Kubernetes release to upgrade to (e.g. \"1.29\").")
+	fs.StringVar(&release, "release", release, "The cluster's Kubernetes release, required for kubectl output (e.g. \"1.27\").")
+	fs.Parse(args)
+
+	// allow flags after the filename, like "snapshot-diff snapshot.json -target 1.29"
+	filename := fs.Arg(0)
+	if fs.NArg() > 1 {
+		fs.Parse(fs.Args()[1:])
+	}
+
+	if filename == "" || target == "" || fs.NArg() != 0 {
+		return errors.New("usage: snapshot-diff SNAPSHOT -target RELEASE [FLAGS] (create snapshots using the clusterdumper, `kubectl api-resources` or `kubectl api-versions`)")
+	}
+
+	snapshot, err := loadAPI(filename)
+	if err != nil {
+		return err
+	}
+
+	if release != "" {
+		snapshot.Release = release
+	}
+
+	if snapshot.Release == "" {
+		return errors.New("snapshot does not contain the cluster's release, specify it using -release")
+	}
+
+	tl, err := opts.Timeline(ctx)
+	if err != nil {
+		return err
+	}
+
+	report, err := tl.CompareSnapshot(snapshot, target)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Cluster %s → %s\n\n", report.Release, report.Target)
+
+	if len(report.Removals) == 0 {
+		fmt.Println("  ✓ no API removals")
+	}
+
+	for _, removal := range report.Removals {
+		if removal.Alternative != "" {
+			fmt.Printf("  [ ] migrate away from %s (removed in %s, use %s instead)\n", removal, removal.RemovedIn, removal.Alternative)
+		} else {
+			fmt.Printf("  [ ] migrate away from %s (removed in %s)\n", removal, removal.RemovedIn)
+		}
+	}
+
+	if len(report.Unknown) > 0 {
+		fmt.Printf("\n%d API resources are not part of Kubernetes (CRDs or aggregated APIs) and were not checked.\n", len(report.Unknown))
+	}
+
+	return nil
+}
+
+// loadAPI reads either a JSON file created by the clusterdumper or the
+// plain-text output of kubectl.
+func loadAPI(filename string) (*types.KubernetesAPI, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(content), []byte("{")) {
+		snapshot, err := kubectldumper.Dump(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse kubectl output: %w", err)
+		}
+
+		return snapshot, nil
+	}
+
+	snapshot := &types.KubernetesAPI{}
+	if err := json.Unmarshal(content, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
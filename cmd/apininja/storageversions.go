@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+func runStorageVersions(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+	group := ""
+
+	fs := flag.NewFlagSet("storage-versions", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.StringVar(&group, "group", group, "Only list changes for this API group (e.g. \"apps\").")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("usage: storage-versions [-group GROUP] [FLAGS]")
+	}
+
+	tl, err := opts.Timeline(ctx, timeline.WithArchived(true))
+	if err != nil {
+		return fmt.Errorf("failed to create timeline: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RELEASE\tGROUP\tKIND\tFROM\tTO")
+
+	found := false
+	changes := 0
+
+	for _, apiGroup := range tl.APIGroups {
+		if group != "" && apiGroup.Name != group {
+			continue
+		}
+
+		found = true
+
+		for _, change := range apiGroup.StorageVersionChanges {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", change.Release, apiGroup.Name, change.Kind, change.From, change.To)
+			changes++
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("unknown API group %q", group)
+	}
+
+	if changes == 0 {
+		fmt.Println("No storage version changes are known (storage versions are only recorded for releases dumped via the clusterdumper).")
+		return nil
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+func runUpgradePath(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+
+	fs := flag.NewFlagSet("upgrade-path", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.Parse(args)
+
+	// allow both "1.24 1.25 1.26" and "1.24→1.25→1.26"
+	releases := []string{}
+	for _, arg := range fs.Args() {
+		arg = strings.ReplaceAll(arg, "→", ",")
+		arg = strings.ReplaceAll(arg, "->", ",")
+
+		for _, release := range strings.Split(arg, ",") {
+			if release = strings.TrimSpace(release); release != "" {
+				releases = append(releases, release)
+			}
+		}
+	}
+
+	if len(releases) < 2 {
+		return errors.New("usage: upgrade-path [FLAGS] RELEASE RELEASE [RELEASE…] (e.g. \"upgrade-path 1.24 1.25 1.26\")")
+	}
+
+	tl, err := opts.Timeline(ctx)
+	if err != nil {
+		return err
+	}
+
+	plan, err := tl.ValidateUpgradePath(releases...)
+	if err != nil {
+		return err
+	}
+
+	for _, hop := range plan.Hops {
+		fmt.Printf("%s → %s\n", hop.From, hop.To)
+
+		for _, problem := range hop.Problems {
+			fmt.Printf("  ✗ %s\n", problem)
+		}
+
+		if len(hop.Removals) == 0 {
+			fmt.Println("  ✓ no API removals")
+		}
+
+		for _, removal := range hop.Removals {
+			fmt.Printf("  [ ] migrate away from %s (removed in %s)\n", removal, removal.RemovedIn)
+		}
+
+		fmt.Println()
+	}
+
+	if !plan.Valid() {
+		return errors.New("upgrade path violates the version skew policy")
+	}
+
+	return nil
+}
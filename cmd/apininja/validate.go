@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+)
+
+func runValidate(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.Parse(args)
+
+	db, err := opts.Database()
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	problems, err := db.Validate(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to validate database: %w", err)
+	}
+
+	if len(problems) == 0 {
+		fmt.Println("✓ no problems found")
+		return nil
+	}
+
+	for _, problem := range problems {
+		fmt.Printf("✗ %s\n", problem)
+	}
+
+	return fmt.Errorf("found %d problem(s) in the release database", len(problems))
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/admission"
+)
+
+const webhookPath = "/validate"
+
+func runWebhook(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+	target := ""
+	deny := false
+	listen := ":8443"
+	certFile := ""
+	keyFile := ""
+
+	fs := flag.NewFlagSet("webhook", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.StringVar(&target, "target", target, "The Kubernetes release the cluster will be upgraded to (e.g. \"1.29\").")
+	fs.BoolVar(&deny, "deny", deny, "Deny objects using APIs that are not served by the target release instead of only warning.")
+	fs.StringVar(&listen, "listen", listen, "The address to listen on.")
+	fs.StringVar(&certFile, "tls-cert", certFile, "The TLS certificate to serve (the API server only talks HTTPS to webhooks).")
+	fs.StringVar(&keyFile, "tls-key", keyFile, "The TLS certificate's private key.")
+	fs.Parse(args)
+
+	if target == "" || certFile == "" || keyFile == "" {
+		return errors.New("usage: webhook -target RELEASE -tls-cert FILE -tls-key FILE [FLAGS]")
+	}
+
+	tl, err := opts.Timeline(ctx)
+	if err != nil {
+		return err
+	}
+
+	webhook, err := admission.NewWebhook(tl, target, deny)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(webhookPath, webhook)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+
+	srv := &http.Server{
+		Addr:              listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Reviewing objects against Kubernetes %s on %s%s…", target, listen, webhookPath)
+
+	if err := srv.ListenAndServeTLS(certFile, keyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
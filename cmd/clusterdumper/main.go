@@ -4,11 +4,15 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"time"
 
 	"go.xrstf.de/kube-api.ninja/pkg/dumper"
 
@@ -18,11 +22,17 @@ import (
 )
 
 type appOptions struct {
-	kubeconfig string
+	kubeconfig  string
+	outputDir   string
+	release     string
+	releaseDate string
 }
 
 func (opts *appOptions) AddFlags(fs *flag.FlagSet) {
 	flag.StringVar(&opts.kubeconfig, "kubeconfig", "", "The kubeconfig to dump the API from (defaults to $KUBECONFIG).")
+	flag.StringVar(&opts.outputDir, "output-dir", "", "If set, store the API as a release in this data directory (e.g. \"data\") instead of printing it.")
+	flag.StringVar(&opts.release, "release", "", "Override the minor release reported by the cluster (e.g. \"1.28\").")
+	flag.StringVar(&opts.releaseDate, "release-date", "", "The release date (YYYY-MM-DD) to record for new releases in -output-dir (defaults to today).")
 }
 
 func (opts *appOptions) Validate() error {
@@ -34,6 +44,12 @@ func (opts *appOptions) Validate() error {
 		}
 	}
 
+	if opts.releaseDate != "" {
+		if _, err := time.Parse("2006-01-02", opts.releaseDate); err != nil {
+			return fmt.Errorf("invalid -release-date: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -64,13 +80,35 @@ func main() {
 		log.Fatalf("Failed to build discovery client: %v", err)
 	}
 
-	releaseData, err := dumper.DumpClusterData(discoveryClient)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	releaseData, err := dumper.DumpClusterData(ctx, discoveryClient)
 	if err != nil {
 		log.Fatalf("Failed to dump cluster info: %v", err)
 	}
 
 	releaseData.Sort()
 
+	if opts.release != "" {
+		releaseData.Release = opts.release
+	}
+
+	if opts.outputDir != "" {
+		releaseDate := time.Now()
+		if opts.releaseDate != "" {
+			releaseDate, _ = time.Parse("2006-01-02", opts.releaseDate)
+		}
+
+		releaseDir, err := dumper.WriteReleaseDirectory(opts.outputDir, releaseData, releaseDate)
+		if err != nil {
+			log.Fatalf("Failed to write release: %v", err)
+		}
+
+		log.Printf("Stored release %s in %s.", releaseData.Release, releaseDir)
+		return
+	}
+
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
 
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"os"
+
+	"go.xrstf.de/kube-api.ninja/pkg/conformancedumper"
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+type appOptions struct {
+	conformanceFile string
+	apiFile         string
+}
+
+func (opts *appOptions) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&opts.conformanceFile, "conformance-file", "", "The conformance.yaml file to read.")
+	fs.StringVar(&opts.apiFile, "api-file", "", "The api.json file of the release the conformance tests belong to.")
+}
+
+func (opts *appOptions) Validate() error {
+	if opts.conformanceFile == "" {
+		return errors.New("no -conformance-file specified")
+	}
+
+	if opts.apiFile == "" {
+		return errors.New("no -api-file specified")
+	}
+
+	return nil
+}
+
+func main() {
+	opts := appOptions{}
+
+	opts.AddFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := opts.Validate(); err != nil {
+		log.Fatalf("Invalid command line: %v", err)
+	}
+
+	f, err := os.Open(opts.apiFile)
+	if err != nil {
+		log.Fatalf("Failed to open API file: %v", err)
+	}
+	defer f.Close()
+
+	api := &types.KubernetesAPI{}
+	if err := json.NewDecoder(f).Decode(api); err != nil {
+		log.Fatalf("Failed to parse API file: %v", err)
+	}
+
+	coverage, err := conformancedumper.DumpConformanceData(opts.conformanceFile, api)
+	if err != nil {
+		log.Fatalf("Failed to dump conformance data: %v", err)
+	}
+
+	coverage.Sort()
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(coverage); err != nil {
+		log.Fatalf("Failed to JSON encode result: %v", err)
+	}
+}
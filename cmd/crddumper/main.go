@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"go.xrstf.de/kube-api.ninja/pkg/crddumper"
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+type appOptions struct {
+	projectVersion string
+}
+
+func (opts *appOptions) AddFlags(fs *flag.FlagSet) {
+	flag.StringVar(&opts.projectVersion, "project-version", "", "The version of the project the CRDs belong to (e.g. \"1.13.2\").")
+}
+
+func (opts *appOptions) Validate() error {
+	if opts.projectVersion == "" {
+		return errors.New("no -project-version specified")
+	}
+
+	if _, err := version.ParseSemver(opts.projectVersion); err != nil {
+		return fmt.Errorf("invalid project version: %w", err)
+	}
+
+	if flag.NArg() == 0 {
+		return errors.New("no CRD files specified")
+	}
+
+	return nil
+}
+
+func main() {
+	opts := appOptions{}
+
+	opts.AddFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := opts.Validate(); err != nil {
+		log.Fatalf("Invalid command line: %v", err)
+	}
+
+	releaseData, err := crddumper.DumpCRDs(flag.Args(), opts.projectVersion)
+	if err != nil {
+		log.Fatalf("Failed to dump CRDs: %v", err)
+	}
+
+	releaseData.Sort()
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(releaseData); err != nil {
+		log.Fatalf("Failed to JSON encode result: %v", err)
+	}
+}
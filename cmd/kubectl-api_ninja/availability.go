@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var errResourceUnavailable = errors.New("resource is not available")
+
+func runAvailability(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+
+	fs := flag.NewFlagSet("availability", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: availability [FLAGS] RESOURCE")
+	}
+
+	tl, err := opts.Timeline(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load timeline: %w", err)
+	}
+
+	availability, err := tl.Availability(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "API VERSION\tRELEASES\tSTATUS")
+
+	for _, v := range availability.Versions {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", groupVersion(availability.Group, v.Version), releaseRange(v.Releases), versionStatus(v))
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	release, source, err := opts.Release()
+	if err != nil {
+		// the table is useful even without a cluster
+		fmt.Fprintf(os.Stderr, "\nCould not detect the cluster's version: %v\n", err)
+		return nil
+	}
+
+	if !tl.HasRelease(release) {
+		return fmt.Errorf("unknown Kubernetes release %s", release)
+	}
+
+	fmt.Println()
+
+	served := availability.ServedIn(release)
+	if len(served) == 0 {
+		fmt.Printf("%s are not available in Kubernetes %s (%s).\n", availability.Plural, release, source)
+		return errResourceUnavailable
+	}
+
+	for i, v := range served {
+		served[i] = groupVersion(availability.Group, v)
+	}
+
+	fmt.Printf("Kubernetes %s (%s) serves %s as %s.\n", release, source, availability.Plural, strings.Join(served, ", "))
+
+	return nil
+}
+
+// detectClusterRelease determines the minor release of the cluster the
+// kubeconfig points to, using the same loading rules as kubectl.
+func detectClusterRelease(kubeconfig string, context string) (string, string, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{
+		CurrentContext: context,
+	})
+
+	rawConfig, err := clientConfig.RawConfig()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build REST config: %w", err)
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create discovery client: %w", err)
+	}
+
+	serverVersion, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to determine server version: %w", err)
+	}
+
+	parsed, err := version.ParseSemver(serverVersion.GitVersion)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid server version %q: %w", serverVersion.GitVersion, err)
+	}
+
+	contextName := context
+	if contextName == "" {
+		contextName = rawConfig.CurrentContext
+	}
+
+	return parsed.MajorMinor(), fmt.Sprintf("context %q", contextName), nil
+}
+
+func versionStatus(v timeline.VersionAvailability) string {
+	status := []string{}
+
+	if v.DeprecatedIn != "" {
+		status = append(status, "deprecated in "+v.DeprecatedIn)
+	}
+
+	if v.RemovedIn != "" {
+		status = append(status, "removed in "+v.RemovedIn)
+	}
+
+	if v.PredictedRemovalIn != "" {
+		status = append(status, "removal predicted for "+v.PredictedRemovalIn)
+	}
+
+	if v.SucceededBy != nil {
+		status = append(status, "succeeded by "+groupVersion(v.SucceededBy.Group, v.SucceededBy.Version))
+	}
+
+	if len(status) == 0 {
+		return "served"
+	}
+
+	return strings.Join(status, ", ")
+}
+
+func releaseRange(releases []string) string {
+	switch len(releases) {
+	case 0:
+		return "-"
+	case 1:
+		return releases[0]
+	default:
+		return fmt.Sprintf("%s – %s", releases[0], releases[len(releases)-1])
+	}
+}
+
+func groupVersion(group, version string) string {
+	if group == "core" {
+		return version
+	}
+
+	return fmt.Sprintf("%s/%s", group, version)
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+)
+
+func runCategory(ctx context.Context, args []string) error {
+	opts := globalOptions{}
+
+	fs := flag.NewFlagSet("category", flag.ExitOnError)
+	opts.AddFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: category [FLAGS] CATEGORY")
+	}
+
+	release, source, err := opts.Release()
+	if err != nil {
+		return fmt.Errorf("failed to detect the cluster's version (use -release to skip the detection): %w", err)
+	}
+
+	tl, err := opts.Timeline(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load timeline: %w", err)
+	}
+
+	if !tl.HasRelease(release) {
+		return fmt.Errorf("unknown Kubernetes release %s", release)
+	}
+
+	category := fs.Arg(0)
+
+	members := tl.CategoryMembers(category, release)
+	if len(members) == 0 {
+		return fmt.Errorf("no resources are known to be in category %q in Kubernetes %s (%s)", category, release, source)
+	}
+
+	for _, member := range members {
+		fmt.Println(member)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// kubectl-api_ninja is a kubectl plugin; once it is in the $PATH, it can be
+// invoked as "kubectl api-ninja".
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+
+	"go.xrstf.de/kube-api.ninja/pkg/client"
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+type command struct {
+	description string
+	run         func(ctx context.Context, args []string) error
+}
+
+var commands = map[string]command{
+	"availability": {
+		description: "show which API versions offer a resource (e.g. \"deployments.apps\") in which Kubernetes releases",
+		run:         runAvailability,
+	},
+	"category": {
+		description: "list the resources in a category (e.g. \"all\") for the cluster's release",
+		run:         runCategory,
+	},
+}
+
+type globalOptions struct {
+	server        string
+	dataDirectory string
+	kubeconfig    string
+	context       string
+	release       string
+}
+
+func (opts *globalOptions) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&opts.server, "server", client.DefaultBaseURL, "The kube-api.ninja site to fetch the timeline.json from (the public site, any statically hosted render output or \"render -listen\").")
+	fs.StringVar(&opts.dataDirectory, "data", "", "Use a local release database instead of querying a server.")
+	fs.StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file used to detect the cluster's version (defaults to $KUBECONFIG or ~/.kube/config).")
+	fs.StringVar(&opts.context, "context", "", "The kubeconfig context to use.")
+	fs.StringVar(&opts.release, "release", "", "Compare against this Kubernetes release (e.g. \"1.28\") instead of detecting the cluster's version.")
+}
+
+func (opts *globalOptions) Timeline(ctx context.Context) (*timeline.Timeline, error) {
+	if opts.dataDirectory == "" {
+		c, err := client.New(opts.server, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.Timeline(ctx)
+	}
+
+	db, err := database.NewReleaseDatabase(opts.dataDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	releases, err := db.LoadReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return timeline.CreateTimeline(ctx, releases)
+}
+
+// Release returns the release given via -release or otherwise the release
+// of the cluster, plus a description where it came from.
+func (opts *globalOptions) Release() (string, string, error) {
+	if opts.release != "" {
+		return opts.release, "-release", nil
+	}
+
+	return detectClusterRelease(opts.kubeconfig, opts.context)
+}
+
+func main() {
+	log.SetFlags(0)
+
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	cmd, exists := commands[flag.Arg(0)]
+	if !exists {
+		printUsage()
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if err := cmd.run(ctx, flag.Args()[1:]); err != nil {
+		log.Printf("Error: %v", err)
+
+		if errors.Is(err, errResourceUnavailable) {
+			os.Exit(2)
+		}
+
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintf(os.Stderr, "Usage: kubectl api-ninja COMMAND [FLAGS] [ARGS]\n\nAvailable commands:\n\n")
+
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-15s %s\n", name, commands[name].description)
+	}
+
+	fmt.Fprintln(os.Stderr, "\nExit codes: 1 = general error, 2 = resource is not available in the cluster's release")
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"go.xrstf.de/kube-api.ninja/pkg/openapidumper"
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+
+	"k8s.io/client-go/discovery"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type appOptions struct {
+	specDirectory     string
+	kubeconfig        string
+	kubernetesVersion string
+}
+
+func (opts *appOptions) AddFlags(fs *flag.FlagSet) {
+	flag.StringVar(&opts.specDirectory, "spec-directory", "", "The directory containing the OpenAPI v3 documents (like api/openapi-spec/v3/ in the Kubernetes repository).")
+	flag.StringVar(&opts.kubeconfig, "kubeconfig", "", "Fetch the OpenAPI v3 documents from the /openapi/v3 endpoint of this cluster instead.")
+	flag.StringVar(&opts.kubernetesVersion, "kubernetes-version", "", "The Kubernetes version the OpenAPI documents belong to (defaults to the cluster's version when using -kubeconfig).")
+}
+
+func (opts *appOptions) Validate() error {
+	if (opts.specDirectory == "") == (opts.kubeconfig == "") {
+		return errors.New("exactly one of -spec-directory or -kubeconfig must be specified")
+	}
+
+	if opts.kubernetesVersion == "" {
+		if opts.kubeconfig == "" {
+			return errors.New("no -kubernetes-version specified")
+		}
+
+		return nil
+	}
+
+	if _, err := version.ParseSemver(opts.kubernetesVersion); err != nil {
+		return fmt.Errorf("invalid Kubernetes version: %w", err)
+	}
+
+	return nil
+}
+
+func main() {
+	opts := appOptions{}
+
+	opts.AddFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := opts.Validate(); err != nil {
+		log.Fatalf("Invalid command line: %v", err)
+	}
+
+	var (
+		releaseData *types.KubernetesAPI
+		err         error
+	)
+
+	if opts.kubeconfig != "" {
+		releaseData, err = dumpCluster(opts.kubeconfig, opts.kubernetesVersion)
+	} else {
+		releaseData, err = openapidumper.DumpOpenAPISpecs(opts.specDirectory, opts.kubernetesVersion)
+	}
+
+	if err != nil {
+		log.Fatalf("Failed to dump OpenAPI documents: %v", err)
+	}
+
+	releaseData.Sort()
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(releaseData); err != nil {
+		log.Fatalf("Failed to JSON encode result: %v", err)
+	}
+}
+
+func dumpCluster(kubeconfig string, kubernetesVersion string) (*types.KubernetesAPI, error) {
+	config, err := clientcmd.LoadFromFile(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	restConfig, err := clientcmd.NewDefaultClientConfig(*config, nil).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build REST config: %w", err)
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build discovery client: %w", err)
+	}
+
+	if kubernetesVersion == "" {
+		server, err := discoveryClient.ServerVersion()
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover cluster version: %w", err)
+		}
+
+		kubernetesVersion = server.GitVersion
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	return openapidumper.DumpLiveOpenAPI(ctx, discoveryClient.OpenAPIV3(), kubernetesVersion)
+}
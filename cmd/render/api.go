@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/schema"
+	"go.xrstf.de/kube-api.ninja/pkg/view"
+)
+
+const apiPrefix = "/api/v1/"
+
+// handleAPI serves the timeline as JSON, in the same structure as the
+// timeline.json export:
+//
+//	/api/v1/timeline        the entire timeline (supports the same view
+//	                        parameters as the index page, e.g. ?groups=apps)
+//	/api/v1/releases        the metadata of all releases
+//	/api/v1/releases/1.28   the metadata of a single release
+//	/api/v1/groups          the names of all API groups
+//	/api/v1/groups/apps     a single API group
+//	/api/v1/resources/apps/deployments
+//	                        all versions of a resource in all releases (by
+//	                        kind or plural name), only with -resource-index
+//	/api/v1/openapi.json    the OpenAPI document describing these endpoints
+//
+// When serving from a resource index, the timeline is not loaded and only
+// the resources and openapi.json endpoints are available.
+func (s *server) handleAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeAPIError(w, http.StatusMethodNotAllowed, "only GET requests are supported")
+		return
+	}
+
+	resource, name, _ := strings.Cut(strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/"), "/")
+
+	switch {
+	case resource == "resources" && strings.Contains(name, "/"):
+		s.handleResource(w, r, name)
+		return
+
+	case resource == "openapi.json" && name == "":
+		s.writeAPIResponse(w, r, schema.OpenAPI(strings.TrimSuffix(apiPrefix, "/")))
+		return
+
+	case s.data == nil:
+		writeAPIError(w, http.StatusNotFound, "unknown API endpoint (only resources are available when serving from a resource index)")
+		return
+	}
+
+	tl := s.data.Timeline
+
+	switch {
+	case resource == "timeline" && name == "":
+		state, err := apiViewState(r)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.writeAPIResponse(w, r, state.Apply(tl))
+
+	case resource == "releases" && name == "":
+		s.writeAPIResponse(w, r, tl.Releases)
+
+	case resource == "releases":
+		for _, release := range tl.Releases {
+			if release.Version == name {
+				s.writeAPIResponse(w, r, release)
+				return
+			}
+		}
+
+		writeAPIError(w, http.StatusNotFound, "unknown release "+name)
+
+	case resource == "groups" && name == "":
+		names := []string{}
+		for _, group := range tl.APIGroups {
+			names = append(names, group.Name)
+		}
+
+		s.writeAPIResponse(w, r, names)
+
+	case resource == "groups":
+		for _, group := range tl.APIGroups {
+			if group.Name == name {
+				s.writeAPIResponse(w, r, group)
+				return
+			}
+		}
+
+		writeAPIError(w, http.StatusNotFound, "unknown API group "+name)
+
+	default:
+		writeAPIError(w, http.StatusNotFound, "unknown API endpoint")
+	}
+}
+
+// handleResource looks up a single resource ("apps/deployments") in the
+// resource index, so the timeline does not need to be consulted.
+func (s *server) handleResource(w http.ResponseWriter, r *http.Request, name string) {
+	if s.resources == nil {
+		writeAPIError(w, http.StatusNotFound, "no resource index configured")
+		return
+	}
+
+	group, kind, _ := strings.Cut(name, "/")
+	if group == "core" {
+		group = ""
+	}
+
+	resources, err := s.resources.Resource(r.Context(), group, kind)
+	if err != nil {
+		log.Printf("Failed to query resource index: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to query resource index")
+		return
+	}
+
+	if len(resources) == 0 {
+		writeAPIError(w, http.StatusNotFound, "unknown resource "+name)
+		return
+	}
+
+	s.writeAPIResponse(w, r, resources)
+}
+
+// apiViewState allows to filter the timeline like personalized views do,
+// either via the encoded view or the human-friendly parameters.
+func apiViewState(r *http.Request) (*view.State, error) {
+	query := r.URL.Query()
+
+	if encoded := query.Get(view.QueryParameter); encoded != "" {
+		return view.Decode(encoded)
+	}
+
+	return &view.State{
+		Pinned:     splitList(query.Get("pin")),
+		Groups:     splitList(query.Get("groups")),
+		StableOnly: query.Get("stable") == "true",
+	}, nil
+}
+
+func (s *server) writeAPIResponse(w http.ResponseWriter, r *http.Request, data any) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Failed to encode API response: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to encode response")
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	s.serveContent(w, r, "application/json", encoded)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
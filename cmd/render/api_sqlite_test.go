@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+//go:build sqlite
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+)
+
+func TestAPIFromResourceIndex(t *testing.T) {
+	fsys := fstest.MapFS{
+		"releases/1.29/api.json": &fstest.MapFile{Data: []byte(`{"version": "1.29.0", "release": "1.29", "apiGroups": [{"name": "apps", "preferredVersion": "v1", "apiVersions": [{"version": "v1", "resources": [{"kind": "Deployment", "singular": "deployment", "plural": "deployments", "namespaced": true}]}]}]}`)},
+	}
+
+	filename := filepath.Join(t.TempDir(), "index.sqlite")
+	if err := database.BuildResourceIndex(context.Background(), database.NewReleaseDatabaseFromFS(fsys), filename); err != nil {
+		t.Fatalf("Failed to build index: %v", err)
+	}
+
+	index, err := database.OpenResourceIndex(filename)
+	if err != nil {
+		t.Fatalf("Failed to open index: %v", err)
+	}
+	defer index.Close()
+
+	s := &server{metrics: newMetrics(), resources: index}
+
+	rec := httptest.NewRecorder()
+	s.handleAPI(rec, httptest.NewRequest(http.MethodGet, "/api/v1/resources/apps/deployments", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	resources := []database.IndexedResource{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resources); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(resources) != 1 || resources[0].Kind != "Deployment" || resources[0].Release != "1.29" {
+		t.Errorf("Expected the Deployment of 1.29, got %+v.", resources)
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIWithoutTimeline(t *testing.T) {
+	// without data, the server answers from a resource index only
+	s := &server{metrics: newMetrics()}
+
+	testcases := map[string]int{
+		"/api/v1/openapi.json":               http.StatusOK,
+		"/api/v1/timeline":                   http.StatusNotFound,
+		"/api/v1/releases":                   http.StatusNotFound,
+		"/api/v1/groups/apps":                http.StatusNotFound,
+		"/api/v1/resources/apps/deployments": http.StatusNotFound,
+	}
+
+	for path, expected := range testcases {
+		rec := httptest.NewRecorder()
+		s.handleAPI(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if rec.Code != expected {
+			t.Errorf("Expected status %d for %s, got %d: %s", expected, path, rec.Code, rec.Body.String())
+		}
+	}
+}
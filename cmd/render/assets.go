@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// copyAssets copies all files from src into dst, unless both are the same
+// directory. If dst is located inside src, it is skipped while copying.
+func copyAssets(src, dst string) error {
+	absSrc, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+
+	absDst, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+
+	if absSrc == absDst {
+		return nil
+	}
+
+	return filepath.WalkDir(absSrc, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == absDst {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(absSrc, path)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(absDst, rel)
+
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// copyChangedAssets copies the given files from src into dst and removes the
+// files that do not exist in src anymore.
+func copyChangedAssets(src, dst string, files []string) error {
+	for _, file := range files {
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dst, rel)
+
+		if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
+			if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		if err := copyFile(file, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
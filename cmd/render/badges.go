@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/badge"
+)
+
+const badgePrefix = "/badge/"
+
+// handleBadge renders badges from the served timeline:
+//
+//	/badge/release/1.28.svg                        support status of a release
+//	/badge/release/1.28/eol.svg                    end-of-life date of a release
+//	/badge/api/networking.k8s.io/v1/Ingress.svg    status of a resource
+//	/badge/api/v1/Pod.svg                          status of a core resource
+func (s *server) handleBadge(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, badgePrefix)
+	if !strings.HasSuffix(path, ".svg") {
+		http.NotFound(w, r)
+		return
+	}
+
+	parts := strings.Split(strings.TrimSuffix(path, ".svg"), "/")
+	tl := s.data.Timeline
+
+	var b badge.Badge
+
+	switch {
+	case parts[0] == "release" && (len(parts) == 2 || (len(parts) == 3 && parts[2] == "eol")):
+		if !tl.HasRelease(parts[1]) {
+			http.Error(w, "unknown release "+parts[1], http.StatusNotFound)
+			return
+		}
+
+		release := tl.ReleaseMetadata(parts[1])
+
+		b = badge.ForRelease(release)
+		if len(parts) == 3 {
+			b = badge.ForReleaseEOL(release)
+		}
+
+	case parts[0] == "api" && (len(parts) == 3 || len(parts) == 4):
+		// core resources have no group in their path, like in the Kubernetes API
+		if len(parts) == 3 {
+			parts = []string{"api", "core", parts[1], parts[2]}
+		}
+
+		var err error
+
+		b, err = badge.ForResource(tl, parts[1], parts[2], parts[3])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	// allow READMEs to pick up changes without hammering the server
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	s.serveContent(w, r, "image/svg+xml", b.SVG())
+}
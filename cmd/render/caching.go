@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// contentETag returns a strong ETag for the given content.
+func contentETag(content []byte) string {
+	hash := sha256.Sum256(content)
+
+	return `"` + hex.EncodeToString(hash[:16]) + `"`
+}
+
+// hashFiles computes the ETags of all files in the output directory, keyed
+// by their URL path (e.g. "/static/site.css"). Since the site is rendered
+// before it is served, the files do not change while the server runs.
+func hashFiles(dir string) (map[string]string, error) {
+	etags := map[string]string{}
+
+	err := filepath.WalkDir(dir, func(filename string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		f, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		hash := sha256.New()
+		if _, err := io.Copy(hash, f); err != nil {
+			return fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+
+		rel, err := filepath.Rel(dir, filename)
+		if err != nil {
+			return err
+		}
+
+		etags["/"+filepath.ToSlash(rel)] = `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+
+		return nil
+	})
+
+	return etags, err
+}
+
+// serveFile serves a static file from the output directory. The file server
+// already handles If-Modified-Since, and also honors If-None-Match as long
+// as the ETag header is set beforehand.
+func (s *server) serveFile(w http.ResponseWriter, r *http.Request) {
+	urlPath := path.Clean("/" + r.URL.Path)
+	if strings.HasSuffix(r.URL.Path, "/") {
+		urlPath = path.Join(urlPath, "index.html")
+	}
+
+	if etag, ok := s.etags[urlPath]; ok {
+		w.Header().Set("ETag", etag)
+	}
+
+	http.FileServer(http.Dir(s.outputDir)).ServeHTTP(w, r)
+}
+
+// serveContent writes a generated response and answers conditional requests
+// with "304 Not Modified", so that polling clients do not need to download
+// the same payload over and over again. Responses only change when the
+// server is restarted with new data, so the start time is used as their
+// modification time.
+func (s *server) serveContent(w http.ResponseWriter, r *http.Request, contentType string, content []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", contentETag(content))
+
+	http.ServeContent(w, r, "", s.started, bytes.NewReader(content))
+}
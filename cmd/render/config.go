@@ -0,0 +1,334 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+	"go.xrstf.de/kube-api.ninja/pkg/webhook"
+
+	"sigs.k8s.io/yaml"
+)
+
+// siteConfig describes which variants of the website should be rendered.
+type siteConfig struct {
+	// Assets is the directory containing the static files (images, Bootstrap,
+	// ...) that are copied into every profile's output directory.
+	Assets string `json:"assets,omitempty"`
+	// Source configures where the release data is loaded from; it defaults
+	// to the local "data" directory.
+	Source   database.SourceConfig `json:"source,omitempty"`
+	Branding siteBranding          `json:"branding,omitempty"`
+	Profiles []siteProfile         `json:"profiles"`
+	// Redirects maps pages that are not generated anymore (like
+	// "old-page.html") to their replacement (like "about.html"). Removed
+	// pages without a configured redirect lead to the start page.
+	Redirects map[string]string `json:"redirects,omitempty"`
+	// Webhooks are notified whenever the data of a profile has changed.
+	Webhooks []webhook.Config `json:"webhooks,omitempty"`
+}
+
+// siteBranding allows self-hosted instances to customize the most visible
+// parts of the website without forking the templates.
+type siteBranding struct {
+	// Title is used for the page titles and the Atom feed.
+	Title string `json:"title,omitempty"`
+	// Name is shown next to the logo in the navbar.
+	Name string `json:"name,omitempty"`
+	// Logo is the URL of the navbar logo, relative to the site root.
+	Logo string `json:"logo,omitempty"`
+	// URL is the public base URL of the website.
+	URL string `json:"url,omitempty"`
+	// FooterLinks are shown at the bottom of every page.
+	FooterLinks []siteLink `json:"footerLinks,omitempty"`
+	// Analytics is an HTML snippet that is included verbatim at the end of
+	// every page.
+	Analytics string `json:"analytics,omitempty"`
+}
+
+type siteLink struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func (b *siteBranding) applyDefaults() {
+	if b.Title == "" {
+		b.Title = "Kubernetes API Timeline"
+	}
+
+	if b.Name == "" {
+		b.Name = "API Timeline"
+	}
+
+	if b.Logo == "" {
+		b.Logo = "static/images/kubernetes-logo.svg"
+	}
+
+	if b.URL == "" {
+		b.URL = "https://kube-api.ninja/"
+	}
+
+	b.URL = strings.TrimSuffix(b.URL, "/") + "/"
+}
+
+// AnalyticsSnippet marks the configured snippet as safe HTML; it comes from
+// the site config and so is as trustworthy as the templates themselves.
+func (b siteBranding) AnalyticsSnippet() template.HTML {
+	return template.HTML(b.Analytics)
+}
+
+const (
+	// channelStable only shows releases that are already released.
+	channelStable = "stable"
+	// channelNext additionally shows the upcoming release, based on its
+	// alpha/beta/RC data.
+	channelNext = "next"
+)
+
+// siteProfile is one variant of the website.
+type siteProfile struct {
+	Name   string `json:"name"`
+	Output string `json:"output"`
+	// Path is the URL path the profile is published under, relative to the
+	// branding URL (e.g. "next/").
+	Path string `json:"path,omitempty"`
+	// Channel is either "stable" (default) or "next".
+	Channel string `json:"channel,omitempty"`
+	// Project renders the dataset of an ecosystem project (like
+	// "cert-manager", see data/projects/) instead of Kubernetes itself.
+	Project string `json:"project,omitempty"`
+	// Distribution renders the dataset of a Kubernetes distribution (like
+	// "openshift" or "k3s", see data/distributions/) instead of upstream
+	// Kubernetes.
+	Distribution string `json:"distribution,omitempty"`
+
+	// RecentReleases is the number of non-archived releases (default 11).
+	RecentReleases int `json:"recentReleases,omitempty"`
+	// ArchiveAfterEOLDays archives releases whose end of life is more than
+	// this many days ago, instead of keeping a fixed number of recent releases.
+	ArchiveAfterEOLDays int `json:"archiveAfterEOLDays,omitempty"`
+	// NeverArchive treats all releases alike, no matter how old they are.
+	NeverArchive bool `json:"neverArchive,omitempty"`
+	// MinRelease and MaxRelease limit the releases shown (both inclusive).
+	MinRelease string `json:"minRelease,omitempty"`
+	MaxRelease string `json:"maxRelease,omitempty"`
+	// HideArchived removes archived releases entirely.
+	HideArchived bool `json:"hideArchived,omitempty"`
+	// SupportedOnly removes all releases that have reached their end of life.
+	SupportedOnly bool `json:"supportedOnly,omitempty"`
+	// Overlays are additional data directories whose APIs (like internal
+	// CRDs) are added to the releases.
+	Overlays []string `json:"overlays,omitempty"`
+	// Annotations is a YAML/JSON file with notes about resources (owners,
+	// migration tickets, ...).
+	Annotations string `json:"annotations,omitempty"`
+	// FeaturedGroups are shown at the top of the timeline (in this order)
+	// and on the essentials page.
+	FeaturedGroups []string `json:"featuredGroups,omitempty"`
+	// Partitioned additionally exports the timeline as one JSON document per
+	// API group plus an index (in timeline/), so that clients can load the
+	// groups they need on demand.
+	Partitioned bool `json:"partitioned,omitempty"`
+	// URLMap is the file recording every URL the profile ever published
+	// (default data/urls/<name>.json). It must be kept with the data, as the
+	// output directory is usually not preserved between builds.
+	URLMap string `json:"urlMap,omitempty"`
+}
+
+func (p siteProfile) urlMapFile() string {
+	if p.URLMap != "" {
+		return p.URLMap
+	}
+
+	return filepath.Join(dataDirectory, "urls", p.Name+".json")
+}
+
+// defaultFeaturedGroups are the API groups most users work with every day.
+var defaultFeaturedGroups = []string{"core", "apps", "batch", "networking.k8s.io"}
+
+func defaultSiteConfig() *siteConfig {
+	config := &siteConfig{
+		Assets: outputDirectory,
+		Source: defaultSource(),
+		Profiles: []siteProfile{
+			{
+				Name:           "full",
+				Output:         outputDirectory,
+				Channel:        channelStable,
+				FeaturedGroups: defaultFeaturedGroups,
+			},
+			{
+				Name:           "next",
+				Output:         filepath.Join(outputDirectory, "next"),
+				Path:           "next/",
+				Channel:        channelNext,
+				FeaturedGroups: defaultFeaturedGroups,
+			},
+		},
+	}
+	config.Branding.applyDefaults()
+
+	return config
+}
+
+func defaultSource() database.SourceConfig {
+	return database.SourceConfig{
+		Kind:    "directory",
+		Options: map[string]string{"path": dataDirectory},
+	}
+}
+
+func loadSiteConfig(filename string) (*siteConfig, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &siteConfig{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	if config.Assets == "" {
+		config.Assets = outputDirectory
+	}
+
+	if config.Source.Kind == "" {
+		config.Source = defaultSource()
+	}
+
+	config.Branding.applyDefaults()
+
+	for i, link := range config.Branding.FooterLinks {
+		if link.Title == "" || link.URL == "" {
+			return nil, fmt.Errorf("footer link #%d needs both a title and a URL", i+1)
+		}
+	}
+
+	if len(config.Profiles) == 0 {
+		return nil, fmt.Errorf("%s does not define any profiles", filename)
+	}
+
+	names := map[string]struct{}{}
+	for i, profile := range config.Profiles {
+		if profile.Name == "" {
+			return nil, fmt.Errorf("profile #%d has no name", i+1)
+		}
+
+		if _, exists := names[profile.Name]; exists {
+			return nil, fmt.Errorf("profile %q is defined more than once", profile.Name)
+		}
+		names[profile.Name] = struct{}{}
+
+		if profile.Output == "" {
+			return nil, fmt.Errorf("profile %q has no output directory", profile.Name)
+		}
+
+		switch profile.Channel {
+		case "":
+			config.Profiles[i].Channel = channelStable
+		case channelStable, channelNext:
+		default:
+			return nil, fmt.Errorf("profile %q has invalid channel %q", profile.Name, profile.Channel)
+		}
+
+		if profile.NeverArchive && (profile.RecentReleases > 0 || profile.ArchiveAfterEOLDays > 0) {
+			return nil, fmt.Errorf("profile %q cannot combine neverArchive with other archival settings", profile.Name)
+		}
+
+		if profile.RecentReleases > 0 && profile.ArchiveAfterEOLDays > 0 {
+			return nil, fmt.Errorf("profile %q can either use recentReleases or archiveAfterEOLDays", profile.Name)
+		}
+
+		if profile.Project != "" && profile.Distribution != "" {
+			return nil, fmt.Errorf("profile %q cannot render both a project and a distribution", profile.Name)
+		}
+
+		if profile.Path != "" {
+			config.Profiles[i].Path = strings.Trim(profile.Path, "/") + "/"
+		}
+	}
+
+	for i, hook := range config.Webhooks {
+		if hook.URL == "" {
+			return nil, fmt.Errorf("webhook #%d has no URL", i+1)
+		}
+
+		for _, name := range hook.Profiles {
+			if _, exists := names[name]; !exists {
+				return nil, fmt.Errorf("webhook #%d refers to unknown profile %q", i+1, name)
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// channelURL returns the URL of the first profile of the given channel, or an
+// empty string if no such profile exists.
+func (c *siteConfig) channelURL(channel string) string {
+	for _, profile := range c.Profiles {
+		if profile.Channel == channel {
+			return c.Branding.URL + profile.Path
+		}
+	}
+
+	return ""
+}
+
+// sitemapURLs returns the URLs of the sitemaps of all profiles.
+func (c *siteConfig) sitemapURLs() []string {
+	result := []string{}
+
+	for _, profile := range c.Profiles {
+		result = append(result, c.Branding.URL+profile.Path+sitemapFilename)
+	}
+
+	return result
+}
+
+// nestedOutputs returns the output directories of all other profiles that are
+// located inside the given profile's output directory.
+func (c *siteConfig) nestedOutputs(profile siteProfile) []string {
+	result := []string{}
+
+	for _, other := range c.Profiles {
+		if other.Name == profile.Name {
+			continue
+		}
+
+		rel, err := filepath.Rel(profile.Output, other.Output)
+		if err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			result = append(result, other.Output)
+		}
+	}
+
+	return result
+}
+
+func loadAnnotations(filename string) ([]types.Annotation, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := []types.Annotation{}
+	if err := yaml.UnmarshalStrict(content, &annotations); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	for i, annotation := range annotations {
+		if annotation.Kind == "" {
+			return nil, fmt.Errorf("annotation #%d in %s has no kind", i+1, filename)
+		}
+	}
+
+	return annotations, nil
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"go.xrstf.de/kube-api.ninja/pkg/graphql"
+)
+
+const graphqlPath = apiPrefix + "graphql"
+
+type graphqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// handleGraphQL allows to query slices of the timeline, either via
+// GET /api/v1/graphql?query=…&variables=… or by POSTing a JSON document
+// like {"query": "…", "variables": {…}}.
+func (s *server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	req := graphqlRequest{}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				writeGraphQLError(w, "invalid variables: "+err.Error())
+				return
+			}
+		}
+
+	case http.MethodPost:
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+			writeGraphQLError(w, "invalid request body: "+err.Error())
+			return
+		}
+
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		http.Error(w, "only GET and POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if req.Query == "" {
+		writeGraphQLError(w, "no query given")
+		return
+	}
+
+	writeGraphQLResponse(w, http.StatusOK, graphql.Execute(s.data.Timeline, req.Query, req.Variables))
+}
+
+func writeGraphQLError(w http.ResponseWriter, message string) {
+	writeGraphQLResponse(w, http.StatusBadRequest, &graphql.Response{
+		Errors: []graphql.Error{{Message: message}},
+	})
+}
+
+func writeGraphQLResponse(w http.ResponseWriter, status int, response *graphql.Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Failed to encode GraphQL response: %v", err)
+	}
+}
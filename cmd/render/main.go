@@ -4,25 +4,89 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"go.xrstf.de/kube-api.ninja/pkg/badge"
+	"go.xrstf.de/kube-api.ninja/pkg/changelog"
 	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/kubeconform"
+	"go.xrstf.de/kube-api.ninja/pkg/rego"
 	"go.xrstf.de/kube-api.ninja/pkg/render"
+	"go.xrstf.de/kube-api.ninja/pkg/schema"
+	"go.xrstf.de/kube-api.ninja/pkg/search"
+	"go.xrstf.de/kube-api.ninja/pkg/stats"
 	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+	"go.xrstf.de/kube-api.ninja/pkg/view"
+
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
 	outputDirectory = "public"
+	dataDirectory   = "data"
+
+	// releaseTemplate and whatsNewTemplate are rendered once per release.
+	releaseTemplate  = "_release.md"
+	whatsNewTemplate = "_whatsnew.html"
 )
 
+type appOptions struct {
+	configFile string
+	asOf       string
+	projected  int
+	listen     string
+	resources  string
+	archiveDir string
+	cacheDir   string
+	watch      bool
+}
+
+func (o *appOptions) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.configFile, "config", o.configFile, "Site configuration file (YAML) defining the profiles to render (renders the full site into public/ if not given).")
+	fs.StringVar(&o.asOf, "as-of", o.asOf, "Render the site as it would have looked on this date (YYYY-MM-DD).")
+	fs.IntVar(&o.projected, "projected-releases", o.projected, "Number of speculative future releases to extrapolate from the release cadence and deprecation policy.")
+	fs.StringVar(&o.archiveDir, "archive", o.archiveDir, "If set, store a dated copy of the timeline exports (JSON, CSV) in this directory whenever the data changed.")
+	fs.StringVar(&o.cacheDir, "cache", o.cacheDir, "If set, cache the merged data of each release in this directory, so that only changed releases are merged again on the next run.")
+	fs.BoolVar(&o.watch, "watch", o.watch, "Keep running and rebuild the site whenever the data, the assets or the templates change (the server is restarted when combined with -listen).")
+	fs.StringVar(&o.listen, "listen", o.listen, "If set (e.g. \":8080\"), serve the first profile via HTTP after rendering, including personalized views.")
+	fs.StringVar(&o.resources, "resource-index", o.resources, "If set, serve the previously rendered site without loading the release database and answer per-resource API requests from this SQLite file (requires -listen, see \"apininja data sqlite\", requires building with -tags sqlite).")
+}
+
 func main() {
+	opts := appOptions{}
+	opts.AddFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
 	now := time.Now().UTC()
 
+	var asOf *time.Time
+	if opts.asOf != "" {
+		parsed, err := time.Parse("2006-01-02", opts.asOf)
+		if err != nil {
+			log.Fatalf("Invalid -as-of date: %v", err)
+		}
+
+		if parsed.After(now) {
+			log.Fatalf("Invalid -as-of date: %s is in the future.", opts.asOf)
+		}
+
+		asOf = &parsed
+	}
+
 	stamp := os.Getenv("ASSET_STAMP")
 	if stamp == "" {
 		stamp = now.Format("2006-01-02-15-04-05")
@@ -30,74 +94,447 @@ func main() {
 		stamp = stamp[:10]
 	}
 
-	db, err := database.NewReleaseDatabase("data")
-	if err != nil {
-		log.Fatalf("Failed to open database: %v", err)
-	}
+	config := defaultSiteConfig()
+	if opts.configFile != "" {
+		var err error
 
-	releaseNames, err := db.Releases()
-	if err != nil {
-		log.Fatalf("Failed to list available releases: %v", err)
+		config, err = loadSiteConfig(opts.configFile)
+		if err != nil {
+			log.Fatalf("Failed to load site config: %v", err)
+		}
 	}
 
-	releases := []*database.KubernetesRelease{}
-	for _, releaseName := range releaseNames {
-		release, err := db.Release(releaseName)
+	serverMetrics := newMetrics()
+
+	// the resource index replaces the timeline in server mode, so neither the
+	// release database is loaded nor the site is rendered again
+	if opts.resources != "" {
+		if opts.listen == "" || opts.watch {
+			log.Fatal("-resource-index requires -listen and cannot be combined with -watch.")
+		}
+
+		resources, err := database.OpenResourceIndex(opts.resources)
 		if err != nil {
-			log.Fatalf("Failed to load release %q: %v", releaseName, err)
+			log.Fatalf("Failed to open resource index: %v", err)
+		}
+		defer resources.Close()
+
+		if err := serve(ctx, opts.listen, config.Profiles[0].Output, config.Profiles[0].urlMapFile(), nil, nil, resources, serverMetrics); err != nil {
+			log.Fatalf("Failed to serve: %v", err)
 		}
 
-		releases = append(releases, release)
+		return
 	}
 
-	timelineObj, err := timeline.CreateTimeline(releases, now)
-	if err != nil {
-		log.Fatalf("Failed to create timeline: %v", err)
+	s := &site{
+		opts:    opts,
+		config:  config,
+		now:     now,
+		asOf:    asOf,
+		stamp:   stamp,
+		logger:  slog.New(slog.NewTextHandler(os.Stderr, nil)),
+		metrics: serverMetrics,
+	}
+
+	if err := s.loadTemplates(); err != nil {
+		log.Fatalf("Failed to load templates: %v", err)
+	}
+
+	if err := s.build(ctx); err != nil {
+		log.Fatalf("Failed to build site: %v", err)
+	}
+
+	log.Println("Done.")
+
+	if opts.watch {
+		if err := s.watch(ctx); err != nil {
+			log.Fatalf("Failed to watch: %v", err)
+		}
+
+		return
+	}
+
+	if opts.listen != "" {
+		if err := s.serve(ctx); err != nil {
+			log.Fatalf("Failed to serve: %v", err)
+		}
 	}
+}
+
+// site renders all profiles of the website.
+type site struct {
+	opts    appOptions
+	config  *siteConfig
+	now     time.Time
+	asOf    *time.Time
+	stamp   string
+	logger  *slog.Logger
+	metrics *metrics
+
+	htmlTemplates []render.Renderable
+	textTemplates []render.Renderable
+
+	// pages contains the data of each profile, in the same order as the
+	// profiles; it is only set once the site was built.
+	pages []*pageData
+}
 
+func (s *site) loadTemplates() error {
 	htmlTemplates, err := render.LoadHTMLTemplates()
 	if err != nil {
-		log.Fatalf("Failed to parse HTML template: %v", err)
+		return fmt.Errorf("failed to parse HTML template: %w", err)
 	}
 
 	textTemplates, err := render.LoadTextTemplates()
 	if err != nil {
-		log.Fatalf("Failed to parse text template: %v", err)
+		return fmt.Errorf("failed to parse text template: %w", err)
+	}
+
+	s.htmlTemplates = htmlTemplates
+	s.textTemplates = textTemplates
+
+	return nil
+}
+
+// serve makes the first profile available via HTTP.
+func (s *site) serve(ctx context.Context) error {
+	return serve(ctx, s.opts.listen, s.config.Profiles[0].Output, s.config.Profiles[0].urlMapFile(), s.htmlTemplates, s.pages[0], nil, s.metrics)
+}
+
+// build loads the release data and renders all profiles.
+func (s *site) build(ctx context.Context) error {
+	return s.buildProfiles(ctx, nil)
+}
+
+// buildProfiles loads the release data and renders the profiles with the
+// given names, or all profiles if names is nil.
+func (s *site) buildProfiles(ctx context.Context, names sets.Set[string]) error {
+	db, err := database.OpenSource(s.config.Source)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
 	}
 
+	// releases are loaded once and shared by all profiles, so their
+	// (cached) data is only read from disk once
+	loadStart := time.Now()
+	releases, err := db.LoadReleases(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load releases: %w", err)
+	}
+
+	s.metrics.observeDatabaseLoad(time.Since(loadStart))
+
+	keps, err := db.KEPs()
+	if err != nil {
+		return fmt.Errorf("failed to load KEPs: %w", err)
+	}
+
+	if s.pages == nil {
+		s.pages = make([]*pageData, len(s.config.Profiles))
+	}
+
+	for i, profile := range s.config.Profiles {
+		if names != nil && !names.Has(profile.Name) {
+			continue
+		}
+
+		log.Printf("Rendering profile %s…", profile.Name)
+
+		profileReleases := releases
+		if profile.Project != "" {
+			profileReleases, err = loadProjectReleases(ctx, db, profile.Project)
+			if err != nil {
+				return fmt.Errorf("failed to load releases of project %s: %w", profile.Project, err)
+			}
+		}
+
+		if profile.Distribution != "" {
+			profileReleases, err = loadDistributionReleases(ctx, db, profile.Distribution)
+			if err != nil {
+				return fmt.Errorf("failed to load releases of distribution %s: %w", profile.Distribution, err)
+			}
+		}
+
+		profileLogger := s.logger.With("profile", profile.Name)
+
+		timelineOpts := []timeline.Option{
+			timeline.WithNow(s.now),
+			timeline.WithLogger(profileLogger),
+			timeline.WithProgress(func(done int, total int, release string) {
+				profileLogger.Info("Merged release.", "release", release, "progress", fmt.Sprintf("%d/%d", done, total))
+			}),
+			timeline.WithReleaseRange(profile.MinRelease, profile.MaxRelease),
+			timeline.WithArchived(!profile.HideArchived),
+			timeline.WithSupportedOnly(profile.SupportedOnly),
+			timeline.WithUnreleased(profile.Channel == channelNext),
+			timeline.WithFeaturedGroups(profile.FeaturedGroups...),
+			timeline.WithProjectedReleases(s.opts.projected),
+			timeline.WithKEPs(keps...),
+		}
+
+		if s.asOf != nil {
+			timelineOpts = append(timelineOpts, timeline.WithAsOf(*s.asOf))
+		}
+
+		if s.opts.cacheDir != "" {
+			timelineOpts = append(timelineOpts, timeline.WithCache(s.opts.cacheDir))
+		}
+
+		if profile.RecentReleases > 0 {
+			timelineOpts = append(timelineOpts, timeline.WithRecentReleases(profile.RecentReleases))
+		}
+
+		if profile.ArchiveAfterEOLDays > 0 {
+			timelineOpts = append(timelineOpts, timeline.WithArchiveAfterEOL(time.Duration(profile.ArchiveAfterEOLDays)*24*time.Hour))
+		}
+
+		if profile.NeverArchive {
+			timelineOpts = append(timelineOpts, timeline.WithArchival(false))
+		}
+
+		for _, dir := range profile.Overlays {
+			overlay, err := database.NewReleaseDatabase(dir)
+			if err != nil {
+				return fmt.Errorf("failed to open overlay %s: %w", dir, err)
+			}
+
+			timelineOpts = append(timelineOpts, timeline.WithOverlays(overlay))
+		}
+
+		if profile.Annotations != "" {
+			annotations, err := loadAnnotations(profile.Annotations)
+			if err != nil {
+				return fmt.Errorf("failed to load annotations: %w", err)
+			}
+
+			timelineOpts = append(timelineOpts, timeline.WithAnnotations(annotations))
+		}
+
+		timelineObj, err := timeline.CreateTimeline(ctx, profileReleases, timelineOpts...)
+		if err != nil {
+			return fmt.Errorf("failed to create timeline: %w", err)
+		}
+
+		// historic and speculative builds must not end up in the changelog
+		trackChanges := s.asOf == nil && s.opts.projected == 0
+
+		changes, changed, err := updateChangelog(profile.Output, timelineObj, s.now, trackChanges)
+		if err != nil {
+			return fmt.Errorf("failed to update site changelog: %w", err)
+		}
+
+		if err := copyAssets(s.config.Assets, profile.Output); err != nil {
+			return fmt.Errorf("failed to copy static assets: %w", err)
+		}
+
+		data := &pageData{
+			Timeline:   timelineObj,
+			AssetStamp: s.stamp,
+			Profile:    profile.Name,
+			Branding:   s.config.Branding,
+			AsOf:       s.asOf,
+			Changelog:  changes,
+			Heatmap:    stats.NewHeatmap(timelineObj),
+			Churn:      stats.NewChurn(timelineObj),
+			Channel:    profile.Channel,
+			StableURL:  s.config.channelURL(channelStable),
+			PreviewURL: s.config.channelURL(channelNext),
+		}
+
+		if err := renderSite(profile.Output, s.htmlTemplates, s.textTemplates, data); err != nil {
+			return fmt.Errorf("failed to render: %w", err)
+		}
+
+		if err := writeReleaseAPIs(ctx, filepath.Join(profile.Output, "releases"), profileReleases, timelineObj); err != nil {
+			return fmt.Errorf("failed to write release APIs: %w", err)
+		}
+
+		if profile.Partitioned {
+			if err := writePartitionedTimeline(filepath.Join(profile.Output, partitionDirectory), timelineObj); err != nil {
+				return fmt.Errorf("failed to write partitioned timeline: %w", err)
+			}
+		}
+
+		if trackChanges {
+			if err := saveSnapshot(profile.Output, timelineObj, changes); err != nil {
+				return fmt.Errorf("failed to save timeline snapshot: %w", err)
+			}
+
+			// links to pages that are not generated anymore must keep working
+			if _, err := updateURLMap(profile.Output, profile.urlMapFile(), profile.Path, s.config.Redirects, s.config.nestedOutputs(profile)); err != nil {
+				return fmt.Errorf("failed to update URL map: %w", err)
+			}
+
+			if s.opts.archiveDir != "" && changed {
+				if err := archiveExports(s.opts.archiveDir, profile.Name, timelineObj, s.now); err != nil {
+					return fmt.Errorf("failed to archive timeline snapshot: %w", err)
+				}
+			}
+
+			// downstream systems must not be able to break the build
+			if len(s.config.Webhooks) > 0 && changed {
+				if err := notifyWebhooks(ctx, s.config, profile, changes, s.now); err != nil {
+					log.Printf("Warning: failed to notify webhooks: %v", err)
+				}
+			}
+		}
+
+		if err := writeSitemap(profile.Output, s.config.Branding.URL+profile.Path, s.config.nestedOutputs(profile)); err != nil {
+			return fmt.Errorf("failed to write sitemap: %w", err)
+		}
+
+		if profile.Path == "" {
+			if err := writeRobots(profile.Output, s.config.sitemapURLs()); err != nil {
+				return fmt.Errorf("failed to write robots.txt: %w", err)
+			}
+		}
+
+		s.pages[i] = data
+	}
+
+	return nil
+}
+
+func renderSite(outputDir string, htmlTemplates, textTemplates []render.Renderable, data *pageData) error {
+	if err := renderPages(outputDir, htmlTemplates, textTemplates, data, nil); err != nil {
+		return err
+	}
+
+	return writeArtifacts(outputDir, data)
+}
+
+// renderPages renders the pages based on the given templates (all if nil); in
+// contrast to the other artifacts, these need to be rendered again when only
+// the templates have changed.
+func renderPages(outputDir string, htmlTemplates, textTemplates []render.Renderable, data *pageData, templates sets.Set[string]) error {
 	for _, dir := range []string{
-		filepath.Join(outputDirectory, "static", "css"),
-		filepath.Join(outputDirectory, "static", "js"),
+		filepath.Join(outputDir, "static", "css"),
+		filepath.Join(outputDir, "static", "js"),
 	} {
 		if err := os.MkdirAll(dir, 0755); err != nil {
-			log.Fatalf("Failed to create %s directory: %v", dir, err)
+			return fmt.Errorf("failed to create %s directory: %w", dir, err)
 		}
 	}
 
-	data := &pageData{
-		Timeline:   timelineObj,
-		AssetStamp: stamp,
+	if err := renderFileType(outputDir, selectTemplates(htmlTemplates, templates), data, "html"); err != nil {
+		return err
 	}
 
-	if err := renderFileType(outputDirectory, htmlTemplates, data, "html"); err != nil {
-		log.Fatalf("Failed to render: %v", err)
+	if err := renderFileType(outputDir, selectTemplates(textTemplates, templates), data, "xml"); err != nil {
+		return err
 	}
 
-	if err := renderFileType(filepath.Join(outputDirectory, "static", "css"), textTemplates, data, "css"); err != nil {
-		log.Fatalf("Failed to render: %v", err)
+	if err := renderFileType(outputDir, selectTemplates(textTemplates, templates), data, "md"); err != nil {
+		return err
 	}
 
-	if err := renderFileType(filepath.Join(outputDirectory, "static", "js"), textTemplates, data, "js"); err != nil {
-		log.Fatalf("Failed to render: %v", err)
+	if templates == nil || templates.Has(releaseTemplate) {
+		if err := renderReleasePages(filepath.Join(outputDir, "releases"), textTemplates, data); err != nil {
+			return err
+		}
 	}
 
-	log.Println("Done.")
+	if templates == nil || templates.Has(whatsNewTemplate) {
+		if err := renderWhatsNewPages(outputDir, htmlTemplates, data); err != nil {
+			return err
+		}
+	}
+
+	if err := renderFileType(filepath.Join(outputDir, "static", "css"), selectTemplates(textTemplates, templates), data, "css"); err != nil {
+		return err
+	}
+
+	if err := renderFileType(filepath.Join(outputDir, "static", "js"), selectTemplates(textTemplates, templates), data, "js"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// selectTemplates returns the templates with the given names, or all
+// templates if names is nil.
+func selectTemplates(tpls []render.Renderable, names sets.Set[string]) []render.Renderable {
+	if names == nil {
+		return tpls
+	}
+
+	result := []render.Renderable{}
+	for _, t := range tpls {
+		if names.Has(t.Name()) {
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
+
+// writeArtifacts writes all data files that do not depend on templates.
+func writeArtifacts(outputDir string, data *pageData) error {
+	if err := writeJSON(filepath.Join(outputDir, "heatmap.json"), data.Heatmap); err != nil {
+		return err
+	}
+
+	if err := writeJSON(filepath.Join(outputDir, "churn.json"), data.Churn); err != nil {
+		return err
+	}
+
+	if err := writeSearchIndex(filepath.Join(outputDir, "search.json"), search.NewIndex(data.Timeline)); err != nil {
+		return err
+	}
+
+	if err := writeBadges(filepath.Join(outputDir, "badges"), data.Timeline); err != nil {
+		return err
+	}
+
+	if err := writeRegoBundles(filepath.Join(outputDir, "rego"), data.Timeline); err != nil {
+		return err
+	}
+
+	if err := writeKubeconformSchemas(filepath.Join(outputDir, "kubeconform"), data.Timeline); err != nil {
+		return err
+	}
+
+	if err := writePermalinks(outputDir, data.Timeline); err != nil {
+		return err
+	}
+
+	if err := writeCalendar(filepath.Join(outputDir, "releases.ics"), data.Timeline, data.Branding.Title); err != nil {
+		return err
+	}
+
+	if err := writeSchemas(filepath.Join(outputDir, "schemas"), data.Branding.URL+"schemas/"); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 type pageData struct {
 	Timeline    *timeline.Timeline
 	AssetStamp  string
 	CurrentPage string
+	// Profile is the name of the site variant being rendered.
+	Profile  string
+	Branding siteBranding
+	// AsOf is set when rendering a historic version of the site.
+	AsOf *time.Time
+	// Changelog lists the changes between previous builds of the site.
+	Changelog []changelog.Entry
+	// View is only set when serving a personalized page.
+	View *view.State
+	// Release is only set when rendering per-release pages.
+	Release *timeline.ReleaseMetadata
+	// WhatsNew is only set when rendering the "what's new" pages.
+	WhatsNew *timeline.WhatsNew
+	// Heatmap contains the number of changes per API group and release.
+	Heatmap *stats.Heatmap
+	// Churn describes how volatile each API group has been.
+	Churn *stats.Churn
+	// Channel is either "stable" or "next" (which includes the upcoming
+	// release); the URLs point to the respective channels, if rendered.
+	Channel    string
+	StableURL  string
+	PreviewURL string
 }
 
 func renderFileType(targetDir string, tpls []render.Renderable, data *pageData, filetype string) error {
@@ -132,3 +569,260 @@ func renderFileType(targetDir string, tpls []render.Renderable, data *pageData,
 
 	return nil
 }
+
+// renderReleasePages renders one plain-text page per release, so that the
+// data is easily accessible from a terminal.
+func renderReleasePages(targetDir string, tpls []render.Renderable, data *pageData) error {
+	var tpl render.Renderable
+	for _, t := range tpls {
+		if t.Name() == releaseTemplate {
+			tpl = t
+			break
+		}
+	}
+
+	if tpl == nil {
+		return fmt.Errorf("no %s template found", releaseTemplate)
+	}
+
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", targetDir, err)
+	}
+
+	defer func() {
+		data.Release = nil
+	}()
+
+	for i := range data.Timeline.Releases {
+		release := &data.Timeline.Releases[i]
+		basename := fmt.Sprintf("%s.md", release.Version)
+
+		log.Printf("Rendering releases/%s…", basename)
+		f, err := os.Create(filepath.Join(targetDir, basename))
+		if err != nil {
+			return err
+		}
+
+		data.CurrentPage = basename
+		data.Release = release
+
+		if err := tpl.Execute(f, data); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to render %s: %w", basename, err)
+		}
+
+		f.Close()
+	}
+
+	return nil
+}
+
+// renderWhatsNewPages renders one page per release that summarizes its API
+// changes compared to the previous release. The pages are placed next to the
+// other HTML pages, so that relative links keep working.
+func renderWhatsNewPages(targetDir string, tpls []render.Renderable, data *pageData) error {
+	var tpl render.Renderable
+	for _, t := range tpls {
+		if t.Name() == whatsNewTemplate {
+			tpl = t
+			break
+		}
+	}
+
+	if tpl == nil {
+		return fmt.Errorf("no %s template found", whatsNewTemplate)
+	}
+
+	defer func() {
+		data.WhatsNew = nil
+	}()
+
+	for _, release := range data.Timeline.Releases {
+		if release.Projected {
+			continue
+		}
+
+		whatsNew, err := data.Timeline.WhatsNew(release.Version)
+		if err != nil {
+			return err
+		}
+
+		basename := fmt.Sprintf("whats-new-%s.html", release.Version)
+
+		log.Printf("Rendering %s…", basename)
+		f, err := os.Create(filepath.Join(targetDir, basename))
+		if err != nil {
+			return err
+		}
+
+		data.CurrentPage = basename
+		data.WhatsNew = whatsNew
+
+		if err := tpl.Execute(f, data); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to render %s: %w", basename, err)
+		}
+
+		f.Close()
+	}
+
+	return nil
+}
+
+// writeBadges creates one support status badge per release, which can be
+// embedded into documentation.
+func writeBadges(targetDir string, tl *timeline.Timeline) error {
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", targetDir, err)
+	}
+
+	for _, release := range tl.Releases {
+		filename := filepath.Join(targetDir, fmt.Sprintf("%s.svg", release.Version))
+
+		if err := os.WriteFile(filename, badge.ForRelease(release).SVG(), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeRegoBundles publishes one Open Policy Agent bundle per release with
+// the APIs that are removed in or deprecated by it.
+func writeRegoBundles(targetDir string, tl *timeline.Timeline) error {
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", targetDir, err)
+	}
+
+	log.Printf("Writing rego bundles…")
+
+	for _, release := range tl.Releases {
+		// speculative data is not suitable for policies
+		if release.Projected {
+			continue
+		}
+
+		data, err := rego.NewData(tl, release.Version)
+		if err != nil {
+			return fmt.Errorf("failed to determine rego data for %s: %w", release.Version, err)
+		}
+
+		var buf bytes.Buffer
+		if err := rego.WriteBundle(&buf, data); err != nil {
+			return fmt.Errorf("failed to create rego bundle for %s: %w", release.Version, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(targetDir, fmt.Sprintf("%s.tar.gz", release.Version)), buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeKubeconformSchemas publishes per-release schemas, so that kubeconform
+// and kubeval can use the site as a schema registry.
+func writeKubeconformSchemas(targetDir string, tl *timeline.Timeline) error {
+	log.Printf("Writing kubeconform schemas…")
+
+	for _, release := range tl.Releases {
+		if release.Projected {
+			continue
+		}
+
+		if _, err := kubeconform.WriteSchemas(targetDir, tl, release.Version); err != nil {
+			return fmt.Errorf("failed to write kubeconform schemas for %s: %w", release.Version, err)
+		}
+	}
+
+	return nil
+}
+
+// loadProjectReleases returns the releases of an ecosystem project, which can
+// be merged into a timeline just like Kubernetes releases.
+func loadProjectReleases(ctx context.Context, db *database.ReleaseDatabase, project string) ([]*database.KubernetesRelease, error) {
+	projectDB, err := db.Project(project)
+	if err != nil {
+		return nil, err
+	}
+
+	return projectDB.LoadReleases(ctx)
+}
+
+// loadDistributionReleases returns the releases of a Kubernetes distribution,
+// which are merged into their own timeline.
+func loadDistributionReleases(ctx context.Context, db *database.ReleaseDatabase, distribution string) ([]*database.KubernetesRelease, error) {
+	distributionDB, err := db.Distribution(distribution)
+	if err != nil {
+		return nil, err
+	}
+
+	return distributionDB.LoadReleases(ctx)
+}
+
+// writeCalendar publishes the release and end of life dates as a calendar
+// that can be subscribed to.
+func writeCalendar(filename string, tl *timeline.Timeline, title string) error {
+	log.Printf("Writing %s…", filepath.Base(filename))
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := tl.WriteICalendar(f, fmt.Sprintf("Kubernetes Releases (%s)", title)); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(filename), err)
+	}
+
+	return f.Close()
+}
+
+// writeJSON writes pre-computed data for consumption by scripts and
+// visualizations.
+func writeJSON(filename string, data any) error {
+	log.Printf("Writing %s…", filepath.Base(filename))
+
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filepath.Base(filename), err)
+	}
+
+	return os.WriteFile(filename, append(encoded, '\n'), 0644)
+}
+
+// writeSearchIndex writes the search index without any indentation, as it is
+// meant to be downloaded by browsers for client-side search.
+func writeSearchIndex(filename string, index *search.Index) error {
+	log.Printf("Writing %s…", filepath.Base(filename))
+
+	encoded, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filepath.Base(filename), err)
+	}
+
+	return os.WriteFile(filename, append(encoded, '\n'), 0644)
+}
+
+// writeSchemas publishes JSON Schemas for all data formats, so that
+// consumers can validate them and generate clients.
+func writeSchemas(targetDir string, baseURL string) error {
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", targetDir, err)
+	}
+
+	for _, doc := range schema.Documents {
+		log.Printf("Writing schemas/%s…", doc.Filename)
+
+		encoded, err := json.MarshalIndent(doc.Schema(baseURL), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", doc.Filename, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(targetDir, doc.Filename), append(encoded, '\n'), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+const metricsPath = "/metrics"
+
+// renderDurationBuckets are the upper bounds (in seconds) of the histogram
+// for rendering personalized index pages.
+var renderDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+// metrics collects the server's metrics and exposes them in the Prometheus
+// text format. The few metrics we have do not justify depending on the
+// Prometheus client library.
+type metrics struct {
+	lock sync.Mutex
+
+	// requests per handler and HTTP status code
+	requests map[requestKey]uint64
+
+	renderBuckets []uint64
+	renderSum     float64
+	renderCount   uint64
+
+	databaseLoad time.Duration
+}
+
+type requestKey struct {
+	handler string
+	code    int
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		requests:      map[requestKey]uint64{},
+		renderBuckets: make([]uint64, len(renderDurationBuckets)),
+	}
+}
+
+func (m *metrics) observeDatabaseLoad(d time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.databaseLoad = d
+}
+
+func (m *metrics) observeRender(d time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	seconds := d.Seconds()
+	for i, bound := range renderDurationBuckets {
+		if seconds <= bound {
+			m.renderBuckets[i]++
+		}
+	}
+
+	m.renderSum += seconds
+	m.renderCount++
+}
+
+func (m *metrics) countRequest(handler string, code int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.requests[requestKey{handler: handler, code: code}]++
+}
+
+// instrument wraps a handler to count its requests by status code.
+func (m *metrics) instrument(handler string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		next(recorder, r)
+		m.countRequest(handler, recorder.code)
+	}
+}
+
+// write dumps all metrics in the Prometheus text exposition format; the
+// timeline size gauges are computed from the served timeline.
+func (m *metrics) write(w io.Writer, tl *timeline.Timeline) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	keys := make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].handler != keys[j].handler {
+			return keys[i].handler < keys[j].handler
+		}
+
+		return keys[i].code < keys[j].code
+	})
+
+	fmt.Fprintln(w, "# HELP apininja_http_requests_total Number of HTTP requests handled, by handler and status code.")
+	fmt.Fprintln(w, "# TYPE apininja_http_requests_total counter")
+	for _, key := range keys {
+		fmt.Fprintf(w, "apininja_http_requests_total{handler=%q,code=\"%d\"} %d\n", key.handler, key.code, m.requests[key])
+	}
+
+	fmt.Fprintln(w, "# HELP apininja_render_duration_seconds Time spent rendering personalized index pages.")
+	fmt.Fprintln(w, "# TYPE apininja_render_duration_seconds histogram")
+	for i, bound := range renderDurationBuckets {
+		fmt.Fprintf(w, "apininja_render_duration_seconds_bucket{le=%q} %d\n", formatFloat(bound), m.renderBuckets[i])
+	}
+	fmt.Fprintf(w, "apininja_render_duration_seconds_bucket{le=\"+Inf\"} %d\n", m.renderCount)
+	fmt.Fprintf(w, "apininja_render_duration_seconds_sum %s\n", formatFloat(m.renderSum))
+	fmt.Fprintf(w, "apininja_render_duration_seconds_count %d\n", m.renderCount)
+
+	fmt.Fprintln(w, "# HELP apininja_database_load_duration_seconds Time it took to load all releases from the database.")
+	fmt.Fprintln(w, "# TYPE apininja_database_load_duration_seconds gauge")
+	fmt.Fprintf(w, "apininja_database_load_duration_seconds %s\n", formatFloat(m.databaseLoad.Seconds()))
+
+	// without a timeline, the server answers from a resource index
+	if tl == nil {
+		return
+	}
+
+	groups, versions, resources := timelineSize(tl)
+
+	writeGauge(w, "apininja_timeline_releases", "Number of releases in the served timeline.", len(tl.Releases))
+	writeGauge(w, "apininja_timeline_api_groups", "Number of API groups in the served timeline.", groups)
+	writeGauge(w, "apininja_timeline_api_versions", "Number of API versions in the served timeline.", versions)
+	writeGauge(w, "apininja_timeline_api_resources", "Number of API resources in the served timeline.", resources)
+}
+
+func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	var tl *timeline.Timeline
+	if s.data != nil {
+		tl = s.data.Timeline
+	}
+
+	s.metrics.write(w, tl)
+}
+
+func writeGauge(w io.Writer, name string, help string, value int) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+	fmt.Fprintf(w, "%s %d\n", name, value)
+}
+
+func timelineSize(tl *timeline.Timeline) (groups int, versions int, resources int) {
+	for _, apiGroup := range tl.APIGroups {
+		groups++
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			versions++
+			resources += len(apiVersion.Resources)
+		}
+	}
+
+	return groups, versions, resources
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// statusRecorder remembers the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/render"
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+// writePermalinks creates a redirect stub for every permalink (like
+// "api/networking.k8s.io/v1/Ingress/"), pointing to the resource's row on the
+// timeline, so that external documentation can deep-link to a resource's
+// history without depending on the layout of the site.
+func writePermalinks(outputDir string, tl *timeline.Timeline) error {
+	for _, permalink := range tl.Permalinks() {
+		target := "index.html#" + render.ResourceAnchor(permalink.Group, permalink.Version, permalink.Kind)
+
+		if err := writeRedirectStub(outputDir, permalink.Path+"/index.html", target); err != nil {
+			return fmt.Errorf("failed to write permalink %s: %w", permalink.Path, err)
+		}
+	}
+
+	return nil
+}
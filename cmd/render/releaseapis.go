@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+
+	"sigs.k8s.io/yaml"
+)
+
+// writeReleaseAPIs publishes the API of each release shown on the site as
+// releases/<release>.json and .yaml (next to the plain-text release pages),
+// so that tools can fetch the API surface of exactly one release without
+// downloading the entire timeline. Unlike the timeline, these files contain
+// the release's data as-is, i.e. without overlays.
+func writeReleaseAPIs(ctx context.Context, targetDir string, releases []*database.KubernetesRelease, tl *timeline.Timeline) error {
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", targetDir, err)
+	}
+
+	for _, release := range releases {
+		if !tl.HasRelease(release.Version()) {
+			continue
+		}
+
+		api, err := release.API(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load API of release %s: %w", release.Version(), err)
+		}
+
+		log.Printf("Writing releases/%s.json…", release.Version())
+
+		encoded, err := json.MarshalIndent(api, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode API of release %s: %w", release.Version(), err)
+		}
+
+		if err := os.WriteFile(filepath.Join(targetDir, release.Version()+".json"), append(encoded, '\n'), 0644); err != nil {
+			return err
+		}
+
+		encoded, err = yaml.Marshal(api)
+		if err != nil {
+			return fmt.Errorf("failed to encode API of release %s: %w", release.Version(), err)
+		}
+
+		if err := os.WriteFile(filepath.Join(targetDir, release.Version()+".yaml"), encoded, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/render"
+	"go.xrstf.de/kube-api.ninja/pkg/view"
+)
+
+type server struct {
+	outputDir string
+	index     render.Renderable
+	// data is nil when serving from a resource index, in which case only
+	// the static files and the index-backed API endpoints are available.
+	data      *pageData
+	redirects map[string]string
+	metrics   *metrics
+	resources *database.ResourceIndex
+	etags     map[string]string
+	started   time.Time
+}
+
+// serve makes the rendered site available via HTTP; in addition to the
+// static files, the index page can be rendered with a personalized view.
+// Without data, the timeline is not available and API requests are answered
+// from the resource index instead.
+func serve(ctx context.Context, addr string, outputDir string, urlMapFile string, htmlTemplates []render.Renderable, data *pageData, resources *database.ResourceIndex, m *metrics) error {
+	s := &server{
+		outputDir: outputDir,
+		data:      data,
+		metrics:   m,
+		resources: resources,
+		started:   time.Now(),
+	}
+
+	for _, t := range htmlTemplates {
+		if t.Name() == "index.html" {
+			s.index = t
+		}
+	}
+
+	if s.index == nil && data != nil {
+		return errors.New("no index.html template found")
+	}
+
+	urls, err := loadURLMap(urlMapFile)
+	if err != nil {
+		return fmt.Errorf("failed to load URL map: %w", err)
+	}
+	s.redirects = urls.Redirects
+
+	s.etags, err = hashFiles(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to hash rendered files: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.metrics.instrument("site", s.handleIndex))
+	mux.HandleFunc(apiPrefix, s.metrics.instrument("api", s.handleAPI))
+	mux.HandleFunc(metricsPath, s.handleMetrics)
+
+	if data != nil {
+		mux.HandleFunc(graphqlPath, s.metrics.instrument("graphql", s.handleGraphQL))
+		mux.HandleFunc(badgePrefix, s.metrics.instrument("badge", s.handleBadge))
+	}
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Serving %s on %s…", outputDir, addr)
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if target, ok := s.redirects[strings.TrimPrefix(r.URL.Path, "/")]; ok {
+		http.Redirect(w, r, "/"+target, http.StatusMovedPermanently)
+		return
+	}
+
+	if (r.URL.Path != "/" && r.URL.Path != "/index.html") || s.data == nil {
+		s.serveFile(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+
+	// human-friendly parameters are turned into an encoded view
+	if query.Has("pin") || query.Has("groups") || query.Has("stable") {
+		state := &view.State{
+			Pinned:     splitList(query.Get("pin")),
+			Groups:     splitList(query.Get("groups")),
+			StableOnly: query.Get("stable") == "true",
+		}
+
+		target := url.URL{Path: r.URL.Path, RawQuery: url.Values{view.QueryParameter: []string{state.Encode()}}.Encode()}
+		http.Redirect(w, r, target.String(), http.StatusSeeOther)
+		return
+	}
+
+	state := &view.State{}
+	if encoded := query.Get(view.QueryParameter); encoded != "" {
+		var err error
+
+		state, err = view.Decode(encoded)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	data := *s.data
+	data.CurrentPage = "index.html"
+	data.Timeline = state.Apply(s.data.Timeline)
+	data.View = state
+
+	// render into a buffer first to not send half a page on errors
+	var buf bytes.Buffer
+
+	start := time.Now()
+	if err := s.index.Execute(&buf, &data); err != nil {
+		log.Printf("Failed to render index: %v", err)
+		http.Error(w, "failed to render page", http.StatusInternalServerError)
+		return
+	}
+	s.metrics.observeRender(time.Since(start))
+
+	s.serveContent(w, r, "text/html; charset=utf-8", buf.Bytes())
+}
+
+func splitList(s string) []string {
+	result := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	sitemapFilename = "sitemap.xml"
+	robotsFilename  = "robots.txt"
+)
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Location string `xml:"loc"`
+}
+
+// writeSitemap lists all pages in the output directory (HTML pages and the
+// plain-text release pages, but no data files or redirect stubs) in a
+// sitemap, so that new pages are picked up without having to maintain a
+// list. Directories of other profiles inside the output directory are
+// skipped, as they get their own sitemap.
+func writeSitemap(outputDir string, baseURL string, skipDirs []string) error {
+	pages, _, err := publishedURLs(outputDir, skipDirs)
+	if err != nil {
+		return fmt.Errorf("failed to determine published URLs: %w", err)
+	}
+
+	urlSet := sitemapURLSet{
+		XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9",
+	}
+
+	for _, page := range sets.List(pages) {
+		if !strings.HasSuffix(page, ".html") && !strings.HasSuffix(page, ".md") {
+			continue
+		}
+
+		// crawlers should not index the same page twice
+		if page == "index.html" || strings.HasSuffix(page, "/index.html") {
+			page = strings.TrimSuffix(page, "index.html")
+		}
+
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{Location: baseURL + page})
+	}
+
+	encoded, err := xml.MarshalIndent(urlSet, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(outputDir, sitemapFilename), append([]byte(xml.Header), append(encoded, '\n')...), 0644)
+}
+
+// writeRobots allows crawling everything and points to the sitemaps of all
+// profiles. robots.txt is only honored at the root of a host, so this should
+// only be written for the profile published there.
+func writeRobots(outputDir string, sitemapURLs []string) error {
+	var sb strings.Builder
+	sb.WriteString("User-agent: *\nAllow: /\n\n")
+
+	for _, url := range sitemapURLs {
+		fmt.Fprintf(&sb, "Sitemap: %s\n", url)
+	}
+
+	return os.WriteFile(filepath.Join(outputDir, robotsFilename), []byte(sb.String()), 0644)
+}
@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/changelog"
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+	"go.xrstf.de/kube-api.ninja/pkg/webhook"
+)
+
+const (
+	snapshotFilename  = "timeline.json"
+	csvFilename       = "timeline.csv"
+	changelogFilename = "changelog.json"
+
+	// partitionDirectory contains the timeline split by API group, see
+	// timeline.PartitionIndex
+	partitionDirectory = "timeline"
+)
+
+// updateChangelog compares the timeline with the snapshot of the previous
+// build in outputDir and returns the changelog including the new changes.
+// The returned flag is true if the data has changed (or there was no
+// previous build).
+func updateChangelog(outputDir string, tl *timeline.Timeline, now time.Time, trackChanges bool) ([]changelog.Entry, bool, error) {
+	entries, err := changelog.Load(filepath.Join(outputDir, changelogFilename))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !trackChanges {
+		return entries, false, nil
+	}
+
+	previous, err := loadSnapshot(filepath.Join(outputDir, snapshotFilename))
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the very first build has nothing to compare against
+	if previous == nil {
+		return entries, true, nil
+	}
+
+	changes := changelog.Compare(previous, tl)
+	log.Printf("Found %d change(s) since the previous build.", len(changes))
+
+	return changelog.Prepend(entries, now, changes), len(changes) > 0, nil
+}
+
+func loadSnapshot(filename string) (*timeline.Timeline, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	tl := &timeline.Timeline{}
+	if err := json.Unmarshal(data, tl); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	return tl, nil
+}
+
+// saveSnapshot stores the timeline and changelog for the next build to
+// compare against.
+func saveSnapshot(outputDir string, tl *timeline.Timeline, entries []changelog.Entry) error {
+	if err := writeExports(outputDir, tl); err != nil {
+		return err
+	}
+
+	return changelog.Save(filepath.Join(outputDir, changelogFilename), entries)
+}
+
+// writeExports writes the timeline in all machine-readable formats.
+func writeExports(targetDir string, tl *timeline.Timeline) error {
+	data, err := json.Marshal(tl)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(targetDir, snapshotFilename), data, 0644); err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(targetDir, csvFilename))
+	if err != nil {
+		return err
+	}
+
+	if err := tl.WriteCSV(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", csvFilename, err)
+	}
+
+	return f.Close()
+}
+
+// writePartitionedTimeline writes one JSON document per API group and an
+// index.json listing them, replacing any previously partitioned timeline.
+func writePartitionedTimeline(targetDir string, tl *timeline.Timeline) error {
+	if err := os.RemoveAll(targetDir); err != nil {
+		return fmt.Errorf("failed to remove previous %s directory: %w", targetDir, err)
+	}
+
+	log.Printf("Writing %s/…", filepath.Base(targetDir))
+
+	index := tl.Partition()
+
+	for i, entry := range index.APIGroups {
+		filename := filepath.Join(targetDir, filepath.FromSlash(entry.Path))
+
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return fmt.Errorf("failed to create %s directory: %w", filepath.Dir(filename), err)
+		}
+
+		data, err := json.Marshal(tl.APIGroups[i])
+		if err != nil {
+			return fmt.Errorf("failed to encode API group %s: %w", entry.Name, err)
+		}
+
+		if err := os.WriteFile(filename, data, 0644); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+
+	return os.WriteFile(filepath.Join(targetDir, "index.json"), data, 0644)
+}
+
+type archiveIndex struct {
+	Snapshots []archiveSnapshot `json:"snapshots"`
+}
+
+type archiveSnapshot struct {
+	Date string `json:"date"`
+	Path string `json:"path"`
+	// Files are relative to the path.
+	Files []string `json:"files"`
+}
+
+// archiveExports stores the exports in a dated directory below archiveDir
+// (like "<archiveDir>/<profile>/2023-12-05/timeline.json") and records it in
+// the index.json, so the history of the dataset itself can be queried. This
+// layout can be synced into a bucket as-is.
+func archiveExports(archiveDir string, profile string, tl *timeline.Timeline, now time.Time) error {
+	profileDir := filepath.Join(archiveDir, profile)
+	date := now.Format("2006-01-02")
+
+	targetDir := filepath.Join(profileDir, date)
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", targetDir, err)
+	}
+
+	if err := writeExports(targetDir, tl); err != nil {
+		return err
+	}
+
+	indexFile := filepath.Join(profileDir, "index.json")
+
+	index := archiveIndex{Snapshots: []archiveSnapshot{}}
+	if data, err := os.ReadFile(indexFile); err == nil {
+		if err := json.Unmarshal(data, &index); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", indexFile, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// multiple updates on the same day replace each other
+	snapshots := []archiveSnapshot{{
+		Date:  date,
+		Path:  date + "/",
+		Files: []string{snapshotFilename, csvFilename},
+	}}
+
+	for _, snapshot := range index.Snapshots {
+		if snapshot.Date != date {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Date > snapshots[j].Date
+	})
+
+	index.Snapshots = snapshots
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Archived snapshot %s/%s.", profile, date)
+
+	return os.WriteFile(indexFile, append(data, '\n'), 0644)
+}
+
+// notifyWebhooks sends the changes of the current build to all configured
+// webhooks.
+func notifyWebhooks(ctx context.Context, config *siteConfig, profile siteProfile, entries []changelog.Entry, now time.Time) error {
+	payload := webhook.Payload{
+		Profile: profile.Name,
+		URL:     config.Branding.URL + profile.Path,
+		Date:    now,
+		Changes: []changelog.Change{},
+	}
+
+	// the very first build has no previous build to compare against
+	if len(entries) > 0 && entries[0].Date.Equal(now) {
+		payload.Changes = entries[0].Changes
+	}
+
+	log.Printf("Notifying webhooks about %d change(s)…", len(payload.Changes))
+
+	return webhook.Send(ctx, nil, config.Webhooks, payload)
+}
@@ -0,0 +1,269 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const redirectsFilename = "_redirects"
+
+// urlMap records every URL that was ever published, so that links to pages
+// that are not generated anymore can be redirected instead of ending in a 404.
+type urlMap struct {
+	// Pages are paths relative to the site root, like "releases/1.29.md".
+	Pages []string `json:"pages"`
+	// Anchors are like "about.html#faq-markdown"; they cannot be redirected,
+	// but removing them is reported.
+	Anchors []string `json:"anchors"`
+	// Redirects maps removed pages to their replacement.
+	Redirects map[string]string `json:"redirects"`
+}
+
+var anchorPattern = regexp.MustCompile(`\sid="([^"]+)"`)
+
+// redirectMarker identifies redirect stubs, which are not considered pages.
+const redirectMarker = "data-redirect-stub"
+
+var redirectTemplate = template.Must(template.New("redirect").Parse(`<!doctype html>
+<html lang="en" ` + redirectMarker + `>
+<head>
+  <meta charset="utf-8">
+  <title>Moved</title>
+  <link rel="canonical" href="{{ . }}">
+  <meta http-equiv="refresh" content="0; url={{ . }}">
+</head>
+<body>
+  <p>This page has moved to <a href="{{ . }}">{{ . }}</a>.</p>
+</body>
+</html>
+`))
+
+// updateURLMap compares the files in the output directory against all
+// previously published pages. Removed pages are redirected to the target
+// configured in the site config or to a sensible fallback: HTML pages get a
+// redirect stub, and all redirects are listed in a _redirects file (as
+// understood by many static hosters, using basePath as the URL prefix) and
+// returned for the built-in server. Directories of other profiles inside the
+// output directory are skipped. The URL map itself is stored outside of the
+// output directory in mapFile.
+func updateURLMap(outputDir string, mapFile string, basePath string, configured map[string]string, skipDirs []string) (map[string]string, error) {
+	previous, err := loadURLMap(mapFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, anchors, err := publishedURLs(outputDir, skipDirs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine published URLs: %w", err)
+	}
+
+	redirects := map[string]string{}
+	for _, page := range sets.List(sets.New(previous.Pages...).Difference(pages)) {
+		target, ok := configured[page]
+		if !ok {
+			target = fallbackURL(page)
+		}
+
+		redirects[page] = target
+
+		if strings.HasSuffix(page, ".html") {
+			if err := writeRedirectStub(outputDir, page, target); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, anchor := range sets.List(sets.New(previous.Anchors...).Difference(anchors)) {
+		page, _, _ := strings.Cut(anchor, "#")
+		if pages.Has(page) {
+			log.Printf("Warning: anchor %s has been removed.", anchor)
+		}
+	}
+
+	if len(redirects) > 0 {
+		log.Printf("Redirecting %d removed page(s).", len(redirects))
+	}
+
+	if err := writeRedirectsFile(filepath.Join(outputDir, redirectsFilename), basePath, redirects); err != nil {
+		return nil, err
+	}
+
+	updated := urlMap{
+		Pages:     sets.List(pages.Union(sets.New(previous.Pages...))),
+		Anchors:   sets.List(anchors.Union(sets.New(previous.Anchors...))),
+		Redirects: redirects,
+	}
+
+	data, err := json.MarshalIndent(updated, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	data = append(data, '\n')
+
+	// the map is part of the repository, so it is only touched when a new
+	// URL was published
+	if existing, err := os.ReadFile(mapFile); err == nil && bytes.Equal(existing, data) {
+		return redirects, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(mapFile), 0755); err != nil {
+		return nil, err
+	}
+
+	return redirects, os.WriteFile(mapFile, data, 0644)
+}
+
+func loadURLMap(filename string) (*urlMap, error) {
+	m := &urlMap{}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return m, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	return m, nil
+}
+
+// publishedURLs returns all files in the output directory (except redirect
+// stubs), plus the anchors in all HTML pages.
+func publishedURLs(outputDir string, skipDirs []string) (sets.Set[string], sets.Set[string], error) {
+	pages := sets.New[string]()
+	anchors := sets.New[string]()
+
+	skip := sets.New[string]()
+	for _, dir := range skipDirs {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		skip.Insert(abs)
+	}
+
+	root, err := filepath.Abs(outputDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if skip.Has(path) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.ToSlash(rel)
+		if rel == redirectsFilename {
+			return nil
+		}
+
+		if !strings.HasSuffix(rel, ".html") {
+			pages.Insert(rel)
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Contains(content, []byte(redirectMarker)) {
+			return nil
+		}
+
+		pages.Insert(rel)
+
+		for _, match := range anchorPattern.FindAllSubmatch(content, -1) {
+			anchors.Insert(fmt.Sprintf("%s#%s", rel, match[1]))
+		}
+
+		return nil
+	})
+
+	return pages, anchors, err
+}
+
+// fallbackURL returns the closest replacement for a removed page.
+func fallbackURL(page string) string {
+	if strings.HasSuffix(page, ".md") {
+		return "index.md"
+	}
+
+	return ""
+}
+
+// writeRedirectStub writes an HTML page that immediately redirects to the
+// target. The target is relative, so the site can be hosted in a subdirectory.
+func writeRedirectStub(outputDir string, page string, target string) error {
+	relTarget := strings.Repeat("../", strings.Count(page, "/")) + target
+	if relTarget == "" {
+		relTarget = "./"
+	}
+
+	filename := filepath.Join(outputDir, filepath.FromSlash(page))
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := redirectTemplate.Execute(f, relTarget); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to render redirect for %s: %w", page, err)
+	}
+
+	return f.Close()
+}
+
+func writeRedirectsFile(filename string, basePath string, redirects map[string]string) error {
+	pages := make([]string, 0, len(redirects))
+	for page := range redirects {
+		pages = append(pages, page)
+	}
+	sort.Strings(pages)
+
+	var sb strings.Builder
+	for _, page := range pages {
+		fmt.Fprintf(&sb, "/%s%s /%s%s 301\n", basePath, page, basePath, redirects[page])
+	}
+
+	return os.WriteFile(filename, []byte(sb.String()), 0644)
+}
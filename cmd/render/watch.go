@@ -0,0 +1,471 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/render"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	templateDirectory = "templates"
+	watchInterval     = time.Second
+)
+
+// siteUpdate is sent to the server whenever the site was rebuilt.
+type siteUpdate struct {
+	htmlTemplates []render.Renderable
+	data          pageData
+}
+
+// inputChanges lists the changed files, by the kind of input.
+type inputChanges struct {
+	templates []string
+	data      []string
+	assets    []string
+}
+
+func (c inputChanges) empty() bool {
+	return len(c.templates) == 0 && len(c.data) == 0 && len(c.assets) == 0
+}
+
+// watch polls the data, asset and template directories and updates the pages
+// that depend on the changed files: template changes re-render the pages
+// using the changed templates (including partials), asset changes copy the
+// changed assets and data changes rebuild the profiles using that data
+// (combine with -cache to only merge changed releases again). If the site is
+// also served, the server is restarted after every update.
+func (s *site) watch(ctx context.Context) error {
+	templatePaths := []string{templateDirectory}
+	dataPaths := s.dataPaths()
+	assetPaths := s.assetPaths()
+
+	templatesState, err := scanFiles(templatePaths)
+	if err != nil {
+		return fmt.Errorf("failed to scan templates: %w", err)
+	}
+
+	dataState, err := scanFiles(dataPaths)
+	if err != nil {
+		return fmt.Errorf("failed to scan data: %w", err)
+	}
+
+	assetsState, err := scanFiles(assetPaths)
+	if err != nil {
+		return fmt.Errorf("failed to scan assets: %w", err)
+	}
+
+	updates := make(chan siteUpdate, 1)
+	serveErr := make(chan error, 1)
+
+	if s.opts.listen != "" {
+		go func() {
+			serveErr <- s.serveUpdates(ctx, updates)
+		}()
+	}
+
+	watched := append(append(templatePaths, dataPaths...), assetPaths...)
+	log.Printf("Watching %s for changes…", strings.Join(watched, ", "))
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if s.opts.listen != "" {
+				return <-serveErr
+			}
+
+			return nil
+		case err := <-serveErr:
+			return err
+		case <-ticker.C:
+		}
+
+		var changes inputChanges
+
+		changes.templates, err = updateFiles(&templatesState, templatePaths)
+		if err != nil {
+			log.Printf("Warning: failed to scan templates: %v", err)
+			continue
+		}
+
+		changes.data, err = updateFiles(&dataState, dataPaths)
+		if err != nil {
+			log.Printf("Warning: failed to scan data: %v", err)
+			continue
+		}
+
+		changes.assets, err = updateFiles(&assetsState, assetPaths)
+		if err != nil {
+			log.Printf("Warning: failed to scan assets: %v", err)
+			continue
+		}
+
+		// the URL maps are written by every build
+		changes.data = slices.DeleteFunc(changes.data, s.generatedFiles().Has)
+
+		if changes.empty() {
+			continue
+		}
+
+		// a broken template or data file must not end the watch, as it is
+		// most likely fixed soon
+		if err := s.rebuild(ctx, changes); err != nil {
+			log.Printf("Failed to rebuild site: %v", err)
+			continue
+		}
+
+		log.Println("Done.")
+
+		if s.opts.listen != "" {
+			// replace a pending update that the server has not picked up yet
+			select {
+			case <-updates:
+			default:
+			}
+
+			updates <- siteUpdate{htmlTemplates: s.htmlTemplates, data: *s.pages[0]}
+		}
+	}
+}
+
+func (s *site) rebuild(ctx context.Context, changes inputChanges) error {
+	if len(changes.templates) > 0 {
+		log.Println("Templates have changed, reloading…")
+
+		if err := s.loadTemplates(); err != nil {
+			return err
+		}
+	}
+
+	// building a profile copies the assets and renders all pages anyway
+	rebuilt := s.affectedProfiles(changes.data)
+	if rebuilt.Len() > 0 {
+		log.Printf("Data of %s has changed, rebuilding…", strings.Join(sets.List(rebuilt), ", "))
+
+		if err := s.buildProfiles(ctx, rebuilt); err != nil {
+			return err
+		}
+	}
+
+	var pages sets.Set[string]
+	if len(changes.templates) > 0 {
+		dependencies, err := templateDependencies(templateDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to determine template dependencies: %w", err)
+		}
+
+		pages = affectedTemplates(dependencies, changes.templates)
+		if pages != nil {
+			log.Printf("Re-rendering pages based on %s…", strings.Join(sets.List(pages), ", "))
+		}
+	}
+
+	for i, profile := range s.config.Profiles {
+		if rebuilt.Has(profile.Name) {
+			continue
+		}
+
+		if len(changes.assets) > 0 {
+			if err := copyChangedAssets(s.config.Assets, profile.Output, changes.assets); err != nil {
+				return fmt.Errorf("failed to copy static assets: %w", err)
+			}
+		}
+
+		if len(changes.templates) > 0 {
+			if err := renderPages(profile.Output, s.htmlTemplates, s.textTemplates, s.pages[i], pages); err != nil {
+				return fmt.Errorf("failed to render profile %s: %w", profile.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// serveUpdates serves the first profile and restarts the server whenever an
+// update arrives, so that it uses the new data.
+func (s *site) serveUpdates(ctx context.Context, updates <-chan siteUpdate) error {
+	current := siteUpdate{htmlTemplates: s.htmlTemplates, data: *s.pages[0]}
+
+	for {
+		serveCtx, cancel := context.WithCancel(ctx)
+		errs := make(chan error, 1)
+
+		go func(update siteUpdate) {
+			errs <- serve(serveCtx, s.opts.listen, s.config.Profiles[0].Output, s.config.Profiles[0].urlMapFile(), update.htmlTemplates, &update.data, nil, s.metrics)
+		}(current)
+
+		select {
+		case err := <-errs:
+			cancel()
+			return err
+
+		case current = <-updates:
+			cancel()
+
+			if err := <-errs; err != nil {
+				return err
+			}
+
+			if ctx.Err() != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// dataPaths returns all local files and directories the site is built from.
+func (s *site) dataPaths() []string {
+	paths := []string{}
+
+	if s.config.Source.Kind == "directory" {
+		paths = append(paths, s.config.Source.Options["path"])
+	}
+
+	for _, profile := range s.config.Profiles {
+		paths = append(paths, profile.Overlays...)
+
+		if profile.Annotations != "" {
+			paths = append(paths, profile.Annotations)
+		}
+	}
+
+	return paths
+}
+
+// assetPaths returns the assets directory, unless the site is rendered into
+// it (like with the default config, which uses public/ for both), because
+// then the assets are used in place and every build would change them.
+func (s *site) assetPaths() []string {
+	assets, err := filepath.Abs(s.config.Assets)
+	if err != nil {
+		return nil
+	}
+
+	for _, profile := range s.config.Profiles {
+		output, err := filepath.Abs(profile.Output)
+		if err != nil {
+			return nil
+		}
+
+		if output == assets || strings.HasPrefix(output, assets+string(filepath.Separator)) {
+			return nil
+		}
+	}
+
+	return []string{s.config.Assets}
+}
+
+// affectedProfiles returns the names of all profiles that are built from
+// any of the given files. The data directory is used by all profiles, while
+// overlays and annotations only affect the profiles that configure them.
+func (s *site) affectedProfiles(files []string) sets.Set[string] {
+	result := sets.New[string]()
+
+	source := ""
+	if s.config.Source.Kind == "directory" {
+		source = s.config.Source.Options["path"]
+	}
+
+	for _, profile := range s.config.Profiles {
+		inputs := []string{source, profile.Annotations}
+		inputs = append(inputs, profile.Overlays...)
+
+		for _, file := range files {
+			for _, input := range inputs {
+				if input != "" && isWithin(file, input) {
+					result.Insert(profile.Name)
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// generatedFiles returns the files below the data paths that are written by
+// the build itself and so must not trigger another build.
+func (s *site) generatedFiles() sets.Set[string] {
+	result := sets.New[string]()
+	for _, profile := range s.config.Profiles {
+		result.Insert(filepath.Clean(profile.urlMapFile()))
+	}
+
+	return result
+}
+
+// isWithin returns true if path is the given file or directory, or located
+// below it.
+func isWithin(path string, fileOrDir string) bool {
+	rel, err := filepath.Rel(fileOrDir, path)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+var (
+	// templates can be defined in any file, e.g. the shared partials
+	defineAction   = regexp.MustCompile(`{{-?\s*(?:define|block)\s+"([^"]+)"`)
+	templateAction = regexp.MustCompile(`{{-?\s*(?:template|block)\s+"([^"]+)"`)
+)
+
+// templateDependencies returns for each file in the template directory the
+// names of all template files it is rendered from: the file itself plus the
+// files defining the templates it includes (transitively).
+func templateDependencies(dir string) (map[string]sets.Set[string], error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	definedIn := map[string]string{}
+	references := map[string]sets.Set[string]{}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		name := filepath.Base(file)
+
+		// every file is a template named after the file
+		definedIn[name] = name
+		for _, match := range defineAction.FindAllSubmatch(content, -1) {
+			definedIn[string(match[1])] = name
+		}
+
+		references[name] = sets.New[string]()
+		for _, match := range templateAction.FindAllSubmatch(content, -1) {
+			references[name].Insert(string(match[1]))
+		}
+	}
+
+	result := map[string]sets.Set[string]{}
+	for name := range references {
+		dependencies := sets.New(name)
+		queue := []string{name}
+
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+
+			for ref := range references[current] {
+				if file, ok := definedIn[ref]; ok && !dependencies.Has(file) {
+					dependencies.Insert(file)
+					queue = append(queue, file)
+				}
+			}
+		}
+
+		result[name] = dependencies
+	}
+
+	return result, nil
+}
+
+// affectedTemplates returns the names of all templates that depend on any of
+// the changed files. If a template file was removed, its dependents cannot be
+// determined anymore and nil is returned, which means all templates.
+func affectedTemplates(dependencies map[string]sets.Set[string], changed []string) sets.Set[string] {
+	changedNames := sets.New[string]()
+	for _, file := range changed {
+		name := filepath.Base(file)
+		if _, exists := dependencies[name]; !exists {
+			return nil
+		}
+
+		changedNames.Insert(name)
+	}
+
+	result := sets.New[string]()
+	for name, files := range dependencies {
+		if files.HasAny(sets.List(changedNames)...) {
+			result.Insert(name)
+		}
+	}
+
+	return result
+}
+
+// fileStates summarizes the sizes and modification times of files, which is
+// much cheaper than hashing their contents.
+type fileStates map[string]string
+
+// scanFiles returns the states of all files below the given paths. Paths that
+// do not exist are ignored.
+func scanFiles(paths []string) (fileStates, error) {
+	states := fileStates{}
+
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+
+				return err
+			}
+
+			if d.IsDir() {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			states[filepath.Clean(path)] = fmt.Sprintf("%d %d", info.Size(), info.ModTime().UnixNano())
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return states, nil
+}
+
+// updateFiles updates the states to the current states of the files below
+// the paths and returns all files that were added, removed or modified, in
+// alphabetical order.
+func updateFiles(states *fileStates, paths []string) ([]string, error) {
+	current, err := scanFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := sets.New[string]()
+	for file, state := range current {
+		if (*states)[file] != state {
+			changed.Insert(file)
+		}
+	}
+
+	for file := range *states {
+		if _, exists := current[file]; !exists {
+			changed.Insert(file)
+		}
+	}
+
+	*states = current
+
+	return sets.List(changed), nil
+}
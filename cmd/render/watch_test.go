@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestScanFiles(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "releases", "1.29", "api.json")
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	if err := os.WriteFile(filename, []byte(`{}`), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	paths := []string{dir, filepath.Join(dir, "does-not-exist")}
+
+	initial, err := scanFiles(paths)
+	if err != nil {
+		t.Fatalf("Failed to scan files: %v", err)
+	}
+
+	again, err := scanFiles(paths)
+	if err != nil {
+		t.Fatalf("Failed to scan files: %v", err)
+	}
+
+	if !reflect.DeepEqual(initial, again) {
+		t.Errorf("Expected states of unchanged files to be stable, got %v and %v.", initial, again)
+	}
+
+	if _, ok := initial[filename]; !ok || len(initial) != 1 {
+		t.Errorf("Expected only %s to be scanned, got %v.", filename, initial)
+	}
+}
+
+func TestUpdateFiles(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "api.json")
+	eolFile := filepath.Join(dir, "eol.txt")
+	paths := []string{dir}
+
+	if err := os.WriteFile(filename, []byte(`{}`), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	state, err := scanFiles(paths)
+	if err != nil {
+		t.Fatalf("Failed to scan files: %v", err)
+	}
+
+	changes := []struct {
+		name     string
+		change   func() error
+		expected []string
+	}{
+		{
+			name:     "nothing changed",
+			change:   func() error { return nil },
+			expected: []string{},
+		},
+		{
+			name:     "content changed",
+			change:   func() error { return os.WriteFile(filename, []byte(`{"release": "1.29"}`), 0644) },
+			expected: []string{filename},
+		},
+		{
+			name:     "state was updated",
+			change:   func() error { return nil },
+			expected: []string{},
+		},
+		{
+			name: "modification time changed",
+			change: func() error {
+				later := time.Now().Add(time.Hour)
+				return os.Chtimes(filename, later, later)
+			},
+			expected: []string{filename},
+		},
+		{
+			name:     "file added",
+			change:   func() error { return os.WriteFile(eolFile, []byte("2025-02-28"), 0644) },
+			expected: []string{eolFile},
+		},
+		{
+			name:     "file removed",
+			change:   func() error { return os.Remove(filename) },
+			expected: []string{filename},
+		},
+	}
+
+	// the steps build on each other, so they cannot be subtests
+	for _, step := range changes {
+		if err := step.change(); err != nil {
+			t.Fatalf("%s: failed to change files: %v", step.name, err)
+		}
+
+		changed, err := updateFiles(&state, paths)
+		if err != nil {
+			t.Fatalf("%s: failed to update file states: %v", step.name, err)
+		}
+
+		if !reflect.DeepEqual(changed, step.expected) {
+			t.Errorf("%s: expected %v to have changed, got %v.", step.name, step.expected, changed)
+		}
+	}
+}
+
+func TestTemplateDependencies(t *testing.T) {
+	dir := t.TempDir()
+
+	templates := map[string]string{
+		"_partials.html": `{{ define "footer" }}<footer>{{ template "links" }}</footer>{{ end }}{{ define "links" }}<a href="#">top</a>{{ end }}`,
+		"_icons.html":    `{{ define "icon" }}<svg></svg>{{ end }}`,
+		"index.html":     `<body>{{ template "icon" }}{{ template "footer" . }}</body>`,
+		"about.html":     `<body>{{- template "footer" . -}}</body>`,
+		"feed.xml":       `<feed></feed>`,
+	}
+
+	for name, content := range templates {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template: %v", err)
+		}
+	}
+
+	dependencies, err := templateDependencies(dir)
+	if err != nil {
+		t.Fatalf("Failed to determine dependencies: %v", err)
+	}
+
+	testcases := []struct {
+		changed  []string
+		expected []string
+	}{
+		{
+			changed:  []string{filepath.Join(dir, "_partials.html")},
+			expected: []string{"_partials.html", "about.html", "index.html"},
+		},
+		{
+			changed:  []string{filepath.Join(dir, "_icons.html")},
+			expected: []string{"_icons.html", "index.html"},
+		},
+		{
+			changed:  []string{filepath.Join(dir, "feed.xml")},
+			expected: []string{"feed.xml"},
+		},
+		{
+			// a removed file could have been used by any template
+			changed:  []string{filepath.Join(dir, "removed.html")},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		affected := affectedTemplates(dependencies, tc.changed)
+
+		var names []string
+		if affected != nil {
+			names = sets.List(affected)
+		}
+
+		if !reflect.DeepEqual(names, tc.expected) {
+			t.Errorf("Expected changes to %v to affect %v, got %v.", tc.changed, tc.expected, names)
+		}
+	}
+}
+
+func TestAffectedProfiles(t *testing.T) {
+	s := &site{config: &siteConfig{
+		Source: defaultSource(),
+		Profiles: []siteProfile{
+			{Name: "full"},
+			{Name: "company", Overlays: []string{"overlays/company"}, Annotations: "annotations.yaml"},
+		},
+	}}
+
+	testcases := []struct {
+		files    []string
+		expected []string
+	}{
+		{
+			files:    []string{"data/releases/1.29/api.json"},
+			expected: []string{"company", "full"},
+		},
+		{
+			files:    []string{"overlays/company/releases/1.29/api.json"},
+			expected: []string{"company"},
+		},
+		{
+			files:    []string{"annotations.yaml"},
+			expected: []string{"company"},
+		},
+		{
+			files:    []string{"overlays/company-old/api.json"},
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		if affected := sets.List(s.affectedProfiles(tc.files)); !reflect.DeepEqual(affected, tc.expected) {
+			t.Errorf("Expected changes to %v to affect %v, got %v.", tc.files, tc.expected, affected)
+		}
+	}
+}
+
+func TestAssetPaths(t *testing.T) {
+	testcases := []struct {
+		name     string
+		assets   string
+		outputs  []string
+		expected []string
+	}{
+		{
+			name:     "rendering into the assets",
+			assets:   "public",
+			outputs:  []string{"public", "public/next"},
+			expected: nil,
+		},
+		{
+			name:     "rendering below the assets",
+			assets:   "static",
+			outputs:  []string{"static/site"},
+			expected: nil,
+		},
+		{
+			name:     "separate assets",
+			assets:   "static",
+			outputs:  []string{"public", "static-site"},
+			expected: []string{"static"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &siteConfig{Assets: tc.assets}
+			for _, output := range tc.outputs {
+				config.Profiles = append(config.Profiles, siteProfile{Output: output})
+			}
+
+			s := &site{config: config}
+
+			if paths := s.assetPaths(); !reflect.DeepEqual(paths, tc.expected) {
+				t.Errorf("Expected %v, got %v.", tc.expected, paths)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+#!/usr/bin/env bash
+
+set -e
+
+cd $(dirname $0)/..
+
+make clean build
+
+currentdev=1.29
+
+# conformance metadata is only published since Kubernetes 1.17
+for release in 1.17 1.18 1.19 1.20 1.21 1.22 1.23 1.24 1.25 1.26 1.27 1.28 1.29; do
+  echo "Dumping conformance coverage for Kubernetes $release …"
+
+  # allow to fetch the development branch before it was released
+  branch="release-$release"
+  if [[ "$currentdev" == "$release" ]]; then
+    branch="master"
+  fi
+
+  wget --output-document conformance.yaml https://github.com/kubernetes/kubernetes/raw/$branch/test/conformance/testdata/conformance.yaml
+
+  _build/conformancedumper \
+    -conformance-file conformance.yaml \
+    -api-file "data/releases/$release/api.json" \
+    > "data/releases/$release/conformance.json"
+done
+
+rm conformance.yaml
@@ -17,13 +17,31 @@ for release in 1.11 1.12 1.13 1.14 1.15 1.16 1.17 1.18 1.19 1.20 1.21 1.22 1.23
     branch="master"
   fi
 
+  mkdir -p "data/releases/$release"
+
+  # prefer the more precise OpenAPI v3 documents, which exist since 1.24
+  if [[ "$(printf '%s\n' "1.24" "$release" | sort -V | head -n1)" == "1.24" ]]; then
+    rm -rf openapi-v3
+    mkdir openapi-v3
+
+    curl --silent --fail "https://api.github.com/repos/kubernetes/kubernetes/contents/api/openapi-spec/v3?ref=$branch" |
+      jq -r '.[] | select(.name | endswith(".json")) | .download_url' |
+      xargs wget --quiet --directory-prefix openapi-v3
+
+    _build/openapidumper \
+      -spec-directory openapi-v3 \
+      -kubernetes-version "$release.0" \
+      > "data/releases/$release/api.json"
+
+    continue
+  fi
+
   wget --output-document swagger.json https://github.com/kubernetes/kubernetes/raw/$branch/api/openapi-spec/swagger.json
 
-  mkdir -p "data/releases/$release"
   _build/swaggerdumper \
     -swagger-file swagger.json \
     -kubernetes-version "$release.0" \
     > "data/releases/$release/api.json"
 done
 
-rm swagger.json
+rm -rf swagger.json openapi-v3
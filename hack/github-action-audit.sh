@@ -0,0 +1,51 @@
+#!/usr/bin/env bash
+
+# This script runs the manifest audit for the GitHub Action (see action.yml)
+# and is configured via the INPUT_* environment variables.
+
+set -euo pipefail
+
+# paths are whitespace-separated on purpose
+read -ra paths <<< "$INPUT_PATHS"
+
+if [ "$INPUT_CHANGED_FILES_ONLY" = "true" ]; then
+  if [ -z "${BASE_REF:-}" ]; then
+    echo "::error::changed-files-only can only be used in pull requests."
+    exit 1
+  fi
+
+  git fetch --no-tags origin "$BASE_REF"
+
+  changed=()
+  while IFS= read -r file; do
+    case "$file" in
+      *.yaml | *.yml | *.json) ;;
+      *) continue ;;
+    esac
+
+    for path in "${paths[@]}"; do
+      path="${path%/...}"
+      path="${path#./}"
+      path="${path%/}"
+
+      if [ "$path" = "." ] || [ "$file" = "$path" ] || [[ "$file" == "$path"/* ]]; then
+        changed+=("$file")
+        break
+      fi
+    done
+  done < <(git diff --name-only --diff-filter=ACMR "FETCH_HEAD...HEAD")
+
+  if [ ${#changed[@]} -eq 0 ]; then
+    echo "No manifests have been changed."
+    exit 0
+  fi
+
+  paths=("${changed[@]}")
+fi
+
+args=(-data "${INPUT_DATA:-$GITHUB_ACTION_PATH/data}" -target "$INPUT_TARGET" -format github)
+if [ "$INPUT_STRICT" = "true" ]; then
+  args+=(-strict)
+fi
+
+exec "$APININJA" audit "${args[@]}" "${paths[@]}"
@@ -10,6 +10,21 @@ get_version() {
   curl -sfL "https://dl.k8s.io/release/$1-$2.txt"
 }
 
+# fetch all stable GitHub releases once to record the patch history
+githubReleases="$(mktemp)"
+trap "rm -f $githubReleases" EXIT
+
+page=1
+while true; do
+  releases=$(curl -sfL "https://api.github.com/repos/kubernetes/kubernetes/releases?per_page=100&page=$page")
+  if [ "$(echo "$releases" | jq length)" -eq 0 ]; then
+    break
+  fi
+
+  echo "$releases" | jq -c '.[] | select(.prerelease | not) | {version: (.tag_name | ltrimstr("v")), date: (.published_at | split("T")[0])}' >> "$githubReleases"
+  page=$((page + 1))
+done
+
 cd data/releases/
 for release in *; do
   (
@@ -27,5 +42,10 @@ for release in *; do
       # trim leading v
       echo "${newVersion#v}" > latest.txt
     fi
+
+    patches=$(jq -s --arg release "$release" '[.[] | select(.version | startswith($release + "."))] | sort_by(.version | split(".") | map(tonumber))' "$githubReleases")
+    if [ "$(echo "$patches" | jq length)" -gt 0 ]; then
+      echo "$patches" > patches.json
+    fi
   )
 done
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package admission implements a validating admission webhook that checks
+// the API versions of incoming objects against a future Kubernetes release.
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/manifest"
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Webhook reviews objects against a target release, usually the release
+// the cluster will be upgraded to next. Objects using APIs that are
+// deprecated or will be removed later are always admitted with a warning,
+// objects using APIs that are not served by the target release anymore are
+// either admitted with a warning or denied.
+type Webhook struct {
+	timeline *timeline.Timeline
+	target   string
+	deny     bool
+}
+
+func NewWebhook(tl *timeline.Timeline, target string, deny bool) (*Webhook, error) {
+	if !tl.HasRelease(target) {
+		return nil, fmt.Errorf("%w %q", timeline.ErrUnknownRelease, target)
+	}
+
+	return &Webhook{
+		timeline: tl,
+		target:   target,
+		deny:     deny,
+	}, nil
+}
+
+// ServeHTTP handles admission.k8s.io/v1 AdmissionReviews.
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.Header().Set("Allow", "POST")
+		http.Error(rw, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	review := admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(http.MaxBytesReader(rw, r.Body, 1<<20)).Decode(&review); err != nil {
+		http.Error(rw, fmt.Sprintf("invalid AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if review.Request == nil {
+		http.Error(rw, "AdmissionReview contains no request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = w.Review(review.Request)
+	review.Request = nil
+
+	rw.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(rw).Encode(review); err != nil {
+		log.Printf("Failed to encode AdmissionReview: %v", err)
+	}
+}
+
+// Review decides whether the requested object is admitted.
+func (w *Webhook) Review(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	response := &admissionv1.AdmissionResponse{
+		UID:     req.UID,
+		Allowed: true,
+	}
+
+	// RequestKind is how the object was sent, Kind might have been converted
+	// to match the webhook's rules
+	gvk := req.Kind
+	if req.RequestKind != nil {
+		gvk = *req.RequestKind
+	}
+
+	object := manifest.Object{
+		Source:     requestSource(req),
+		APIVersion: schema.GroupVersion{Group: gvk.Group, Version: gvk.Version}.String(),
+		Kind:       gvk.Kind,
+		Name:       req.Name,
+	}
+
+	report, err := w.timeline.Audit([]manifest.Object{object}, w.target)
+	if err != nil {
+		// never block the cluster because of the webhook itself
+		response.Warnings = []string{fmt.Sprintf("kube-api.ninja could not check %s: %v", object, err)}
+		return response
+	}
+
+	for _, finding := range report.Findings {
+		message := fmt.Sprintf("%s %s is not ready for Kubernetes %s: %s", object.APIVersion, object.Kind, w.target, finding)
+
+		if w.deny && report.Failed() {
+			response.Allowed = false
+			response.Result = &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: message,
+				Reason:  metav1.StatusReasonForbidden,
+				Code:    http.StatusForbidden,
+			}
+
+			continue
+		}
+
+		response.Warnings = append(response.Warnings, message)
+	}
+
+	return response
+}
+
+func requestSource(req *admissionv1.AdmissionRequest) string {
+	parts := []string{}
+	if req.Namespace != "" {
+		parts = append(parts, req.Namespace)
+	}
+
+	if req.Name != "" {
+		parts = append(parts, req.Name)
+	}
+
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testTimeline() *timeline.Timeline {
+	return &timeline.Timeline{
+		Releases: []timeline.ReleaseMetadata{
+			{Version: "1.24"},
+			{Version: "1.25"},
+			{Version: "1.26"},
+		},
+		APIGroups: []timeline.APIGroup{
+			{
+				Name: "batch",
+				APIVersions: []timeline.APIVersion{
+					{
+						Version: "v1",
+						Resources: []timeline.APIResource{
+							{Kind: "CronJob", Releases: []string{"1.24", "1.25", "1.26"}},
+						},
+					},
+					{
+						Version: "v1beta1",
+						Resources: []timeline.APIResource{
+							{Kind: "CronJob", Releases: []string{"1.24"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "core",
+				APIVersions: []timeline.APIVersion{
+					{
+						Version: "v1",
+						Resources: []timeline.APIResource{
+							{Kind: "ComponentStatus", Releases: []string{"1.24", "1.25"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "flowcontrol.apiserver.k8s.io",
+				APIVersions: []timeline.APIVersion{
+					{
+						// the removal is only known from the curated data
+						Version: "v1beta1",
+						Resources: []timeline.APIResource{
+							{Kind: "FlowSchema", Releases: []string{"1.24", "1.25", "1.26"}, DeprecatedIn: "1.23", RemovedIn: "1.26"},
+						},
+					},
+					{
+						// the API data still lists the version in the release that removed it
+						Version: "v1alpha1",
+						Resources: []timeline.APIResource{
+							{Kind: "FlowSchema", Releases: []string{"1.24", "1.25"}, DeprecatedIn: "1.20", RemovedIn: "1.25"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestReview(t *testing.T) {
+	testcases := []struct {
+		name            string
+		deny            bool
+		kind            metav1.GroupVersionKind
+		allowed         bool
+		warnings        int
+		expectedMessage string
+	}{
+		{
+			name:    "stable API",
+			kind:    metav1.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"},
+			allowed: true,
+		},
+		{
+			name:     "removed API is only a warning by default",
+			kind:     metav1.GroupVersionKind{Group: "batch", Version: "v1beta1", Kind: "CronJob"},
+			allowed:  true,
+			warnings: 1,
+		},
+		{
+			name:            "removed API is denied",
+			deny:            true,
+			kind:            metav1.GroupVersionKind{Group: "batch", Version: "v1beta1", Kind: "CronJob"},
+			allowed:         false,
+			expectedMessage: "batch/v1beta1 CronJob is not ready for Kubernetes 1.25: removed in 1.25, use batch/v1 instead",
+		},
+		{
+			name:     "deprecated API is never denied",
+			deny:     true,
+			kind:     metav1.GroupVersionKind{Version: "v1", Kind: "ComponentStatus"},
+			allowed:  true,
+			warnings: 1,
+		},
+		{
+			name:     "deprecated flowcontrol API is a warning",
+			deny:     true,
+			kind:     metav1.GroupVersionKind{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta1", Kind: "FlowSchema"},
+			allowed:  true,
+			warnings: 1,
+		},
+		{
+			name:            "curated removal is denied",
+			deny:            true,
+			kind:            metav1.GroupVersionKind{Group: "flowcontrol.apiserver.k8s.io", Version: "v1alpha1", Kind: "FlowSchema"},
+			allowed:         false,
+			expectedMessage: "flowcontrol.apiserver.k8s.io/v1alpha1 FlowSchema is not ready for Kubernetes 1.25: removed in 1.25, use flowcontrol.apiserver.k8s.io/v1beta1 instead",
+		},
+		{
+			name:    "custom resources are ignored",
+			deny:    true,
+			kind:    metav1.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"},
+			allowed: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			webhook, err := NewWebhook(testTimeline(), "1.25", testcase.deny)
+			if err != nil {
+				t.Fatalf("Failed to create webhook: %v", err)
+			}
+
+			response := webhook.Review(&admissionv1.AdmissionRequest{
+				UID:  "abc",
+				Kind: testcase.kind,
+				Name: "test",
+			})
+
+			if response.UID != "abc" {
+				t.Errorf("Expected the request UID to be copied, got %q.", response.UID)
+			}
+
+			if response.Allowed != testcase.allowed {
+				t.Errorf("Expected allowed=%v, got %v.", testcase.allowed, response.Allowed)
+			}
+
+			if len(response.Warnings) != testcase.warnings {
+				t.Errorf("Expected %d warnings, got %v.", testcase.warnings, response.Warnings)
+			}
+
+			if testcase.expectedMessage != "" && (response.Result == nil || response.Result.Message != testcase.expectedMessage) {
+				t.Errorf("Expected message %q, got %+v.", testcase.expectedMessage, response.Result)
+			}
+		})
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	webhook, err := NewWebhook(testTimeline(), "1.25", false)
+	if err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+
+	body, err := json.Marshal(admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:         "abc",
+			Kind:        metav1.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"},
+			RequestKind: &metav1.GroupVersionKind{Group: "batch", Version: "v1beta1", Kind: "CronJob"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	webhook.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	review := admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &review); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if review.Kind != "AdmissionReview" || review.Request != nil || review.Response == nil {
+		t.Fatalf("Expected an AdmissionReview with only a response, got %+v.", review)
+	}
+
+	// the original request kind must be checked, not the converted one
+	if !review.Response.Allowed || len(review.Response.Warnings) != 1 {
+		t.Errorf("Expected the object to be admitted with a warning, got %+v.", review.Response)
+	}
+}
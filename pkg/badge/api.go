@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package badge
+
+import (
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+// ForResource returns a badge describing the status of a resource in an API
+// version as of the latest release of the timeline, e.g. whether
+// networking.k8s.io/v1 Ingress is still served.
+func ForResource(tl *timeline.Timeline, group string, version string, kind string) (Badge, error) {
+	apiResource := findResource(tl, group, version, kind)
+	if apiResource == nil {
+		return Badge{}, fmt.Errorf("unknown resource %s/%s %s", group, version, kind)
+	}
+
+	label := fmt.Sprintf("%s/%s %s", group, version, kind)
+	if group == "core" {
+		label = fmt.Sprintf("%s %s", version, kind)
+	}
+
+	b := Badge{
+		Label: label,
+	}
+
+	latest := latestRelease(tl)
+
+	switch {
+	case apiResource.RemovedIn != "" && !apiResource.HasRelease(latest):
+		b.Message = fmt.Sprintf("removed in %s", apiResource.RemovedIn)
+		b.Color = ColorRed
+
+	case apiResource.IsDeprecatedIn(latest) && apiResource.RemovedIn != "":
+		b.Message = fmt.Sprintf("deprecated, removal in %s", apiResource.RemovedIn)
+		b.Color = ColorOrange
+
+	case apiResource.IsDeprecatedIn(latest):
+		b.Message = fmt.Sprintf("deprecated since %s", apiResource.DeprecatedIn)
+		b.Color = ColorOrange
+
+	case apiResource.HasRelease(latest):
+		b.Message = fmt.Sprintf("served since %s", firstRelease(tl, apiResource))
+		b.Color = ColorGreen
+
+	default:
+		b.Message = "not served"
+		b.Color = ColorGrey
+	}
+
+	return b, nil
+}
+
+func findResource(tl *timeline.Timeline, group string, version string, kind string) *timeline.APIResource {
+	for i, apiGroup := range tl.APIGroups {
+		if apiGroup.Name != group {
+			continue
+		}
+
+		for j, apiVersion := range apiGroup.APIVersions {
+			if apiVersion.Version != version {
+				continue
+			}
+
+			for k, apiResource := range apiVersion.Resources {
+				if apiResource.Kind == kind {
+					return &tl.APIGroups[i].APIVersions[j].Resources[k]
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// firstRelease returns the earliest release of the timeline that offers the
+// resource (a resource's releases are sorted alphabetically, not by version).
+func firstRelease(tl *timeline.Timeline, apiResource *timeline.APIResource) string {
+	for _, release := range tl.Releases {
+		if apiResource.HasRelease(release.Version) {
+			return release.Version
+		}
+	}
+
+	return ""
+}
+
+// latestRelease returns the most recent release that is not speculative.
+func latestRelease(tl *timeline.Timeline) string {
+	latest := ""
+	for _, release := range tl.Releases {
+		if release.Released && !release.Projected {
+			latest = release.Version
+		}
+	}
+
+	return latest
+}
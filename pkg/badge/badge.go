@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package badge renders small shields.io-style SVG badges.
+package badge
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"unicode/utf8"
+)
+
+const (
+	ColorGreen  = "#4c1"
+	ColorRed    = "#e05d44"
+	ColorBlue   = "#007ec6"
+	ColorOrange = "#fe7d37"
+	ColorGrey   = "#9f9f9f"
+
+	labelColor = "#555"
+	height     = 20
+	padding    = 6
+	// average character width of 11px Verdana, good enough for badges
+	charWidth = 7
+)
+
+// Badge is a label/message pair, like "kubernetes 1.28" / "supported".
+type Badge struct {
+	Label   string
+	Message string
+	Color   string
+}
+
+// SVG renders the badge.
+func (b Badge) SVG() []byte {
+	labelWidth := textWidth(b.Label)
+	messageWidth := textWidth(b.Message)
+	width := labelWidth + messageWidth
+
+	label := html.EscapeString(b.Label)
+	message := html.EscapeString(b.Message)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" role="img" aria-label="%s: %s">`, width, height, label, message)
+	fmt.Fprintf(&buf, `<title>%s: %s</title>`, label, message)
+	fmt.Fprintf(&buf, `<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	fmt.Fprintf(&buf, `<clipPath id="r"><rect width="%d" height="%d" rx="3" fill="#fff"/></clipPath>`, width, height)
+	fmt.Fprintf(&buf, `<g clip-path="url(#r)"><rect width="%d" height="%d" fill="%s"/><rect x="%d" width="%d" height="%d" fill="%s"/><rect width="%d" height="%d" fill="url(#s)"/></g>`,
+		labelWidth, height, labelColor, labelWidth, messageWidth, height, html.EscapeString(b.Color), width, height)
+	fmt.Fprintf(&buf, `<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	fmt.Fprintf(&buf, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`, labelWidth/2, label, labelWidth/2, label)
+	fmt.Fprintf(&buf, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`, labelWidth+messageWidth/2, message, labelWidth+messageWidth/2, message)
+	fmt.Fprintf(&buf, `</g></svg>`)
+
+	return buf.Bytes()
+}
+
+func textWidth(s string) int {
+	return utf8.RuneCountInString(s)*charWidth + 2*padding
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package badge
+
+import (
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+// ForRelease returns a badge describing the support status of a release.
+func ForRelease(release timeline.ReleaseMetadata) Badge {
+	b := Badge{
+		Label: fmt.Sprintf("kubernetes %s", release.Version),
+	}
+
+	switch {
+	case release.Projected:
+		b.Message = "projected"
+		b.Color = ColorGrey
+
+	case !release.Released:
+		b.Message = fmt.Sprintf("planned for %s", release.ReleaseDate.Format("2006-01-02"))
+		b.Color = ColorBlue
+
+	case release.Supported && release.EndOfLifeDate != nil:
+		b.Message = fmt.Sprintf("supported until %s", release.EndOfLifeDate.Format("2006-01-02"))
+		b.Color = ColorGreen
+
+	case release.Supported:
+		b.Message = "supported"
+		b.Color = ColorGreen
+
+	default:
+		b.Message = "EOL"
+		b.Color = ColorRed
+	}
+
+	return b
+}
+
+// ForReleaseEOL returns a badge with the end-of-life date of a release.
+func ForReleaseEOL(release timeline.ReleaseMetadata) Badge {
+	b := Badge{
+		Label:   fmt.Sprintf("kubernetes %s eol", release.Version),
+		Message: "unknown",
+		Color:   ColorGrey,
+	}
+
+	if release.EndOfLifeDate != nil {
+		b.Message = release.EndOfLifeDate.Format("2006-01-02")
+		b.Color = ColorGreen
+
+		if release.Released && !release.Supported {
+			b.Color = ColorRed
+		}
+	}
+
+	return b
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package changelog compares two timelines (usually the previous and the
+// current build of the website) and keeps a history of the differences.
+package changelog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ChangeType categorizes a change.
+type ChangeType string
+
+const (
+	ReleaseAdded      ChangeType = "release-added"
+	ReleaseRemoved    ChangeType = "release-removed"
+	ReleaseUpdated    ChangeType = "release-updated"
+	APIVersionAdded   ChangeType = "apiversion-added"
+	APIVersionRemoved ChangeType = "apiversion-removed"
+	ResourceAdded     ChangeType = "resource-added"
+	ResourceRemoved   ChangeType = "resource-removed"
+	DataCorrection    ChangeType = "data-correction"
+)
+
+// the number of entries kept when saving a changelog
+const maxEntries = 100
+
+// Change is a single difference between two timelines.
+type Change struct {
+	Type    ChangeType `json:"type"`
+	Message string     `json:"message"`
+}
+
+// Entry groups all changes of a single build.
+type Entry struct {
+	Date    time.Time `json:"date"`
+	Changes []Change  `json:"changes"`
+}
+
+// Load reads a changelog file; a missing file is not an error and results
+// in an empty changelog.
+func Load(filename string) ([]Entry, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []Entry{}, nil
+		}
+
+		return nil, err
+	}
+
+	entries := []Entry{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	return entries, nil
+}
+
+// Save writes the changelog, keeping only the most recent entries.
+func Save(filename string, entries []Entry) error {
+	if len(entries) > maxEntries {
+		entries = entries[:maxEntries]
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, append(data, '\n'), 0644)
+}
+
+// Prepend adds a new entry with the given changes to the beginning of the
+// changelog, unless there are no changes.
+func Prepend(entries []Entry, date time.Time, changes []Change) []Entry {
+	if len(changes) == 0 {
+		return entries
+	}
+
+	return append([]Entry{{Date: date, Changes: changes}}, entries...)
+}
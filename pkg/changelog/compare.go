@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package changelog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Compare returns all differences between the old and the new timeline.
+// Existing resources naturally show up in newly added releases, so only
+// differences in releases that were already part of the old timeline are
+// reported as data corrections.
+func Compare(old, new *timeline.Timeline) []Change {
+	changes := []Change{}
+
+	oldReleases := sets.New[string]()
+	for _, rel := range old.Releases {
+		oldReleases.Insert(rel.Version)
+
+		if !new.HasRelease(rel.Version) {
+			changes = append(changes, Change{Type: ReleaseRemoved, Message: fmt.Sprintf("Kubernetes %s has been removed.", rel.Version)})
+		}
+	}
+
+	for _, rel := range new.Releases {
+		if !oldReleases.Has(rel.Version) {
+			changes = append(changes, Change{Type: ReleaseAdded, Message: fmt.Sprintf("Kubernetes %s has been added.", rel.Version)})
+			continue
+		}
+
+		changes = append(changes, compareReleases(old.ReleaseMetadata(rel.Version), rel)...)
+	}
+
+	changes = append(changes, compareAPIs(old, new, oldReleases)...)
+
+	return changes
+}
+
+func compareReleases(old, new timeline.ReleaseMetadata) []Change {
+	changes := []Change{}
+
+	if !old.Released && new.Released {
+		changes = append(changes, Change{Type: ReleaseUpdated, Message: fmt.Sprintf("Kubernetes %s has been released.", new.Version)})
+	}
+
+	if old.Supported && !new.Supported && new.Released {
+		changes = append(changes, Change{Type: ReleaseUpdated, Message: fmt.Sprintf("Kubernetes %s has reached its end of life.", new.Version)})
+	}
+
+	if old.LatestVersion != new.LatestVersion && new.LatestVersion != "" {
+		changes = append(changes, Change{Type: ReleaseUpdated, Message: fmt.Sprintf("Kubernetes %s has been updated to %s.", new.Version, new.LatestVersion)})
+	}
+
+	if !old.ReleaseDate.Equal(new.ReleaseDate) {
+		changes = append(changes, Change{Type: DataCorrection, Message: fmt.Sprintf("The release date of Kubernetes %s is now %s.", new.Version, formatDate(&new.ReleaseDate))})
+	}
+
+	if !equalDates(old.EndOfLifeDate, new.EndOfLifeDate) {
+		changes = append(changes, Change{Type: DataCorrection, Message: fmt.Sprintf("The end of life date of Kubernetes %s is now %s.", new.Version, formatDate(new.EndOfLifeDate))})
+	}
+
+	return changes
+}
+
+func compareAPIs(old, new *timeline.Timeline, knownReleases sets.Set[string]) []Change {
+	changes := []Change{}
+
+	oldVersions := map[string]*timeline.APIVersion{}
+	oldResources := map[string]*timeline.APIResource{}
+	for _, apiGroup := range old.APIGroups {
+		for i, apiVersion := range apiGroup.APIVersions {
+			gv := groupVersion(apiGroup.Name, apiVersion.Version)
+			oldVersions[gv] = &apiGroup.APIVersions[i]
+
+			for j, apiResource := range apiVersion.Resources {
+				oldResources[gv+" "+apiResource.Kind] = &apiGroup.APIVersions[i].Resources[j]
+			}
+		}
+	}
+
+	seen := sets.New[string]()
+
+	for _, apiGroup := range new.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			gv := groupVersion(apiGroup.Name, apiVersion.Version)
+			seen.Insert(gv)
+
+			if _, exists := oldVersions[gv]; !exists {
+				changes = append(changes, Change{Type: APIVersionAdded, Message: fmt.Sprintf("%s has been added.", gv)})
+				continue
+			}
+
+			for _, apiResource := range apiVersion.Resources {
+				key := gv + " " + apiResource.Kind
+				seen.Insert(key)
+
+				oldResource, exists := oldResources[key]
+				if !exists {
+					changes = append(changes, Change{Type: ResourceAdded, Message: fmt.Sprintf("%s %s has been added.", gv, apiResource.Kind)})
+					continue
+				}
+
+				// only consider releases that were known before, new releases
+				// naturally bring new resources
+				before := sets.New(oldResource.Releases...).Intersection(knownReleases)
+				after := sets.New(apiResource.Releases...).Intersection(knownReleases)
+
+				if added := after.Difference(before); added.Len() > 0 {
+					changes = append(changes, Change{Type: DataCorrection, Message: fmt.Sprintf("%s %s is now listed as available in %s.", gv, apiResource.Kind, strings.Join(sets.List(added), ", "))})
+				}
+
+				if removed := before.Difference(after); removed.Len() > 0 {
+					changes = append(changes, Change{Type: DataCorrection, Message: fmt.Sprintf("%s %s is no longer listed as available in %s.", gv, apiResource.Kind, strings.Join(sets.List(removed), ", "))})
+				}
+			}
+		}
+	}
+
+	for _, apiGroup := range old.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			gv := groupVersion(apiGroup.Name, apiVersion.Version)
+			if !seen.Has(gv) {
+				changes = append(changes, Change{Type: APIVersionRemoved, Message: fmt.Sprintf("%s has been removed.", gv)})
+				continue
+			}
+
+			for _, apiResource := range apiVersion.Resources {
+				if !seen.Has(gv + " " + apiResource.Kind) {
+					changes = append(changes, Change{Type: ResourceRemoved, Message: fmt.Sprintf("%s %s has been removed.", gv, apiResource.Kind)})
+				}
+			}
+		}
+	}
+
+	return changes
+}
+
+func groupVersion(group, version string) string {
+	if group == "core" {
+		return version
+	}
+
+	return fmt.Sprintf("%s/%s", group, version)
+}
+
+func equalDates(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return "unknown"
+	}
+
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package changelog
+
+import (
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+func TestCompare(t *testing.T) {
+	old := &timeline.Timeline{
+		Releases: []timeline.ReleaseMetadata{
+			{Version: "1.27", Released: true, Supported: true, LatestVersion: "1.27.4"},
+			{Version: "1.28", LatestVersion: "1.28.0-rc.0"},
+		},
+		APIGroups: []timeline.APIGroup{{
+			Name: "batch",
+			APIVersions: []timeline.APIVersion{{
+				Version: "v1",
+				Resources: []timeline.APIResource{
+					{Kind: "Job", Releases: []string{"1.27"}},
+				},
+			}},
+		}},
+	}
+
+	new := &timeline.Timeline{
+		Releases: []timeline.ReleaseMetadata{
+			{Version: "1.27", Released: true, Supported: true, LatestVersion: "1.27.4"},
+			{Version: "1.28", Released: true, Supported: true, LatestVersion: "1.28.0"},
+			{Version: "1.29"},
+		},
+		APIGroups: []timeline.APIGroup{{
+			Name: "batch",
+			APIVersions: []timeline.APIVersion{{
+				Version: "v1",
+				Resources: []timeline.APIResource{
+					// 1.29 is a new release and must not be reported
+					{Kind: "Job", Releases: []string{"1.27", "1.28", "1.29"}},
+					{Kind: "CronJob", Releases: []string{"1.29"}},
+				},
+			}},
+		}},
+	}
+
+	expected := []Change{
+		{Type: ReleaseUpdated, Message: "Kubernetes 1.28 has been released."},
+		{Type: ReleaseUpdated, Message: "Kubernetes 1.28 has been updated to 1.28.0."},
+		{Type: ReleaseAdded, Message: "Kubernetes 1.29 has been added."},
+		{Type: DataCorrection, Message: "batch/v1 Job is now listed as available in 1.28."},
+		{Type: ResourceAdded, Message: "batch/v1 CronJob has been added."},
+	}
+
+	changes := Compare(old, new)
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %v", len(expected), len(changes), changes)
+	}
+
+	for i, change := range changes {
+		if change != expected[i] {
+			t.Errorf("Expected change #%d to be %v, got %v.", i, expected[i], change)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+/*
+Package client implements a client for the kube-api.ninja JSON API, for
+tools that want to consume the merged timeline without bundling the
+release database themselves.
+
+Client.Timeline uses the timeline.json export, which is part of every
+rendered site, so it works with the static public site as well as with
+"render -listen". All other methods use the /api/v1 endpoints, which are
+only served by "render -listen".
+
+This package is part of the supported Go API of kube-api.ninja and follows
+semantic versioning, see the README for details.
+*/
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+// DefaultBaseURL is the public kube-api.ninja instance.
+const DefaultBaseURL = "https://kube-api.ninja"
+
+// ErrNotFound is returned when the requested release, API group or resource
+// does not exist, or the instance does not serve the requested endpoint.
+var ErrNotFound = errors.New("not found")
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// New returns a new client for the instance at baseURL. If httpClient
+// is nil, http.DefaultClient is used.
+func New(baseURL string, httpClient *http.Client) (*Client, error) {
+	if _, err := url.Parse(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+	}, nil
+}
+
+const (
+	// timelinePath is where every rendered site publishes the complete timeline.
+	timelinePath = "/timeline.json"
+	// apiPrefix is the prefix of all endpoints served by "render -listen".
+	apiPrefix = "/api/v1"
+)
+
+// Timeline fetches the complete merged timeline.
+func (c *Client) Timeline(ctx context.Context) (*timeline.Timeline, error) {
+	tl := &timeline.Timeline{}
+	if err := c.get(ctx, timelinePath, tl); err != nil {
+		return nil, err
+	}
+
+	return tl, nil
+}
+
+// Releases fetches the metadata of all releases.
+func (c *Client) Releases(ctx context.Context) ([]timeline.ReleaseMetadata, error) {
+	releases := []timeline.ReleaseMetadata{}
+	if err := c.get(ctx, apiPrefix+"/releases", &releases); err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
+
+// Release fetches the metadata of a single release, like "1.28".
+func (c *Client) Release(ctx context.Context, version string) (*timeline.ReleaseMetadata, error) {
+	release := &timeline.ReleaseMetadata{}
+	if err := c.get(ctx, apiPrefix+"/releases/"+url.PathEscape(version), release); err != nil {
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// Groups fetches the names of all API groups.
+func (c *Client) Groups(ctx context.Context) ([]string, error) {
+	groups := []string{}
+	if err := c.get(ctx, apiPrefix+"/groups", &groups); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// Group fetches a single API group with all its versions and resources.
+func (c *Client) Group(ctx context.Context, name string) (*timeline.APIGroup, error) {
+	group := &timeline.APIGroup{}
+	if err := c.get(ctx, apiPrefix+"/groups/"+url.PathEscape(name), group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// Resource fetches all versions of a resource in all releases. The resource
+// can be given by its kind ("Deployment") or plural name ("deployments"); the
+// core API group is "". This requires the instance to have a resource index.
+func (c *Client) Resource(ctx context.Context, group string, resource string) ([]database.IndexedResource, error) {
+	if group == "" {
+		group = "core"
+	}
+
+	resources := []database.IndexedResource{}
+	if err := c.get(ctx, apiPrefix+"/resources/"+url.PathEscape(group)+"/"+url.PathEscape(resource), &resources); err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d for %s", resp.StatusCode, path)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
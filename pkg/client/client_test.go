@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTimeline(t *testing.T) {
+	testcases := []struct {
+		name     string
+		status   int
+		body     string
+		expected string
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			body:   `{"Releases": [{"Version": "1.28"}, {"Version": "1.29"}], "APIGroups": [{"Name": "apps"}]}`,
+		},
+		{
+			name:     "not found",
+			status:   http.StatusNotFound,
+			body:     "404 page not found",
+			expected: "not found",
+		},
+		{
+			name:     "invalid JSON",
+			status:   http.StatusOK,
+			body:     `{"Releases": [`,
+			expected: "failed to decode response",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/timeline.json" {
+					http.NotFound(w, r)
+					return
+				}
+
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			c, err := New(server.URL+"/", nil)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			tl, err := c.Timeline(context.Background())
+
+			if tc.expected != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expected) {
+					t.Fatalf("Expected error containing %q, got %v.", tc.expected, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to fetch timeline: %v", err)
+			}
+
+			if len(tl.Releases) != 2 || tl.Releases[1].Version != "1.29" || len(tl.APIGroups) != 1 || tl.APIGroups[0].Name != "apps" {
+				t.Errorf("Expected the timeline to be decoded, got %+v.", tl)
+			}
+		})
+	}
+}
+
+func TestAPI(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/releases", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"Version": "1.28"}, {"Version": "1.29"}]`))
+	})
+	mux.HandleFunc("/api/v1/releases/1.29", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Version": "1.29", "Supported": true}`))
+	})
+	mux.HandleFunc("/api/v1/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`["", "apps"]`))
+	})
+	mux.HandleFunc("/api/v1/groups/apps", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Name": "apps", "APIVersions": [{"Version": "v1"}]}`))
+	})
+	mux.HandleFunc("/api/v1/resources/core/pods", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"release": "1.29", "group": "", "version": "v1", "kind": "Pod", "plural": "pods", "namespaced": true}]`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, err := New(server.URL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	releases, err := c.Releases(ctx)
+	if err != nil || len(releases) != 2 || releases[0].Version != "1.28" {
+		t.Errorf("Expected two releases, got %+v (error: %v).", releases, err)
+	}
+
+	release, err := c.Release(ctx, "1.29")
+	if err != nil || release.Version != "1.29" || !release.Supported {
+		t.Errorf("Expected release 1.29, got %+v (error: %v).", release, err)
+	}
+
+	if _, err := c.Release(ctx, "1.99"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown release, got %v.", err)
+	}
+
+	groups, err := c.Groups(ctx)
+	if err != nil || len(groups) != 2 || groups[1] != "apps" {
+		t.Errorf("Expected two API groups, got %v (error: %v).", groups, err)
+	}
+
+	group, err := c.Group(ctx, "apps")
+	if err != nil || group.Name != "apps" || len(group.APIVersions) != 1 {
+		t.Errorf("Expected the apps API group, got %+v (error: %v).", group, err)
+	}
+
+	resources, err := c.Resource(ctx, "", "pods")
+	if err != nil || len(resources) != 1 || resources[0].Kind != "Pod" || !resources[0].Namespaced {
+		t.Errorf("Expected the Pod resource, got %+v (error: %v).", resources, err)
+	}
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package conformancedumper
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+// conformanceTest is a single entry in Kubernetes'
+// test/conformance/testdata/conformance.yaml.
+type conformanceTest struct {
+	TestName    string `json:"testname"`
+	CodeName    string `json:"codename"`
+	Description string `json:"description"`
+	Release     string `json:"release"`
+	File        string `json:"file"`
+}
+
+func (t conformanceTest) text() string {
+	return strings.Join([]string{t.TestName, t.CodeName, t.Description}, "\n")
+}
+
+// DumpConformanceData reads the conformance test metadata and determines which
+// resources from the given API are covered by at least one test. Since the
+// metadata does not list resources explicitly, a resource is considered covered
+// if its Kind is mentioned in a test's name or description (see coverageMatcher
+// for kinds that exist in multiple API groups).
+func DumpConformanceData(filename string, api *types.KubernetesAPI) (*types.ConformanceCoverage, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conformance metadata: %w", err)
+	}
+
+	tests := []conformanceTest{}
+	if err := yaml.Unmarshal(data, &tests); err != nil {
+		return nil, fmt.Errorf("failed to parse conformance metadata: %w", err)
+	}
+
+	return calculateCoverage(tests, api), nil
+}
+
+func calculateCoverage(tests []conformanceTest, api *types.KubernetesAPI) *types.ConformanceCoverage {
+	result := &types.ConformanceCoverage{
+		Release:   api.Release,
+		Resources: []types.ConformanceResource{},
+	}
+
+	matcher := newCoverageMatcher(api)
+
+	for _, apiGroup := range api.APIGroups {
+		// the same kind can appear in multiple versions, but conformance
+		// tests do not care about versions, so we only count each kind once
+		seen := sets.New[string]()
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, resource := range apiVersion.Resources {
+				if seen.Has(resource.Kind) {
+					continue
+				}
+				seen.Insert(resource.Kind)
+
+				count := 0
+				for _, test := range tests {
+					if matcher.covers(test, apiGroup.Name, resource.Kind) {
+						count++
+					}
+				}
+
+				if count > 0 {
+					result.Resources = append(result.Resources, types.ConformanceResource{
+						Group: apiGroup.Name,
+						Kind:  resource.Kind,
+						Tests: count,
+					})
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// coverageMatcher decides whether a conformance test covers a resource. Kinds
+// that only exist in a single API group are matched by name alone. For kinds
+// like Event (core and events.k8s.io) or Ingress (extensions and
+// networking.k8s.io), a test only covers the groups it mentions, like
+// "events.k8s.io" or "batch/v1"; tests that mention none of them are
+// attributed to the core group, if the kind exists there, or to all groups
+// otherwise.
+type coverageMatcher struct {
+	// kindGroups contains all API groups of each kind.
+	kindGroups map[string]sets.Set[string]
+
+	kindPatterns  map[string]*regexp.Regexp
+	groupPatterns map[string]*regexp.Regexp
+}
+
+func newCoverageMatcher(api *types.KubernetesAPI) *coverageMatcher {
+	m := &coverageMatcher{
+		kindGroups:    map[string]sets.Set[string]{},
+		kindPatterns:  map[string]*regexp.Regexp{},
+		groupPatterns: map[string]*regexp.Regexp{},
+	}
+
+	for _, apiGroup := range api.APIGroups {
+		if apiGroup.Name != "" {
+			m.groupPatterns[apiGroup.Name] = groupPattern(apiGroup.Name)
+		}
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, resource := range apiVersion.Resources {
+				if _, ok := m.kindGroups[resource.Kind]; !ok {
+					m.kindGroups[resource.Kind] = sets.New[string]()
+					m.kindPatterns[resource.Kind] = regexp.MustCompile(fmt.Sprintf(`\b%s(s|es)?\b`, regexp.QuoteMeta(resource.Kind)))
+				}
+
+				m.kindGroups[resource.Kind].Insert(apiGroup.Name)
+			}
+		}
+	}
+
+	return m
+}
+
+// groupPattern matches mentions of an API group. Short group names like "apps"
+// are common words, so they only count as part of an API version ("apps/v1").
+func groupPattern(group string) *regexp.Regexp {
+	if strings.Contains(group, ".") {
+		return regexp.MustCompile(fmt.Sprintf(`(^|[^\w.-])%s\b`, regexp.QuoteMeta(group)))
+	}
+
+	return regexp.MustCompile(fmt.Sprintf(`\b%s/v[0-9]`, regexp.QuoteMeta(group)))
+}
+
+func (m *coverageMatcher) covers(test conformanceTest, group string, kind string) bool {
+	kindPattern, ok := m.kindPatterns[kind]
+	if !ok {
+		return false
+	}
+
+	text := test.text()
+	if !kindPattern.MatchString(text) {
+		return false
+	}
+
+	candidates := m.kindGroups[kind]
+	if candidates.Len() == 1 {
+		return true
+	}
+
+	mentioned := sets.New[string]()
+	for candidate := range candidates {
+		if candidate != "" && m.groupPatterns[candidate].MatchString(text) {
+			mentioned.Insert(candidate)
+		}
+	}
+
+	switch {
+	case mentioned.Len() > 0:
+		return mentioned.Has(group)
+	case candidates.Has(""):
+		return group == ""
+	default:
+		return true
+	}
+}
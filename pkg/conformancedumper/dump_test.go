@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package conformancedumper
+
+import (
+	"reflect"
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+func testAPI() *types.KubernetesAPI {
+	group := func(name string, version string, kinds ...string) types.APIGroup {
+		resources := []types.Resource{}
+		for _, kind := range kinds {
+			resources = append(resources, types.Resource{Kind: kind})
+		}
+
+		return types.APIGroup{
+			Name:             name,
+			PreferredVersion: version,
+			APIVersions:      []types.APIVersion{{Version: version, Resources: resources}},
+		}
+	}
+
+	return &types.KubernetesAPI{
+		Release: "1.19",
+		APIGroups: []types.APIGroup{
+			group("", "v1", "Event", "Pod"),
+			group("apps", "v1", "Deployment"),
+			group("events.k8s.io", "v1", "Event"),
+			group("extensions", "v1beta1", "Ingress"),
+			group("networking.k8s.io", "v1", "Ingress"),
+		},
+	}
+}
+
+func TestCoverageMatcher(t *testing.T) {
+	matcher := newCoverageMatcher(testAPI())
+
+	testcases := []struct {
+		name     string
+		test     conformanceTest
+		group    string
+		kind     string
+		expected bool
+	}{
+		{
+			name:     "kind of a single group is matched by name",
+			test:     conformanceTest{TestName: "Deployment, RollingUpdate"},
+			group:    "apps",
+			kind:     "Deployment",
+			expected: true,
+		},
+		{
+			name:     "plural kinds are matched",
+			test:     conformanceTest{Description: "List all Pods in the namespace."},
+			group:    "",
+			kind:     "Pod",
+			expected: true,
+		},
+		{
+			name:     "other kinds are not matched",
+			test:     conformanceTest{TestName: "Deployment, RollingUpdate"},
+			group:    "",
+			kind:     "Pod",
+			expected: false,
+		},
+		{
+			name:     "kind must be a whole word",
+			test:     conformanceTest{TestName: "PodTemplate, lifecycle"},
+			group:    "",
+			kind:     "Pod",
+			expected: false,
+		},
+		{
+			name:     "mentioned group covers its kind",
+			test:     conformanceTest{Description: "Create an Event via the events.k8s.io API."},
+			group:    "events.k8s.io",
+			kind:     "Event",
+			expected: true,
+		},
+		{
+			name:     "mentioned group excludes the core group",
+			test:     conformanceTest{Description: "Create an Event via the events.k8s.io API."},
+			group:    "",
+			kind:     "Event",
+			expected: false,
+		},
+		{
+			name:     "unscoped tests are attributed to the core group",
+			test:     conformanceTest{TestName: "Event resource lifecycle"},
+			group:    "",
+			kind:     "Event",
+			expected: true,
+		},
+		{
+			name:     "unscoped tests do not cover other groups if the kind is in core",
+			test:     conformanceTest{TestName: "Event resource lifecycle"},
+			group:    "events.k8s.io",
+			kind:     "Event",
+			expected: false,
+		},
+		{
+			name:     "short group names only count as part of an API version",
+			test:     conformanceTest{Description: "Ingress API must support the extensions/v1beta1 version."},
+			group:    "networking.k8s.io",
+			kind:     "Ingress",
+			expected: false,
+		},
+		{
+			name:     "unscoped tests cover all groups if the kind is not in core",
+			test:     conformanceTest{TestName: "Ingress API"},
+			group:    "extensions",
+			kind:     "Ingress",
+			expected: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if covered := matcher.covers(tc.test, tc.group, tc.kind); covered != tc.expected {
+				t.Errorf("Expected %v, got %v.", tc.expected, covered)
+			}
+		})
+	}
+}
+
+func TestCalculateCoverage(t *testing.T) {
+	tests := []conformanceTest{
+		{TestName: "Event resource lifecycle"},
+		{Description: "The events.k8s.io/v1 API must allow to list Events."},
+		{TestName: "Pod, lifecycle"},
+	}
+
+	coverage := calculateCoverage(tests, testAPI())
+
+	expected := []types.ConformanceResource{
+		{Group: "", Kind: "Event", Tests: 1},
+		{Group: "", Kind: "Pod", Tests: 1},
+		{Group: "events.k8s.io", Kind: "Event", Tests: 1},
+	}
+
+	if !reflect.DeepEqual(coverage.Resources, expected) {
+		t.Errorf("Expected %+v, got %+v.", expected, coverage.Resources)
+	}
+}
@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package crddumper builds API data from CustomResourceDefinitions, like the
+// CRD bundles that ecosystem projects (cert-manager, Istio, the Gateway API,
+// ...) attach to their releases. The result uses the same format as the
+// Kubernetes releases and is meant for the project datasets in the database.
+package crddumper
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// define just enough of the CRD spec to parse what we need :)
+
+type crdSpec struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Spec       struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind     string `json:"kind"`
+			Plural   string `json:"plural"`
+			Singular string `json:"singular"`
+		} `json:"names"`
+		Scope    string           `json:"scope"`
+		Versions []crdVersionSpec `json:"versions"`
+
+		// apiextensions.k8s.io/v1beta1 only
+		Version    string         `json:"version"`
+		Validation *crdValidation `json:"validation"`
+	} `json:"spec"`
+}
+
+type crdVersionSpec struct {
+	Name   string         `json:"name"`
+	Served bool           `json:"served"`
+	Schema *crdValidation `json:"schema"`
+}
+
+type crdValidation struct {
+	OpenAPIV3Schema struct {
+		Description string `json:"description"`
+	} `json:"openAPIV3Schema"`
+}
+
+// DumpCRDs reads all CRDs from the given YAML/JSON files (other objects are
+// ignored) and returns the APIs they define. projectVersion is the version of
+// the project the CRDs belong to (like "1.13.2").
+func DumpCRDs(filenames []string, projectVersion string) (*types.KubernetesAPI, error) {
+	parsedVersion, err := version.ParseSemver(projectVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project version: %w", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	crds := []crdSpec{}
+	for _, filename := range filenames {
+		parsed, err := readCRDs(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		crds = append(crds, parsed...)
+	}
+
+	if len(crds) == 0 {
+		return nil, errors.New("no CustomResourceDefinitions found")
+	}
+
+	result := &types.KubernetesAPI{
+		Version:   parsedVersion.String(),
+		Release:   parsedVersion.MajorMinor(),
+		APIGroups: []types.APIGroup{},
+	}
+
+	for _, crd := range crds {
+		logger.Info("Found resource.", "group", crd.Spec.Group, "resource", crd.Spec.Names.Kind, "namespaced", crd.Spec.Scope == "Namespaced")
+
+		group := findOrAddGroup(result, crd.Spec.Group)
+
+		for _, crdVersion := range crdVersions(&crd) {
+			apiVersion := findOrAddVersion(group, crdVersion.Name)
+
+			description := crd.Spec.Validation
+			if crdVersion.Schema != nil {
+				description = crdVersion.Schema
+			}
+
+			res := types.Resource{
+				Kind:       crd.Spec.Names.Kind,
+				Namespaced: crd.Spec.Scope == "Namespaced",
+				Plural:     crd.Spec.Names.Plural,
+				Singular:   crd.Spec.Names.Singular,
+			}
+
+			if res.Singular == "" {
+				res.Singular = strings.ToLower(res.Kind)
+			}
+
+			if description != nil {
+				res.Description = description.OpenAPIV3Schema.Description
+			}
+
+			apiVersion.Resources = append(apiVersion.Resources, res)
+		}
+	}
+
+	// compute preferred version for each API group
+	for i, group := range result.APIGroups {
+		apiVersions := []string{}
+		for _, v := range group.APIVersions {
+			apiVersions = append(apiVersions, v.Version)
+		}
+
+		preferred, err := version.PreferredAPIVersion(apiVersions)
+		if err != nil {
+			return nil, fmt.Errorf("API group %s: %w", group.Name, err)
+		}
+
+		result.APIGroups[i].PreferredVersion = preferred.String()
+	}
+
+	return result, nil
+}
+
+func readCRDs(filename string) ([]crdSpec, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", filename, err)
+	}
+	defer f.Close()
+
+	decoder := yaml.NewYAMLOrJSONDecoder(f, 4096)
+	crds := []crdSpec{}
+
+	for {
+		crd := crdSpec{}
+		if err := decoder.Decode(&crd); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+
+		if crd.Kind == "CustomResourceDefinition" && strings.HasPrefix(crd.APIVersion, "apiextensions.k8s.io/") {
+			crds = append(crds, crd)
+		}
+	}
+
+	return crds, nil
+}
+
+// crdVersions returns the served versions of a CRD; CRDs using the old
+// v1beta1 format might only specify a single version.
+func crdVersions(crd *crdSpec) []crdVersionSpec {
+	if len(crd.Spec.Versions) == 0 {
+		return []crdVersionSpec{{Name: crd.Spec.Version, Served: true}}
+	}
+
+	served := []crdVersionSpec{}
+	for _, v := range crd.Spec.Versions {
+		if v.Served {
+			served = append(served, v)
+		}
+	}
+
+	return served
+}
+
+func findOrAddGroup(api *types.KubernetesAPI, name string) *types.APIGroup {
+	for i, group := range api.APIGroups {
+		if group.Name == name {
+			return &api.APIGroups[i]
+		}
+	}
+
+	api.APIGroups = append(api.APIGroups, types.APIGroup{
+		Name:        name,
+		APIVersions: []types.APIVersion{},
+	})
+
+	return &api.APIGroups[len(api.APIGroups)-1]
+}
+
+func findOrAddVersion(group *types.APIGroup, name string) *types.APIVersion {
+	for i, v := range group.APIVersions {
+		if v.Version == name {
+			return &group.APIVersions[i]
+		}
+	}
+
+	group.APIVersions = append(group.APIVersions, types.APIVersion{
+		Version:   name,
+		Resources: []types.Resource{},
+	})
+
+	return &group.APIVersions[len(group.APIVersions)-1]
+}
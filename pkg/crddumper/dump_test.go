@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package crddumper
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testCRDs = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: httproutes.gateway.networking.k8s.io
+spec:
+  group: gateway.networking.k8s.io
+  names:
+    kind: HTTPRoute
+    plural: httproutes
+  scope: Namespaced
+  versions:
+    - name: v1
+      served: true
+      schema:
+        openAPIV3Schema:
+          description: HTTPRoute provides a way to route HTTP requests.
+    - name: v1beta1
+      served: true
+    - name: v1alpha2
+      served: false
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: gateway-system
+`
+
+func TestDumpCRDs(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "crds.yaml")
+	if err := os.WriteFile(filename, []byte(testCRDs), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	api, err := DumpCRDs([]string{filename}, "v1.0.0")
+	if err != nil {
+		t.Fatalf("Failed to dump CRDs: %v", err)
+	}
+
+	if api.Release != "1.0" {
+		t.Errorf("Expected release 1.0, got %q", api.Release)
+	}
+
+	if len(api.APIGroups) != 1 {
+		t.Fatalf("Expected 1 API group, got %+v", api.APIGroups)
+	}
+
+	group := api.APIGroups[0]
+	if group.PreferredVersion != "v1" {
+		t.Errorf("Expected preferred version v1, got %q", group.PreferredVersion)
+	}
+
+	// unserved versions must be skipped
+	if len(group.APIVersions) != 2 {
+		t.Fatalf("Expected 2 API versions, got %+v", group.APIVersions)
+	}
+
+	res := group.APIVersions[0].Resources[0]
+	if res.Kind != "HTTPRoute" || !res.Namespaced || res.Singular != "httproute" || res.Description == "" {
+		t.Errorf("Unexpected resource: %+v", res)
+	}
+}
@@ -1,18 +1,64 @@
 package database
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"runtime"
 	"sort"
+	"sync"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
 
 	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/yaml"
+)
+
+var releasePattern = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+
+const (
+	deprecationsFile = "deprecations.yaml"
+	featureGatesFile = "featuregates.yaml"
+	enablementFile   = "enablement.yaml"
+	kepsFile         = "keps.yaml"
 )
 
+// ReleaseDatabase is a collection of Kubernetes releases, read from a
+// file system that contains one "releases/<version>/" directory per release.
 type ReleaseDatabase struct {
-	baseDir string
+	fsys fs.FS
+
+	deprecationsOnce sync.Once
+	deprecations     []types.Deprecation
+	deprecationsErr  error
+
+	featureGatesOnce sync.Once
+	featureGates     []types.FeatureGate
+	featureGatesErr  error
+
+	enablementsOnce sync.Once
+	enablements     []types.DefaultEnablement
+	enablementsErr  error
+
+	kepsOnce sync.Once
+	keps     []types.KEP
+	kepsErr  error
 }
 
+// NewReleaseDatabaseFromFS creates a database from any file system, like
+// files embedded into a binary (via embed.FS) or a remote data source.
+func NewReleaseDatabaseFromFS(fsys fs.FS) *ReleaseDatabase {
+	return &ReleaseDatabase{
+		fsys: fsys,
+	}
+}
+
+// NewReleaseDatabase opens (and creates, if needed) the database in baseDir.
 func NewReleaseDatabase(baseDir string) (*ReleaseDatabase, error) {
 	err := os.MkdirAll(baseDir, 0755)
 	if err != nil {
@@ -24,48 +70,259 @@ func NewReleaseDatabase(baseDir string) (*ReleaseDatabase, error) {
 		return nil, fmt.Errorf("failed to determine absolute path for data directory: %w", err)
 	}
 
-	return &ReleaseDatabase{
-		baseDir: baseDir,
-	}, nil
+	return NewReleaseDatabaseFromFS(os.DirFS(baseDir)), nil
 }
 
+// Releases returns the names of all known minor releases (like "1.29"),
+// sorted in ascending order.
 func (db *ReleaseDatabase) Releases() ([]string, error) {
-	dirs, err := filepath.Glob(filepath.Join(db.baseDir, "releases", "*"))
+	entries, err := fs.ReadDir(db.fsys, "releases")
 	if err != nil {
+		// an empty database is not an error
+		if errors.Is(err, fs.ErrNotExist) {
+			return []string{}, nil
+		}
+
 		return nil, fmt.Errorf("failed to find release directories: %w", err)
 	}
 
 	releases := []string{}
-	for _, dir := range dirs {
-		releases = append(releases, filepath.Base(dir))
-	}
+	parsed := map[string]*version.Version{}
 
-	sort.Slice(releases, func(i, j int) bool {
-		iRelease, err := version.ParseGeneric(releases[i])
-		if err != nil {
-			panic(err)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
 		}
 
-		jRelease, err := version.ParseGeneric(releases[j])
+		release := entry.Name()
+
+		v, err := version.ParseGeneric(release)
 		if err != nil {
-			panic(err)
+			return nil, fmt.Errorf("%w: release directory %q: %v", ErrMalformedVersion, release, err)
 		}
 
-		return iRelease.LessThan(jRelease)
+		releases = append(releases, release)
+		parsed[release] = v
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return parsed[releases[i]].LessThan(parsed[releases[j]])
 	})
 
 	return releases, nil
 }
 
+// LoadReleases returns all releases in the database, sorted in ascending order.
+// The APIs of all releases are loaded and parsed concurrently (using at most
+// GOMAXPROCS workers), so that later calls to KubernetesRelease.API are cheap.
+func (db *ReleaseDatabase) LoadReleases(ctx context.Context) ([]*KubernetesRelease, error) {
+	releaseNames, err := db.Releases()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list available releases: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		releases = make([]*KubernetesRelease, len(releaseNames))
+		errs     = make([]error, len(releaseNames))
+		indexes  = make(chan int)
+		wg       sync.WaitGroup
+	)
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(releaseNames) {
+		workers = len(releaseNames)
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for idx := range indexes {
+				releases[idx], errs[idx] = db.loadRelease(ctx, releaseNames[idx])
+
+				// no need to load any further releases
+				if errs[idx] != nil {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	for idx := range releaseNames {
+		if ctx.Err() != nil {
+			break
+		}
+
+		indexes <- idx
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	// report the first real error instead of the cancellations it caused
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
+
+func (db *ReleaseDatabase) loadRelease(ctx context.Context, releaseName string) (*KubernetesRelease, error) {
+	release, err := db.Release(releaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load release %q: %w", releaseName, err)
+	}
+
+	if _, err := release.API(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load API of release %q: %w", releaseName, err)
+	}
+
+	return release, nil
+}
+
+// Release returns a single release.
 func (db *ReleaseDatabase) Release(version string) (*KubernetesRelease, error) {
-	fullDir := filepath.Join(db.baseDir, "releases", version)
+	if !releasePattern.MatchString(version) {
+		return nil, fmt.Errorf("%w: %q is not a minor release like \"1.29\"", ErrMalformedVersion, version)
+	}
+
+	releaseDir := path.Join("releases", version)
+
+	if _, err := fs.Stat(db.fsys, releaseDir); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w %q", ErrUnknownRelease, version)
+		}
 
-	if _, err := os.Stat(fullDir); err != nil {
 		return nil, fmt.Errorf("failed to find release %q: %w", version, err)
 	}
 
+	fsys, err := fs.Sub(db.fsys, releaseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open release %q: %w", version, err)
+	}
+
+	deprecations, err := db.Deprecations()
+	if err != nil {
+		return nil, err
+	}
+
+	featureGates, err := db.FeatureGates()
+	if err != nil {
+		return nil, err
+	}
+
+	enablements, err := db.DefaultEnablements()
+	if err != nil {
+		return nil, err
+	}
+
 	return &KubernetesRelease{
-		release: version,
-		baseDir: fullDir,
+		release:      version,
+		fsys:         fsys,
+		deprecations: deprecations,
+		featureGates: featureGates,
+		enablements:  enablements,
 	}, nil
 }
+
+// Deprecations returns the curated deprecation notices from the optional
+// deprecations.yaml, which are applied to the APIs of all releases.
+func (db *ReleaseDatabase) Deprecations() ([]types.Deprecation, error) {
+	db.deprecationsOnce.Do(func() {
+		db.deprecations = []types.Deprecation{}
+
+		data, err := fs.ReadFile(db.fsys, deprecationsFile)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				db.deprecationsErr = fmt.Errorf("failed to read %s: %w", deprecationsFile, err)
+			}
+
+			return
+		}
+
+		if err := yaml.UnmarshalStrict(data, &db.deprecations); err != nil {
+			db.deprecationsErr = fmt.Errorf("failed to parse %s: %w", deprecationsFile, err)
+		}
+	})
+
+	return db.deprecations, db.deprecationsErr
+}
+
+// FeatureGates returns the feature gates from the optional featuregates.yaml,
+// which are applied to the APIs of all releases.
+func (db *ReleaseDatabase) FeatureGates() ([]types.FeatureGate, error) {
+	db.featureGatesOnce.Do(func() {
+		db.featureGates = []types.FeatureGate{}
+
+		data, err := fs.ReadFile(db.fsys, featureGatesFile)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				db.featureGatesErr = fmt.Errorf("failed to read %s: %w", featureGatesFile, err)
+			}
+
+			return
+		}
+
+		if err := yaml.UnmarshalStrict(data, &db.featureGates); err != nil {
+			db.featureGatesErr = fmt.Errorf("failed to parse %s: %w", featureGatesFile, err)
+		}
+	})
+
+	return db.featureGates, db.featureGatesErr
+}
+
+// DefaultEnablements returns the curated exceptions from the optional
+// enablement.yaml, which are applied to the APIs of all releases.
+func (db *ReleaseDatabase) DefaultEnablements() ([]types.DefaultEnablement, error) {
+	db.enablementsOnce.Do(func() {
+		db.enablements = []types.DefaultEnablement{}
+
+		data, err := fs.ReadFile(db.fsys, enablementFile)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				db.enablementsErr = fmt.Errorf("failed to read %s: %w", enablementFile, err)
+			}
+
+			return
+		}
+
+		if err := yaml.UnmarshalStrict(data, &db.enablements); err != nil {
+			db.enablementsErr = fmt.Errorf("failed to parse %s: %w", enablementFile, err)
+		}
+	})
+
+	return db.enablements, db.enablementsErr
+}
+
+// KEPs returns the curated Kubernetes Enhancement Proposals from the optional
+// keps.yaml, which are linked to the API changes they caused.
+func (db *ReleaseDatabase) KEPs() ([]types.KEP, error) {
+	db.kepsOnce.Do(func() {
+		db.keps = []types.KEP{}
+
+		data, err := fs.ReadFile(db.fsys, kepsFile)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				db.kepsErr = fmt.Errorf("failed to read %s: %w", kepsFile, err)
+			}
+
+			return
+		}
+
+		if err := yaml.UnmarshalStrict(data, &db.keps); err != nil {
+			db.kepsErr = fmt.Errorf("failed to parse %s: %w", kepsFile, err)
+		}
+	})
+
+	return db.keps, db.kepsErr
+}
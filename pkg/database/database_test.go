@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadReleases(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for minor := 5; minor <= 30; minor++ {
+		release := fmt.Sprintf("1.%d", minor)
+		fsys["releases/"+release+"/api.json"] = &fstest.MapFile{Data: []byte(fmt.Sprintf(`{"release": %q}`, release))}
+	}
+
+	releases, err := NewReleaseDatabaseFromFS(fsys).LoadReleases(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to load releases: %v", err)
+	}
+
+	if len(releases) != 26 {
+		t.Fatalf("Expected 26 releases, got %d.", len(releases))
+	}
+
+	for i, release := range releases {
+		expected := fmt.Sprintf("1.%d", i+5)
+		if release.Version() != expected {
+			t.Errorf("Expected release %d to be %s, got %s.", i, expected, release.Version())
+		}
+
+		if release.api == nil || release.api.Release != expected {
+			t.Errorf("Expected the API of release %s to be loaded.", expected)
+		}
+	}
+
+	fsys["releases/1.17/api.json"] = &fstest.MapFile{Data: []byte(`{`)}
+
+	if _, err := NewReleaseDatabaseFromFS(fsys).LoadReleases(context.Background()); err == nil {
+		t.Error("Expected a broken release to fail loading.")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := NewReleaseDatabaseFromFS(fsys).LoadReleases(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled context to stop loading, got %v.", err)
+	}
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// Distributions returns the names of all Kubernetes distributions (like
+// "openshift", "k3s" or "rke2") that have their own dataset in
+// "distributions/<name>/".
+func (db *ReleaseDatabase) Distributions() ([]string, error) {
+	distributions, err := db.datasets("distributions")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find distribution directories: %w", err)
+	}
+
+	return distributions, nil
+}
+
+// Distribution returns the dataset of a Kubernetes distribution. Like the
+// upstream database, it contains one "releases/<version>/" directory per
+// minor release, using the distribution's own versioning (e.g. "4.14" for
+// OpenShift) and its own deprecations.yaml.
+func (db *ReleaseDatabase) Distribution(name string) (*ReleaseDatabase, error) {
+	distributionDB, err := db.dataset("distributions", name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w %q", ErrUnknownDistribution, name)
+		}
+
+		return nil, fmt.Errorf("failed to open distribution %q: %w", name, err)
+	}
+
+	return distributionDB, nil
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDistributions(t *testing.T) {
+	db := NewReleaseDatabaseFromFS(fstest.MapFS{
+		"releases/1.27/latest.txt":                           {Data: []byte("1.27.6\n")},
+		"distributions/openshift/releases/4.14/latest.txt":   {Data: []byte("4.14.1\n")},
+		"distributions/openshift/releases/4.14/released.txt": {Data: []byte("2023-10-31\n")},
+		"distributions/openshift/releases/4.14/api.json":     {Data: []byte(`{"release": "4.14"}`)},
+		"distributions/k3s/releases/1.28/latest.txt":         {Data: []byte("1.28.2\n")},
+		"distributions/README.md":                            {Data: []byte("not a distribution")},
+	})
+
+	distributions, err := db.Distributions()
+	if err != nil {
+		t.Fatalf("Failed to list distributions: %v", err)
+	}
+
+	if len(distributions) != 2 || distributions[0] != "k3s" || distributions[1] != "openshift" {
+		t.Fatalf("Expected distributions [k3s openshift], got %v", distributions)
+	}
+
+	openshift, err := db.Distribution("openshift")
+	if err != nil {
+		t.Fatalf("Failed to open distribution: %v", err)
+	}
+
+	releases, err := openshift.LoadReleases(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to load releases: %v", err)
+	}
+
+	if len(releases) != 1 || releases[0].Version() != "4.14" {
+		t.Fatalf("Expected the distribution's own releases, got %v", releases)
+	}
+
+	if _, err := db.Distribution("rke2"); !errors.Is(err, ErrUnknownDistribution) {
+		t.Errorf("Expected ErrUnknownDistribution, got %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+/*
+Package database provides read access to the on-disk release database,
+which contains one directory per Kubernetes minor release with the dumped
+API (api.json), release/EOL dates and optional metadata files.
+
+Ecosystem projects that ship CRDs (like cert-manager or the Gateway API) can
+have their own datasets in the same layout below projects/<name>/, see
+ReleaseDatabase.Project. Likewise, distributions (like OpenShift, k3s or RKE2)
+have their own release lists and API dumps below distributions/<name>/, see
+ReleaseDatabase.Distribution.
+
+This package is part of the supported Go API of kube-api.ninja and follows
+semantic versioning, see the README for details.
+*/
+package database
@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import "errors"
+
+var (
+	// ErrUnknownRelease is returned when a release does not exist in the database.
+	ErrUnknownRelease = errors.New("unknown release")
+
+	// ErrUnknownProject is returned when an ecosystem project has no dataset
+	// in the database.
+	ErrUnknownProject = errors.New("unknown project")
+
+	// ErrUnknownDistribution is returned when a Kubernetes distribution has no
+	// dataset in the database.
+	ErrUnknownDistribution = errors.New("unknown distribution")
+
+	// ErrMalformedVersion is returned when a release or version string cannot be parsed.
+	ErrMalformedVersion = errors.New("malformed version")
+
+	// ErrMalformedDate is returned when a release or end of life date cannot be parsed.
+	ErrMalformedDate = errors.New("malformed date")
+
+	// ErrMissingEOL is returned when no end of life date is known for a release,
+	// which is normal for releases that are still supported.
+	ErrMissingEOL = errors.New("no end of life date known")
+)
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReleaseIndexFile lists the available releases (one per line). It is
+// required by the HTTP source, as web servers cannot be asked for directory
+// listings.
+const ReleaseIndexFile = "releases/index.txt"
+
+// httpFS is a read-only file system backed by a web server that serves a
+// copy of the data directory. Files are fetched on demand.
+type httpFS struct {
+	baseURL string
+	client  *http.Client
+
+	indexLock sync.Mutex
+	releases  []string
+}
+
+func newHTTPSource(options map[string]string) (fs.FS, error) {
+	baseURL := options["url"]
+	if baseURL == "" {
+		return nil, fmt.Errorf("no url specified")
+	}
+
+	return &httpFS{
+		baseURL: strings.TrimSuffix(baseURL, "/") + "/",
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (h *httpFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if name == "." || name == "releases" {
+		return h.openDir(name)
+	}
+
+	// release directories only exist if they are listed in the index
+	if dir, release := path.Split(name); dir == "releases/" {
+		releases, err := h.releaseIndex()
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+
+		for _, r := range releases {
+			if r == release {
+				return &memFile{name: release, dir: true}, nil
+			}
+		}
+
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	data, err := h.fetch(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &memFile{name: path.Base(name), Reader: bytes.NewReader(data), size: int64(len(data))}, nil
+}
+
+// ReadDir implements fs.ReadDirFS, which is required to list releases.
+func (h *httpFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	switch name {
+	case ".":
+		return []fs.DirEntry{fs.FileInfoToDirEntry(&memFile{name: "releases", dir: true})}, nil
+	case "releases":
+		releases, err := h.releaseIndex()
+		if err != nil {
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+		}
+
+		entries := []fs.DirEntry{}
+		for _, release := range releases {
+			entries = append(entries, fs.FileInfoToDirEntry(&memFile{name: release, dir: true}))
+		}
+
+		return entries, nil
+	default:
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+}
+
+func (h *httpFS) openDir(name string) (fs.File, error) {
+	return &memFile{name: path.Base(name), dir: true}, nil
+}
+
+func (h *httpFS) releaseIndex() ([]string, error) {
+	h.indexLock.Lock()
+	defer h.indexLock.Unlock()
+
+	if h.releases != nil {
+		return h.releases, nil
+	}
+
+	data, err := h.fetch(ReleaseIndexFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", ReleaseIndexFile, err)
+	}
+
+	releases := []string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			releases = append(releases, line)
+		}
+	}
+
+	h.releases = releases
+
+	return releases, nil
+}
+
+func (h *httpFS) fetch(name string) ([]byte, error) {
+	resp, err := h.client.Get(h.baseURL + name)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fs.ErrNotExist
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// memFile is a file (or directory) whose content is held in memory; it also
+// serves as its own fs.FileInfo.
+type memFile struct {
+	*bytes.Reader
+
+	name string
+	size int64
+	dir  bool
+}
+
+var _ fs.FileInfo = &memFile{}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f, nil }
+func (f *memFile) Close() error               { return nil }
+func (f *memFile) Name() string               { return f.name }
+func (f *memFile) Size() int64                { return f.size }
+func (f *memFile) ModTime() time.Time         { return time.Time{} }
+func (f *memFile) IsDir() bool                { return f.dir }
+func (f *memFile) Sys() any                   { return nil }
+
+func (f *memFile) Mode() fs.FileMode {
+	if f.dir {
+		return fs.ModeDir | 0555
+	}
+
+	return 0444
+}
+
+func (f *memFile) Read(p []byte) (int, error) {
+	if f.dir {
+		return 0, fs.ErrInvalid
+	}
+
+	return f.Reader.Read(p)
+}
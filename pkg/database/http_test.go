@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHTTPSource(t *testing.T) {
+	files := fstest.MapFS{
+		"releases/index.txt":        {Data: []byte("1.27\n1.28\n")},
+		"releases/1.27/latest.txt":  {Data: []byte("v1.27.6\n")},
+		"releases/1.28/latest.txt":  {Data: []byte("v1.28.2\n")},
+		"releases/1.28/unused.json": {Data: []byte("{}")},
+	}
+
+	server := httptest.NewServer(http.FileServer(http.FS(files)))
+	defer server.Close()
+
+	db, err := OpenSource(SourceConfig{Kind: "http", Options: map[string]string{"url": server.URL}})
+	if err != nil {
+		t.Fatalf("Failed to open source: %v", err)
+	}
+
+	releases, err := db.Releases()
+	if err != nil {
+		t.Fatalf("Failed to list releases: %v", err)
+	}
+
+	if len(releases) != 2 || releases[0] != "1.27" || releases[1] != "1.28" {
+		t.Fatalf("Expected releases [1.27 1.28], got %v", releases)
+	}
+
+	release, err := db.Release("1.28")
+	if err != nil {
+		t.Fatalf("Failed to open release: %v", err)
+	}
+
+	latest, err := release.LatestVersion()
+	if err != nil {
+		t.Fatalf("Failed to read latest version: %v", err)
+	}
+
+	if latest != "v1.28.2" {
+		t.Errorf("Expected latest version v1.28.2, got %q", latest)
+	}
+
+	if release.hasFile("clients.json") {
+		t.Error("Expected missing file to not exist.")
+	}
+
+	if _, err := db.Release("1.29"); err == nil {
+		t.Error("Expected unlisted release to not exist.")
+	}
+}
+
+func TestOpenSourceUnknownKind(t *testing.T) {
+	if _, err := OpenSource(SourceConfig{Kind: "oci"}); err == nil {
+		t.Fatal("Expected unknown source kind to be rejected.")
+	}
+}
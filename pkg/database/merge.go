@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+// MergeSource is one of multiple descriptions of the same release, like the
+// output of a dumper or a manually curated file.
+type MergeSource struct {
+	Name string
+	API  *types.KubernetesAPI
+}
+
+// Conflict describes a field for which the merged sources disagree.
+type Conflict struct {
+	// Path identifies the affected object, like "apps/v1 Deployment".
+	Path  string
+	Field string
+	// Values maps source names to their values.
+	Values map[string]string
+	// Winner is the name of the source whose value was used.
+	Winner string
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s: %s differs between sources %v, using %s", c.Path, c.Field, c.Values, c.Winner)
+}
+
+// MergeAPIs combines multiple sources for the same release. Sources are
+// given in order of precedence (the first source wins conflicts). The result
+// contains every API group, version and resource found in any source; empty
+// values never cause conflicts and are filled from lower-precedence sources.
+func MergeAPIs(sources []MergeSource) (*types.KubernetesAPI, []Conflict, error) {
+	if len(sources) == 0 {
+		return nil, nil, errors.New("no sources given")
+	}
+
+	m := &merger{
+		result:    &types.KubernetesAPI{APIGroups: []types.APIGroup{}},
+		conflicts: []Conflict{},
+		owners:    map[string]string{},
+	}
+
+	for _, source := range sources {
+		if err := m.mergeRelease(source); err != nil {
+			return nil, nil, err
+		}
+
+		for _, group := range source.API.APIGroups {
+			m.mergeGroup(source.Name, group)
+		}
+	}
+
+	m.result.Sort()
+
+	return m.result, m.conflicts, nil
+}
+
+type merger struct {
+	result    *types.KubernetesAPI
+	conflicts []Conflict
+	// path+field => name of the source that provided the current value
+	owners map[string]string
+}
+
+func (m *merger) mergeRelease(source MergeSource) error {
+	if source.API.Release != "" {
+		if m.result.Release != "" && m.result.Release != source.API.Release {
+			return fmt.Errorf("source %s describes release %s, not %s", source.Name, source.API.Release, m.result.Release)
+		}
+
+		m.result.Release = source.API.Release
+	}
+
+	m.mergeField("release", "version", &m.result.Version, source.API.Version, source.Name)
+
+	return nil
+}
+
+func (m *merger) mergeGroup(sourceName string, group types.APIGroup) {
+	var dest *types.APIGroup
+	for i, g := range m.result.APIGroups {
+		if g.Name == group.Name {
+			dest = &m.result.APIGroups[i]
+			break
+		}
+	}
+
+	if dest == nil {
+		m.result.APIGroups = append(m.result.APIGroups, types.APIGroup{
+			Name:        group.Name,
+			APIVersions: []types.APIVersion{},
+		})
+		dest = &m.result.APIGroups[len(m.result.APIGroups)-1]
+	}
+
+	path := group.Name
+	if path == "" {
+		path = "core"
+	}
+
+	m.mergeField(path, "preferredVersion", &dest.PreferredVersion, group.PreferredVersion, sourceName)
+
+	for _, apiVersion := range group.APIVersions {
+		m.mergeVersion(sourceName, path, dest, apiVersion)
+	}
+}
+
+func (m *merger) mergeVersion(sourceName string, groupPath string, group *types.APIGroup, apiVersion types.APIVersion) {
+	var dest *types.APIVersion
+	for i, v := range group.APIVersions {
+		if v.Version == apiVersion.Version {
+			dest = &group.APIVersions[i]
+			break
+		}
+	}
+
+	if dest == nil {
+		group.APIVersions = append(group.APIVersions, types.APIVersion{
+			Version:   apiVersion.Version,
+			Resources: []types.Resource{},
+		})
+		dest = &group.APIVersions[len(group.APIVersions)-1]
+	}
+
+	for _, resource := range apiVersion.Resources {
+		path := fmt.Sprintf("%s/%s %s", groupPath, apiVersion.Version, resource.Kind)
+
+		var existing *types.Resource
+		for i, r := range dest.Resources {
+			if r.Kind == resource.Kind {
+				existing = &dest.Resources[i]
+				break
+			}
+		}
+
+		if existing == nil {
+			dest.Resources = append(dest.Resources, types.Resource{Kind: resource.Kind})
+			existing = &dest.Resources[len(dest.Resources)-1]
+			m.owners[path+"/namespaced"] = sourceName
+			existing.Namespaced = resource.Namespaced
+		} else if existing.Namespaced != resource.Namespaced {
+			m.addConflict(path, "namespaced", fmt.Sprint(existing.Namespaced), fmt.Sprint(resource.Namespaced), sourceName)
+		}
+
+		m.mergeField(path, "singular", &existing.Singular, resource.Singular, sourceName)
+		m.mergeField(path, "plural", &existing.Plural, resource.Plural, sourceName)
+		m.mergeField(path, "description", &existing.Description, resource.Description, sourceName)
+	}
+}
+
+// mergeField fills empty fields and records conflicts for differing values;
+// since sources are merged in order of precedence, existing values win.
+func (m *merger) mergeField(path, field string, dest *string, value string, sourceName string) {
+	switch {
+	case value == "" || *dest == value:
+		return
+	case *dest == "":
+		*dest = value
+		m.owners[path+"/"+field] = sourceName
+	default:
+		m.addConflict(path, field, *dest, value, sourceName)
+	}
+}
+
+func (m *merger) addConflict(path, field, current, value string, sourceName string) {
+	winner := m.owners[path+"/"+field]
+
+	// a field can conflict across more than two sources
+	for i, c := range m.conflicts {
+		if c.Path == path && c.Field == field {
+			m.conflicts[i].Values[sourceName] = value
+			return
+		}
+	}
+
+	m.conflicts = append(m.conflicts, Conflict{
+		Path:  path,
+		Field: field,
+		Values: map[string]string{
+			winner:     current,
+			sourceName: value,
+		},
+		Winner: winner,
+	})
+}
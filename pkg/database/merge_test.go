@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+func TestMergeAPIs(t *testing.T) {
+	curated := &types.KubernetesAPI{
+		Release: "1.28",
+		APIGroups: []types.APIGroup{{
+			Name:             "batch",
+			PreferredVersion: "v1",
+			APIVersions: []types.APIVersion{{
+				Version: "v1",
+				Resources: []types.Resource{
+					{Kind: "Job", Namespaced: true, Plural: "jobs", Description: "Job represents the configuration of a single job."},
+				},
+			}},
+		}},
+	}
+
+	dumped := &types.KubernetesAPI{
+		Version: "1.28.2",
+		Release: "1.28",
+		APIGroups: []types.APIGroup{{
+			Name:             "batch",
+			PreferredVersion: "v1",
+			APIVersions: []types.APIVersion{{
+				Version: "v1",
+				Resources: []types.Resource{
+					{Kind: "Job", Namespaced: true, Singular: "job", Plural: "jobs", Description: "Job represents a job."},
+					{Kind: "CronJob", Namespaced: true, Singular: "cronjob", Plural: "cronjobs"},
+				},
+			}},
+		}},
+	}
+
+	merged, conflicts, err := MergeAPIs([]MergeSource{
+		{Name: "curated", API: curated},
+		{Name: "dump", API: dumped},
+	})
+	if err != nil {
+		t.Fatalf("Failed to merge: %v", err)
+	}
+
+	if merged.Version != "1.28.2" || merged.Release != "1.28" {
+		t.Errorf("Expected version 1.28.2 (1.28), got %s (%s)", merged.Version, merged.Release)
+	}
+
+	resources := merged.APIGroups[0].APIVersions[0].Resources
+	if len(resources) != 2 {
+		t.Fatalf("Expected 2 resources, got %+v", resources)
+	}
+
+	// resources are sorted by kind
+	job := resources[1]
+	if job.Singular != "job" || job.Description != "Job represents the configuration of a single job." {
+		t.Errorf("Expected singular name to be filled and curated description to win, got %+v", job)
+	}
+
+	if len(conflicts) != 1 {
+		t.Fatalf("Expected 1 conflict, got %v", conflicts)
+	}
+
+	if c := conflicts[0]; c.Path != "batch/v1 Job" || c.Field != "description" || c.Winner != "curated" {
+		t.Errorf("Expected description conflict won by curated source, got %+v", c)
+	}
+}
+
+func TestMergeAPIsDifferentReleases(t *testing.T) {
+	_, _, err := MergeAPIs([]MergeSource{
+		{Name: "a", API: &types.KubernetesAPI{Release: "1.27"}},
+		{Name: "b", API: &types.KubernetesAPI{Release: "1.28"}},
+	})
+	if err == nil {
+		t.Fatal("Expected error when merging different releases")
+	}
+}
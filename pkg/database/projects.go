@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+)
+
+var datasetPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*$`)
+
+// Projects returns the names of all ecosystem projects (like "cert-manager"
+// or "gateway-api") that have their own dataset in "projects/<name>/".
+func (db *ReleaseDatabase) Projects() ([]string, error) {
+	projects, err := db.datasets("projects")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find project directories: %w", err)
+	}
+
+	return projects, nil
+}
+
+// Project returns the dataset of an ecosystem project, whose APIs are based
+// on CRDs. It uses the same layout as the Kubernetes database, i.e. one
+// "releases/<version>/" directory per minor release of the project, so it can
+// be merged into a timeline just like Kubernetes releases.
+func (db *ReleaseDatabase) Project(name string) (*ReleaseDatabase, error) {
+	projectDB, err := db.dataset("projects", name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w %q", ErrUnknownProject, name)
+		}
+
+		return nil, fmt.Errorf("failed to open project %q: %w", name, err)
+	}
+
+	return projectDB, nil
+}
+
+// datasets returns the names of all nested databases in the given directory.
+func (db *ReleaseDatabase) datasets(directory string) ([]string, error) {
+	entries, err := fs.ReadDir(db.fsys, directory)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []string{}, nil
+		}
+
+		return nil, err
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() && datasetPattern.MatchString(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// dataset opens a nested database, which uses the same layout as the root
+// database. fs.ErrNotExist is returned if it does not exist.
+func (db *ReleaseDatabase) dataset(directory string, name string) (*ReleaseDatabase, error) {
+	if !datasetPattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid name %q", name)
+	}
+
+	datasetDir := path.Join(directory, name)
+
+	if _, err := fs.Stat(db.fsys, datasetDir); err != nil {
+		return nil, err
+	}
+
+	fsys, err := fs.Sub(db.fsys, datasetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReleaseDatabaseFromFS(fsys), nil
+}
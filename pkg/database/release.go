@@ -1,26 +1,42 @@
 package database
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io/fs"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"go.xrstf.de/kube-api.ninja/pkg/types"
 	"go.xrstf.de/kube-api.ninja/pkg/version"
 )
 
+// KubernetesRelease gives access to the data of a single minor release.
 type KubernetesRelease struct {
-	release string
-	baseDir string
+	release      string
+	fsys         fs.FS
+	deprecations []types.Deprecation
+	featureGates []types.FeatureGate
+	enablements  []types.DefaultEnablement
+
+	// the API is by far the largest file and is cached so that multiple
+	// timelines can be created from the same releases cheaply
+	apiLock sync.Mutex
+	api     *types.KubernetesAPI
 }
 
+// Version returns the minor release, e.g. "1.29".
 func (r *KubernetesRelease) Version() string {
 	return r.release
 }
 
+// Semver returns the release as a semantic version, e.g. "1.29.0".
 func (r *KubernetesRelease) Semver() *version.Semver {
 	parsed, err := version.ParseSemver(fmt.Sprintf("v%s.0", r.release))
 	if err != nil {
@@ -30,32 +46,124 @@ func (r *KubernetesRelease) Semver() *version.Semver {
 	return parsed
 }
 
-func (r *KubernetesRelease) API() (*types.KubernetesAPI, error) {
-	f, err := os.Open(filepath.Join(r.baseDir, "api.json"))
-	if err != nil {
+// API returns the dumped API of this release. The result is cached and
+// shared between all callers, so it must not be modified.
+func (r *KubernetesRelease) API(ctx context.Context) (*types.KubernetesAPI, error) {
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
-	defer f.Close()
 
-	decoder := json.NewDecoder(f)
+	r.apiLock.Lock()
+	defer r.apiLock.Unlock()
+
+	if r.api == nil {
+		rel := &types.KubernetesAPI{}
+		if err := r.readJSON("api.json", rel); err != nil {
+			return nil, err
+		}
+
+		rel.ApplyDeprecations(r.deprecations)
+		rel.ApplyFeatureGates(r.release, r.featureGates)
+		rel.ApplyDefaultEnablements(r.release, r.enablements)
+
+		r.api = rel
+	}
 
-	rel := &types.KubernetesAPI{}
-	if err := decoder.Decode(rel); err != nil {
+	return r.api, nil
+}
+
+// ClientVersions returns the matching client libraries for this release,
+// or nil if they are not known.
+func (r *KubernetesRelease) ClientVersions() (*types.ClientVersions, error) {
+	clients := &types.ClientVersions{}
+	if exists, err := r.readOptionalJSON("clients.json", clients); !exists || err != nil {
 		return nil, err
 	}
 
-	return rel, nil
+	return clients, nil
 }
 
+// RuntimeVersions returns the compatible container runtimes for this
+// release, or nil if they are not known.
+func (r *KubernetesRelease) RuntimeVersions() (*types.RuntimeVersions, error) {
+	runtimes := &types.RuntimeVersions{}
+	if exists, err := r.readOptionalJSON("runtimes.json", runtimes); !exists || err != nil {
+		return nil, err
+	}
+
+	return runtimes, nil
+}
+
+// SpecVersions returns the supported CSI/CNI/Gateway API versions for this
+// release, or nil if they are not known.
+func (r *KubernetesRelease) SpecVersions() (*types.SpecVersions, error) {
+	specs := &types.SpecVersions{}
+	if exists, err := r.readOptionalJSON("specs.json", specs); !exists || err != nil {
+		return nil, err
+	}
+
+	return specs, nil
+}
+
+// ProviderSupport returns when managed Kubernetes providers made this release
+// available and how long they support it, or nil if this is not known.
+func (r *KubernetesRelease) ProviderSupport() ([]types.ProviderSupport, error) {
+	providers := []types.ProviderSupport{}
+	if exists, err := r.readOptionalJSON("providers.json", &providers); !exists || err != nil {
+		return nil, err
+	}
+
+	for _, provider := range providers {
+		if provider.Provider == "" {
+			return nil, errors.New("provider name must not be empty")
+		}
+
+		if _, err := time.ParseInLocation("2006-01-02", provider.Available, time.UTC); err != nil {
+			return nil, fmt.Errorf("invalid availability date for provider %s: %w", provider.Provider, err)
+		}
+
+		// the end of support is often announced much later
+		for _, date := range []string{provider.SupportedUntil, provider.ExtendedSupportUntil} {
+			if date == "" {
+				continue
+			}
+
+			if _, err := time.ParseInLocation("2006-01-02", date, time.UTC); err != nil {
+				return nil, fmt.Errorf("invalid end of support date for provider %s: %w", provider.Provider, err)
+			}
+		}
+	}
+
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].Provider < providers[j].Provider
+	})
+
+	return providers, nil
+}
+
+// ConformanceCoverage returns the resources covered by the conformance tests
+// of this release, or nil if no coverage data has been dumped.
+func (r *KubernetesRelease) ConformanceCoverage() (*types.ConformanceCoverage, error) {
+	coverage := &types.ConformanceCoverage{}
+	if exists, err := r.readOptionalJSON("conformance.json", coverage); !exists || err != nil {
+		return nil, err
+	}
+
+	return coverage, nil
+}
+
+// ReleaseDate returns the date of the first stable release.
 func (r *KubernetesRelease) ReleaseDate() (time.Time, error) {
 	return r.readTime("released.txt")
 }
 
+// EndOfLifeDate returns the end of life date, or ErrMissingEOL if it is
+// not known yet.
 func (r *KubernetesRelease) EndOfLifeDate() (*time.Time, error) {
 	// EOL files are optional (EOL dates are not known before a new release)
 	data, err := r.readFile("eol.txt")
 	if err != nil || len(data) == 0 {
-		return nil, nil
+		return nil, ErrMissingEOL
 	}
 
 	t, err := r.readTime("eol.txt")
@@ -66,12 +174,154 @@ func (r *KubernetesRelease) EndOfLifeDate() (*time.Time, error) {
 	return &t, err
 }
 
+// LatestVersion returns the most recent patch release, e.g. "1.29.3".
 func (r *KubernetesRelease) LatestVersion() (string, error) {
 	return r.readFile("latest.txt")
 }
 
+// PatchReleases returns the known patch releases of this release (including
+// the initial .0 release), sorted by version, or nil if the history is not
+// known.
+func (r *KubernetesRelease) PatchReleases() ([]types.PatchRelease, error) {
+	patches := []types.PatchRelease{}
+	if exists, err := r.readOptionalJSON("patches.json", &patches); !exists || err != nil {
+		return nil, err
+	}
+
+	versions := map[string]*version.Semver{}
+	for _, patch := range patches {
+		parsed, err := version.ParseSemver(patch.Version)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid patch release %q: %v", ErrMalformedVersion, patch.Version, err)
+		}
+
+		if parsed.MajorMinor() != r.release {
+			return nil, fmt.Errorf("%w: patch release %s does not belong to %s", ErrMalformedVersion, patch.Version, r.release)
+		}
+
+		if _, err := time.ParseInLocation("2006-01-02", patch.Date, time.UTC); err != nil {
+			return nil, fmt.Errorf("%w: invalid date for patch release %s: %v", ErrMalformedDate, patch.Version, err)
+		}
+
+		versions[patch.Version] = parsed
+	}
+
+	sort.Slice(patches, func(i, j int) bool {
+		return versions[patches[i].Version].LessThan(versions[patches[j].Version])
+	})
+
+	return patches, nil
+}
+
+// Advisories returns the known security advisories affecting this release.
+func (r *KubernetesRelease) Advisories() ([]types.Advisory, error) {
+	advisories := []types.Advisory{}
+	if exists, err := r.readOptionalJSON("advisories.json", &advisories); !exists || err != nil {
+		return nil, err
+	}
+
+	return advisories, nil
+}
+
+// ReleaseNotes returns the API-related entries of the upstream changelog,
+// or nil if they have not been imported for this release.
+func (r *KubernetesRelease) ReleaseNotes() ([]types.ReleaseNote, error) {
+	notes := []types.ReleaseNote{}
+	if exists, err := r.readOptionalJSON("releasenotes.json", &notes); !exists || err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+// Highlights returns a curated list of notable changes in this release,
+// one per line in highlights.txt. The file is optional.
+func (r *KubernetesRelease) Highlights() ([]string, error) {
+	if !r.hasFile("highlights.txt") {
+		return nil, nil
+	}
+
+	contents, err := r.readFile("highlights.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	highlights := []string{}
+	for _, line := range strings.Split(contents, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			highlights = append(highlights, line)
+		}
+	}
+
+	return highlights, nil
+}
+
+// Checksum returns a hash over all data that makes up the API of this
+// release, i.e. the dumped API, the conformance coverage and the applicable
+// deprecations, feature gates and default enablements. Release metadata like the latest patch
+// version is not included.
+func (r *KubernetesRelease) Checksum() (string, error) {
+	hash := sha256.New()
+	hash.Write([]byte(r.release))
+
+	files := []string{"api.json"}
+	if r.hasFile("conformance.json") {
+		files = append(files, "conformance.json")
+	}
+
+	for _, basename := range files {
+		data, err := fs.ReadFile(r.fsys, basename)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(hash, "\x00%s\x00%d\x00", basename, len(data))
+		hash.Write(data)
+	}
+
+	encoder := json.NewEncoder(hash)
+	if err := encoder.Encode(r.deprecations); err != nil {
+		return "", err
+	}
+
+	if err := encoder.Encode(r.featureGates); err != nil {
+		return "", err
+	}
+
+	if err := encoder.Encode(r.enablements); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (r *KubernetesRelease) hasFile(basename string) bool {
+	_, err := fs.Stat(r.fsys, basename)
+	return err == nil
+}
+
+// readOptionalJSON is like readJSON, but returns false instead of an error if
+// the file does not exist.
+func (r *KubernetesRelease) readOptionalJSON(basename string, dst any) (bool, error) {
+	if !r.hasFile(basename) {
+		return false, nil
+	}
+
+	return true, r.readJSON(basename, dst)
+}
+
+func (r *KubernetesRelease) readJSON(basename string, dst any) error {
+	f, err := r.fsys.Open(basename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewDecoder(f).Decode(dst)
+}
+
 func (r *KubernetesRelease) readFile(basename string) (string, error) {
-	data, err := os.ReadFile(filepath.Join(r.baseDir, basename))
+	data, err := fs.ReadFile(r.fsys, basename)
 	if err != nil {
 		return "", err
 	}
@@ -87,7 +337,7 @@ func (r *KubernetesRelease) readTime(basename string) (time.Time, error) {
 
 	date, err := time.ParseInLocation("2006-01-02", contents, time.UTC)
 	if err != nil {
-		return time.Time{}, err
+		return time.Time{}, fmt.Errorf("%w: invalid date in %s: %v", ErrMalformedDate, basename, err)
 	}
 
 	return date, nil
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+func TestPatchReleases(t *testing.T) {
+	release := &KubernetesRelease{
+		release: "1.28",
+		fsys: fstest.MapFS{
+			"patches.json": {Data: []byte(`[
+				{"version": "1.28.10", "date": "2024-05-14"},
+				{"version": "1.28.0", "date": "2023-08-15"},
+				{"version": "1.28.2", "date": "2023-09-13"}
+			]`)},
+		},
+	}
+
+	patches, err := release.PatchReleases()
+	if err != nil {
+		t.Fatalf("Failed to read patch releases: %v", err)
+	}
+
+	versions := []string{}
+	for _, patch := range patches {
+		versions = append(versions, patch.Version)
+	}
+
+	if len(versions) != 3 || versions[0] != "1.28.0" || versions[1] != "1.28.2" || versions[2] != "1.28.10" {
+		t.Errorf("Expected patch releases to be sorted by version, got %v", versions)
+	}
+
+	release.fsys = fstest.MapFS{
+		"patches.json": {Data: []byte(`[{"version": "1.27.5", "date": "2023-08-23"}]`)},
+	}
+
+	if _, err := release.PatchReleases(); !errors.Is(err, ErrMalformedVersion) {
+		t.Errorf("Expected patch release of another minor release to be rejected, got %v", err)
+	}
+
+	release.fsys = fstest.MapFS{
+		"patches.json": {Data: []byte(`[{"version": "1.28.1", "date": "2023-08-32"}]`)},
+	}
+
+	if _, err := release.PatchReleases(); !errors.Is(err, ErrMalformedDate) {
+		t.Errorf("Expected patch release with an invalid date to be rejected, got %v", err)
+	}
+
+	release.fsys = fstest.MapFS{}
+
+	if patches, err := release.PatchReleases(); err != nil || patches != nil {
+		t.Errorf("Expected no patch releases without patches.json, got %v (%v)", patches, err)
+	}
+}
+
+func TestFeatureGates(t *testing.T) {
+	api := `{
+		"release": "1.28",
+		"apiGroups": [{
+			"name": "admissionregistration.k8s.io",
+			"apiVersions": [{
+				"version": "v1beta1",
+				"resources": [
+					{"kind": "ValidatingAdmissionPolicy"},
+					{"kind": "ValidatingAdmissionPolicyBinding"}
+				]
+			}]
+		}, {
+			"name": "resource.k8s.io",
+			"apiVersions": [{
+				"version": "v1alpha2",
+				"resources": [{"kind": "ResourceClaim"}]
+			}]
+		}]
+	}`
+
+	gates := []types.FeatureGate{
+		{
+			Name: "ValidatingAdmissionPolicy",
+			Stages: []types.FeatureGateStage{
+				{Stage: "beta", DefaultValue: false, FromVersion: "1.28", ToVersion: "1.29"},
+				{Stage: "stable", DefaultValue: true, FromVersion: "1.30"},
+			},
+			APIs: []types.FeatureGateAPI{{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "ValidatingAdmissionPolicy"}},
+		},
+		{
+			Name:   "DynamicResourceAllocation",
+			Stages: []types.FeatureGateStage{{Stage: "alpha", DefaultValue: false, FromVersion: "1.26"}},
+			APIs:   []types.FeatureGateAPI{{Group: "resource.k8s.io", Version: "v1alpha2"}},
+		},
+	}
+
+	release := &KubernetesRelease{
+		release:      "1.28",
+		fsys:         fstest.MapFS{"api.json": {Data: []byte(api)}},
+		featureGates: gates,
+	}
+
+	rel, err := release.API(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to load API: %v", err)
+	}
+
+	admission := rel.APIGroups[0].APIVersions[0]
+	if admission.FeatureGate != "" {
+		t.Errorf("Expected the gate to only apply to a single resource, but the version requires %q.", admission.FeatureGate)
+	}
+
+	if gate := admission.Resources[0].FeatureGate; gate != "ValidatingAdmissionPolicy" {
+		t.Errorf("Expected ValidatingAdmissionPolicy to require its gate, got %q.", gate)
+	}
+
+	if gate := admission.Resources[1].FeatureGate; gate != "" {
+		t.Errorf("Expected ValidatingAdmissionPolicyBinding to not require a gate, got %q.", gate)
+	}
+
+	resource := rel.APIGroups[1].APIVersions[0]
+	if resource.FeatureGate != "DynamicResourceAllocation" || resource.Resources[0].FeatureGate != "DynamicResourceAllocation" {
+		t.Errorf("Expected the gate to apply to the version and all its resources, got %+v.", resource)
+	}
+
+	if gates[0].RequiredIn("1.30") {
+		t.Error("Expected the gate to be enabled by default once it is stable.")
+	}
+}
+
+func TestDefaultEnablements(t *testing.T) {
+	api := `{
+		"release": "1.26",
+		"apiGroups": [{
+			"name": "flowcontrol.apiserver.k8s.io",
+			"apiVersions": [{
+				"version": "v1beta3",
+				"resources": [{"kind": "FlowSchema"}, {"kind": "PriorityLevelConfiguration"}]
+			}]
+		}, {
+			"name": "",
+			"apiVersions": [{
+				"version": "v1",
+				"resources": [{"kind": "Pod", "defaultEnabled": true}, {"kind": "Service"}]
+			}]
+		}]
+	}`
+
+	enablements := []types.DefaultEnablement{
+		{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Enabled: true, FromVersion: "1.26"},
+		{Group: "core", Version: "v1", Kind: "Pod", Enabled: false, FromVersion: "1.20"},
+		{Group: "core", Version: "v1", Kind: "Service", Enabled: false, FromVersion: "1.20", ToVersion: "1.25"},
+	}
+
+	release := &KubernetesRelease{
+		release:     "1.26",
+		fsys:        fstest.MapFS{"api.json": {Data: []byte(api)}},
+		enablements: enablements,
+	}
+
+	rel, err := release.API(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to load API: %v", err)
+	}
+
+	flowcontrol := rel.APIGroups[0].APIVersions[0]
+	if flowcontrol.DefaultEnabled == nil || !*flowcontrol.DefaultEnabled {
+		t.Errorf("Expected v1beta3 to be enabled by default, got %v.", flowcontrol.DefaultEnabled)
+	}
+
+	if schema := flowcontrol.Resources[0].DefaultEnabled; schema == nil || !*schema {
+		t.Errorf("Expected the exception to apply to all resources of the version, got %v.", schema)
+	}
+
+	core := rel.APIGroups[1].APIVersions[0]
+	if core.DefaultEnabled != nil {
+		t.Errorf("Expected exceptions for a single kind to not apply to the version, got %v.", *core.DefaultEnabled)
+	}
+
+	if pod := core.Resources[0].DefaultEnabled; pod == nil || !*pod {
+		t.Errorf("Expected the value from api.json to be kept, got %v.", pod)
+	}
+
+	if service := core.Resources[1].DefaultEnabled; service != nil {
+		t.Errorf("Expected the exception to no longer apply in 1.26, got %v.", *service)
+	}
+}
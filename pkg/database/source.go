@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"sync"
+)
+
+// SourceConfig selects and configures a data source, e.g. in a YAML file:
+//
+//	kind: http
+//	options:
+//	  url: https://example.com/kube-api-data/
+type SourceConfig struct {
+	Kind    string            `json:"kind"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// SourceFactory creates the file system of a data source. The file system
+// must contain one "releases/<version>/" directory per release.
+type SourceFactory func(options map[string]string) (fs.FS, error)
+
+var (
+	sourcesLock sync.RWMutex
+	sources     = map[string]SourceFactory{}
+)
+
+func init() {
+	RegisterSource("directory", newDirectorySource)
+	RegisterSource("http", newHTTPSource)
+}
+
+// RegisterSource makes a new kind of data source available to OpenSource.
+// Registering the same kind twice replaces the previous factory.
+func RegisterSource(kind string, factory SourceFactory) {
+	sourcesLock.Lock()
+	defer sourcesLock.Unlock()
+
+	sources[kind] = factory
+}
+
+// SourceKinds returns the names of all registered kinds of data sources.
+func SourceKinds() []string {
+	sourcesLock.RLock()
+	defer sourcesLock.RUnlock()
+
+	kinds := []string{}
+	for kind := range sources {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	return kinds
+}
+
+// OpenSource creates a database from the configured data source.
+func OpenSource(config SourceConfig) (*ReleaseDatabase, error) {
+	sourcesLock.RLock()
+	factory, exists := sources[config.Kind]
+	sourcesLock.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("unknown data source kind %q, must be one of %v", config.Kind, SourceKinds())
+	}
+
+	fsys, err := factory(config.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s data source: %w", config.Kind, err)
+	}
+
+	return NewReleaseDatabaseFromFS(fsys), nil
+}
+
+func newDirectorySource(options map[string]string) (fs.FS, error) {
+	dir := options["path"]
+	if dir == "" {
+		return nil, fmt.Errorf("no path specified")
+	}
+
+	db, err := NewReleaseDatabase(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.fsys, nil
+}
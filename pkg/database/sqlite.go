@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// SQLiteDriver is the database/sql driver used for resource indexes. It is
+// only linked into binaries built with the "sqlite" build tag.
+const SQLiteDriver = "sqlite"
+
+// ErrNoSQLite is returned when a resource index is used in a binary that was
+// built without the "sqlite" build tag.
+var ErrNoSQLite = errors.New("built without SQLite support (build with -tags sqlite)")
+
+const resourceIndexSchema = `
+CREATE TABLE releases (
+	name     TEXT PRIMARY KEY,
+	position INTEGER NOT NULL
+);
+
+CREATE TABLE resources (
+	release     TEXT NOT NULL REFERENCES releases (name),
+	api_group   TEXT NOT NULL,
+	api_version TEXT NOT NULL,
+	kind        TEXT NOT NULL,
+	singular    TEXT NOT NULL,
+	plural      TEXT NOT NULL,
+	namespaced  INTEGER NOT NULL,
+	preferred   INTEGER NOT NULL,
+	PRIMARY KEY (release, api_group, api_version, kind)
+);
+
+CREATE INDEX resources_by_kind ON resources (api_group, kind);
+CREATE INDEX resources_by_plural ON resources (api_group, plural);
+`
+
+// IndexedResource is a single resource of a single release in a ResourceIndex.
+type IndexedResource struct {
+	Release    string `json:"release"`
+	Group      string `json:"group"`
+	Version    string `json:"version"`
+	Kind       string `json:"kind"`
+	Singular   string `json:"singular"`
+	Plural     string `json:"plural"`
+	Namespaced bool   `json:"namespaced"`
+	// Preferred is true if Version is the preferred version of the API
+	// group in this release.
+	Preferred bool `json:"preferred"`
+}
+
+// ResourceIndex is a SQLite file that contains the resources of all releases,
+// indexed by API group and kind. Unlike the timeline, it does not need to be
+// held in memory to look up a single resource.
+type ResourceIndex struct {
+	db *sql.DB
+}
+
+// BuildResourceIndex compiles the API data of all releases into a new SQLite
+// file. An existing file is replaced.
+func BuildResourceIndex(ctx context.Context, rdb *ReleaseDatabase, filename string) error {
+	if err := os.Remove(filename); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove existing index: %w", err)
+	}
+
+	db, err := openSQLite(filename)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, resourceIndexSchema); err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	releases, err := rdb.LoadReleases(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load releases: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for position, release := range releases {
+		api, err := release.API(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load API of %s: %w", release.Version(), err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `INSERT INTO releases (name, position) VALUES (?, ?)`, release.Version(), position); err != nil {
+			return fmt.Errorf("failed to insert release %s: %w", release.Version(), err)
+		}
+
+		for _, apiGroup := range api.APIGroups {
+			for _, apiVersion := range apiGroup.APIVersions {
+				preferred := apiVersion.Version == apiGroup.PreferredVersion
+
+				for _, resource := range apiVersion.Resources {
+					_, err := tx.ExecContext(ctx,
+						`INSERT INTO resources (release, api_group, api_version, kind, singular, plural, namespaced, preferred) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+						release.Version(), apiGroup.Name, apiVersion.Version, resource.Kind, resource.Singular, resource.Plural, resource.Namespaced, preferred,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to insert %s/%s %s of %s: %w", apiGroup.Name, apiVersion.Version, resource.Kind, release.Version(), err)
+					}
+				}
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit index: %w", err)
+	}
+
+	return nil
+}
+
+// OpenResourceIndex opens a file created by BuildResourceIndex.
+func OpenResourceIndex(filename string) (*ResourceIndex, error) {
+	if _, err := os.Stat(filename); err != nil {
+		return nil, fmt.Errorf("failed to open index: %w", err)
+	}
+
+	db, err := openSQLite(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResourceIndex{db: db}, nil
+}
+
+func openSQLite(filename string) (*sql.DB, error) {
+	if !slices.Contains(sql.Drivers(), SQLiteDriver) {
+		return nil, ErrNoSQLite
+	}
+
+	db, err := sql.Open(SQLiteDriver, filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	return db, nil
+}
+
+// Close closes the underlying database.
+func (i *ResourceIndex) Close() error {
+	return i.db.Close()
+}
+
+// Releases returns the names of all releases in the index, sorted in
+// ascending order.
+func (i *ResourceIndex) Releases(ctx context.Context) ([]string, error) {
+	rows, err := i.db.QueryContext(ctx, `SELECT name FROM releases ORDER BY position`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query releases: %w", err)
+	}
+	defer rows.Close()
+
+	releases := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to read release: %w", err)
+		}
+
+		releases = append(releases, name)
+	}
+
+	return releases, rows.Err()
+}
+
+// Resource returns all versions of a resource in all releases, sorted by
+// release and version. The resource can be given by its kind ("Deployment")
+// or plural name ("deployments"); the core API group is "". If the resource
+// is unknown, an empty list is returned.
+func (i *ResourceIndex) Resource(ctx context.Context, group string, resource string) ([]IndexedResource, error) {
+	rows, err := i.db.QueryContext(ctx, `
+		SELECT r.release, r.api_group, r.api_version, r.kind, r.singular, r.plural, r.namespaced, r.preferred
+		FROM resources r
+		JOIN releases rel ON rel.name = r.release
+		WHERE r.api_group = ? AND (r.kind = ? OR r.plural = ?)
+		ORDER BY rel.position, r.api_version`,
+		group, resource, resource,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query resource: %w", err)
+	}
+	defer rows.Close()
+
+	resources := []IndexedResource{}
+	for rows.Next() {
+		var r IndexedResource
+		if err := rows.Scan(&r.Release, &r.Group, &r.Version, &r.Kind, &r.Singular, &r.Plural, &r.Namespaced, &r.Preferred); err != nil {
+			return nil, fmt.Errorf("failed to read resource: %w", err)
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, rows.Err()
+}
@@ -0,0 +1,9 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+//go:build sqlite
+
+package database
+
+// The pure-Go driver keeps the static, cgo-free release binaries working.
+import _ "modernc.org/sqlite"
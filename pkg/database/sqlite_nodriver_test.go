@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+//go:build !sqlite
+
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestResourceIndexWithoutDriver(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "index.sqlite")
+
+	err := BuildResourceIndex(context.Background(), NewReleaseDatabaseFromFS(fstest.MapFS{}), filename)
+	if !errors.Is(err, ErrNoSQLite) {
+		t.Errorf("Expected ErrNoSQLite, got %v.", err)
+	}
+}
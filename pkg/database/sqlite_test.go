@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+//go:build sqlite
+
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestResourceIndex(t *testing.T) {
+	fsys := fstest.MapFS{
+		"releases/1.8/api.json":  &fstest.MapFile{Data: []byte(`{"version": "1.8.0", "release": "1.8", "apiGroups": [{"name": "batch", "preferredVersion": "v1", "apiVersions": [{"version": "v1", "resources": [{"kind": "Job", "singular": "job", "plural": "jobs", "namespaced": true}]}, {"version": "v2alpha1", "resources": [{"kind": "CronJob", "singular": "cronjob", "plural": "cronjobs", "namespaced": true}]}]}]}`)},
+		"releases/1.21/api.json": &fstest.MapFile{Data: []byte(`{"version": "1.21.0", "release": "1.21", "apiGroups": [{"name": "batch", "preferredVersion": "v1", "apiVersions": [{"version": "v1", "resources": [{"kind": "CronJob", "singular": "cronjob", "plural": "cronjobs", "namespaced": true}, {"kind": "Job", "singular": "job", "plural": "jobs", "namespaced": true}]}, {"version": "v1beta1", "resources": [{"kind": "CronJob", "singular": "cronjob", "plural": "cronjobs", "namespaced": true}]}]}]}`)},
+	}
+
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "index.sqlite")
+
+	if err := BuildResourceIndex(ctx, NewReleaseDatabaseFromFS(fsys), filename); err != nil {
+		t.Fatalf("Failed to build index: %v", err)
+	}
+
+	index, err := OpenResourceIndex(filename)
+	if err != nil {
+		t.Fatalf("Failed to open index: %v", err)
+	}
+	defer index.Close()
+
+	releases, err := index.Releases(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list releases: %v", err)
+	}
+
+	if expected := []string{"1.8", "1.21"}; !reflect.DeepEqual(releases, expected) {
+		t.Errorf("Expected releases %v, got %v.", expected, releases)
+	}
+
+	cronJob := func(release, version string, preferred bool) IndexedResource {
+		return IndexedResource{Release: release, Group: "batch", Version: version, Kind: "CronJob", Singular: "cronjob", Plural: "cronjobs", Namespaced: true, Preferred: preferred}
+	}
+
+	testcases := []struct {
+		group    string
+		resource string
+		expected []IndexedResource
+	}{
+		{
+			group:    "batch",
+			resource: "CronJob",
+			expected: []IndexedResource{cronJob("1.8", "v2alpha1", false), cronJob("1.21", "v1", true), cronJob("1.21", "v1beta1", false)},
+		},
+		{
+			group:    "batch",
+			resource: "cronjobs",
+			expected: []IndexedResource{cronJob("1.8", "v2alpha1", false), cronJob("1.21", "v1", true), cronJob("1.21", "v1beta1", false)},
+		},
+		{
+			group:    "apps",
+			resource: "CronJob",
+			expected: []IndexedResource{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.group+"/"+tc.resource, func(t *testing.T) {
+			resources, err := index.Resource(ctx, tc.group, tc.resource)
+			if err != nil {
+				t.Fatalf("Failed to query resource: %v", err)
+			}
+
+			if !reflect.DeepEqual(resources, tc.expected) {
+				t.Errorf("Expected %+v, got %+v.", tc.expected, resources)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// supportedReleases is the number of minor releases the Kubernetes project
+// maintains at the same time; older releases must have an end of life date.
+const supportedReleases = 3
+
+// Problem is a single inconsistency found by Validate.
+type Problem struct {
+	// Release is the release directory (like "1.29"), File the file within
+	// it (like "api.json"), if the problem is specific to one.
+	Release string
+	File    string
+	Message string
+}
+
+func (p Problem) String() string {
+	location := path.Join("releases", p.Release, p.File)
+
+	return fmt.Sprintf("%s: %s", location, p.Message)
+}
+
+// optionalDocuments are the optional JSON files of a release, and the types
+// they must decode into.
+var optionalDocuments = []struct {
+	basename    string
+	newDocument func() any
+}{
+	{"clients.json", func() any { return &types.ClientVersions{} }},
+	{"runtimes.json", func() any { return &types.RuntimeVersions{} }},
+	{"specs.json", func() any { return &types.SpecVersions{} }},
+	{"conformance.json", func() any { return &types.ConformanceCoverage{} }},
+	{"patches.json", func() any { return &[]types.PatchRelease{} }},
+	{"providers.json", func() any { return &[]types.ProviderSupport{} }},
+	{"advisories.json", func() any { return &[]types.Advisory{} }},
+	{"releasenotes.json", func() any { return &[]types.ReleaseNote{} }},
+}
+
+// Validate checks all release directories for mistakes that would otherwise
+// only surface on the website (or not at all): files that do not match their
+// schema, unparseable versions and dates, preferred versions that do not
+// exist and duplicate API groups, versions or kinds. Unlike loading the
+// releases, Validate does not stop at the first problem. The error is only
+// set if the database itself cannot be read.
+func (db *ReleaseDatabase) Validate(ctx context.Context) ([]Problem, error) {
+	entries, err := fs.ReadDir(db.fsys, "releases")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find release directories: %w", err)
+	}
+
+	problems := []Problem{}
+
+	for _, entry := range entries {
+		if entry.IsDir() && !releasePattern.MatchString(entry.Name()) {
+			problems = append(problems, Problem{Release: entry.Name(), Message: "directory name is not a minor release like \"1.29\""})
+		}
+	}
+
+	// without valid names, the releases cannot be ordered
+	if len(problems) > 0 {
+		return problems, nil
+	}
+
+	releases, err := db.Releases()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, release := range releases {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		r, err := db.Release(release)
+		if err != nil {
+			return nil, err
+		}
+
+		problems = append(problems, r.validate(len(releases)-i-1)...)
+	}
+
+	return problems, nil
+}
+
+// validate checks a single release, of which newerReleases newer releases
+// exist in the database.
+func (r *KubernetesRelease) validate(newerReleases int) []Problem {
+	problems := []Problem{}
+	report := func(file string, format string, args ...any) {
+		problems = append(problems, Problem{Release: r.release, File: file, Message: fmt.Sprintf(format, args...)})
+	}
+
+	api := &types.KubernetesAPI{}
+	if err := r.readStrictJSON("api.json", api); errors.Is(err, fs.ErrNotExist) {
+		report("api.json", "missing API dump")
+	} else if err != nil {
+		report("api.json", "%v", err)
+	} else {
+		for _, message := range validateAPI(api, r.release) {
+			report("api.json", "%s", message)
+		}
+	}
+
+	for _, document := range optionalDocuments {
+		if !r.hasFile(document.basename) {
+			continue
+		}
+
+		if err := r.readStrictJSON(document.basename, document.newDocument()); err != nil {
+			report(document.basename, "%v", err)
+		}
+	}
+
+	if r.hasFile("patches.json") {
+		if _, err := r.PatchReleases(); err != nil {
+			report("patches.json", "%v", err)
+		}
+	}
+
+	if latest, err := r.LatestVersion(); errors.Is(err, fs.ErrNotExist) {
+		report("latest.txt", "missing latest patch release")
+	} else if err != nil {
+		report("latest.txt", "%v", err)
+	} else if parsed, err := version.ParseSemver(latest); err != nil {
+		report("latest.txt", "%q is not a valid semantic version: %v", latest, err)
+	} else if parsed.MajorMinor() != r.release {
+		report("latest.txt", "%s does not belong to %s", latest, r.release)
+	}
+
+	released, err := r.ReleaseDate()
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		report("released.txt", "missing release date")
+	case err != nil:
+		report("released.txt", "%v", err)
+	}
+
+	eol, err := r.EndOfLifeDate()
+	switch {
+	case errors.Is(err, ErrMissingEOL):
+		if newerReleases >= supportedReleases {
+			report("eol.txt", "missing end of life date, but %d newer releases exist", newerReleases)
+		}
+	case err != nil:
+		report("eol.txt", "%v", err)
+	case !released.IsZero() && !eol.After(released):
+		report("eol.txt", "end of life (%s) is not after the release date (%s)", eol.Format("2006-01-02"), released.Format("2006-01-02"))
+	}
+
+	return problems
+}
+
+// validateAPI checks the consistency of a dumped API.
+func validateAPI(api *types.KubernetesAPI, release string) []string {
+	messages := []string{}
+	report := func(format string, args ...any) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}
+
+	if api.Release != release {
+		report("release is %q, but the file belongs to %s", api.Release, release)
+	}
+
+	if parsed, err := version.ParseSemver(api.Version); err != nil {
+		report("version %q is not a valid semantic version: %v", api.Version, err)
+	} else if parsed.MajorMinor() != release {
+		report("version %s does not belong to %s", api.Version, release)
+	}
+
+	groups := sets.New[string]()
+
+	for _, apiGroup := range api.APIGroups {
+		name := apiGroup.Name
+		if name == "" {
+			name = "core"
+		}
+
+		if groups.Has(apiGroup.Name) {
+			report("API group %s is listed more than once", name)
+		}
+		groups.Insert(apiGroup.Name)
+
+		apiVersions := sets.New[string]()
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			if _, err := version.ParseAPIVersion(apiVersion.Version); err != nil {
+				report("%s/%s is not a valid API version", name, apiVersion.Version)
+			}
+
+			if apiVersions.Has(apiVersion.Version) {
+				report("%s/%s is listed more than once", name, apiVersion.Version)
+			}
+			apiVersions.Insert(apiVersion.Version)
+
+			kinds := sets.New[string]()
+
+			for _, resource := range apiVersion.Resources {
+				if resource.Kind == "" {
+					report("%s/%s contains a resource without kind", name, apiVersion.Version)
+					continue
+				}
+
+				if kinds.Has(resource.Kind) {
+					report("%s/%s contains the kind %s more than once", name, apiVersion.Version, resource.Kind)
+				}
+				kinds.Insert(resource.Kind)
+			}
+		}
+
+		if !apiVersions.Has(apiGroup.PreferredVersion) {
+			report("preferred version %q of API group %s is not one of its versions (%v)", apiGroup.PreferredVersion, name, sets.List(apiVersions))
+		}
+	}
+
+	return messages
+}
+
+// readStrictJSON is like readJSON, but rejects fields that are not part of
+// the destination type, i.e. not part of the published schema.
+func (r *KubernetesRelease) readStrictJSON(basename string, dst any) error {
+	data, err := fs.ReadFile(r.fsys, basename)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return fmt.Errorf("does not match the schema: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestValidate(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for minor := 24; minor <= 28; minor++ {
+		release := fmt.Sprintf("1.%d", minor)
+		fsys["releases/"+release+"/api.json"] = &fstest.MapFile{Data: []byte(fmt.Sprintf(`{"version": "%s.0", "release": %q, "apiGroups": [{"name": "apps", "preferredVersion": "v1", "apiVersions": [{"version": "v1", "resources": [{"kind": "Deployment"}]}]}]}`, release, release))}
+		fsys["releases/"+release+"/latest.txt"] = &fstest.MapFile{Data: []byte(release + ".3")}
+		fsys["releases/"+release+"/released.txt"] = &fstest.MapFile{Data: []byte(fmt.Sprintf("2022-%02d-01", minor-20))}
+		fsys["releases/"+release+"/eol.txt"] = &fstest.MapFile{Data: []byte(fmt.Sprintf("2023-%02d-28", minor-20))}
+	}
+
+	db := NewReleaseDatabaseFromFS(fsys)
+
+	problems, err := db.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to validate database: %v", err)
+	}
+
+	if len(problems) != 0 {
+		t.Fatalf("Expected a valid database, got %v.", problems)
+	}
+
+	// only the most recent releases may lack an EOL date
+	delete(fsys, "releases/1.28/eol.txt")
+	delete(fsys, "releases/1.24/eol.txt")
+
+	fsys["releases/1.25/api.json"] = &fstest.MapFile{Data: []byte(`{"version": "1.25", "release": "1.25", "apiGroups": [{"name": "apps", "preferredVersion": "v2", "apiVersions": [{"version": "v1", "resources": [{"kind": "Deployment"}, {"kind": "Deployment"}]}]}]}`)}
+	fsys["releases/1.26/latest.txt"] = &fstest.MapFile{Data: []byte("1.27.1")}
+	fsys["releases/1.26/clients.json"] = &fstest.MapFile{Data: []byte(`{"clientGo": "v0.26.0", "unknown": true}`)}
+	fsys["releases/1.27/released.txt"] = &fstest.MapFile{Data: []byte("2023-02-30")}
+
+	problems, err = db.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to validate database: %v", err)
+	}
+
+	expected := []string{
+		"releases/1.24/eol.txt: missing end of life date, but 4 newer releases exist",
+		`releases/1.25/api.json: version "1.25" is not a valid semantic version: illegal version string "1.25"`,
+		"releases/1.25/api.json: apps/v1 contains the kind Deployment more than once",
+		`releases/1.25/api.json: preferred version "v2" of API group apps is not one of its versions ([v1])`,
+		`releases/1.26/clients.json: does not match the schema: json: unknown field "unknown"`,
+		"releases/1.26/latest.txt: 1.27.1 does not belong to 1.26",
+		`releases/1.27/released.txt: malformed date: invalid date in released.txt: parsing time "2023-02-30": day out of range`,
+	}
+
+	actual := []string{}
+	for _, problem := range problems {
+		actual = append(actual, problem.String())
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected problems\n%v\ngot\n%v", expected, actual)
+	}
+
+	fsys["releases/latest/api.json"] = &fstest.MapFile{Data: []byte(`{}`)}
+
+	problems, err = db.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to validate database: %v", err)
+	}
+
+	if len(problems) != 1 || problems[0].Release != "latest" {
+		t.Errorf("Expected only the malformed release directory to be reported, got %v.", problems)
+	}
+}
@@ -4,6 +4,9 @@
 package dumper
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"strings"
 
@@ -12,16 +15,27 @@ import (
 	"k8s.io/client-go/discovery"
 )
 
-func DumpClusterData(client *discovery.DiscoveryClient) (*types.KubernetesAPI, error) {
+func DumpClusterData(ctx context.Context, client *discovery.DiscoveryClient) (*types.KubernetesAPI, error) {
 	result := &types.KubernetesAPI{}
 
+	// the discovery client has no context support, so at least
+	// check for cancellation in between the requests
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	server, err := client.ServerVersion()
 	if err != nil {
 		return nil, fmt.Errorf("failed to discover cluster version: %w", err)
 	}
 
 	result.Version = strings.TrimPrefix(server.String(), "v")
-	result.Release = fmt.Sprintf("%s.%s", server.Major, server.Minor)
+	// managed distributions report minor versions like "28+"
+	result.Release = fmt.Sprintf("%s.%s", server.Major, strings.TrimSuffix(server.Minor, "+"))
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	groups, resourceLists, err := client.ServerGroupsAndResources()
 	if err != nil {
@@ -63,6 +77,8 @@ func DumpClusterData(client *discovery.DiscoveryClient) (*types.KubernetesAPI, e
 							Namespaced: resource.Namespaced,
 							Singular:   singular,
 							Plural:     resource.Name,
+							ShortNames: resource.ShortNames,
+							Categories: resource.Categories,
 						})
 					}
 					break
@@ -75,5 +91,78 @@ func DumpClusterData(client *discovery.DiscoveryClient) (*types.KubernetesAPI, e
 		result.APIGroups[i] = g
 	}
 
+	// resource => storage version hash, see resolveStorageVersions
+	storageVersionHashes := map[*types.Resource]string{}
+
+	for _, resourceList := range resourceLists {
+		for _, resource := range resourceList.APIResources {
+			if resource.StorageVersionHash == "" || strings.Contains(resource.Name, "/") {
+				continue
+			}
+
+			if res := findResource(result, resourceList.GroupVersion, resource.Name); res != nil {
+				storageVersionHashes[res] = resource.StorageVersionHash
+			}
+		}
+	}
+
+	resolveStorageVersions(result, storageVersionHashes)
+
 	return result, nil
 }
+
+func findResource(api *types.KubernetesAPI, groupVersion string, plural string) *types.Resource {
+	for i, apiGroup := range api.APIGroups {
+		for j, apiVersion := range apiGroup.APIVersions {
+			if apiVersionString(apiGroup.Name, apiVersion.Version) != groupVersion {
+				continue
+			}
+
+			for k, resource := range apiVersion.Resources {
+				if resource.Plural == plural {
+					return &api.APIGroups[i].APIVersions[j].Resources[k]
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveStorageVersions turns the storage version hashes from the discovery
+// API into API versions. The hash is derived from the group, version and kind
+// the resource is persisted as, so it can be matched against all known
+// versions of the same kind. Kinds that moved between groups (like Ingress)
+// can be persisted in another group.
+func resolveStorageVersions(api *types.KubernetesAPI, hashes map[*types.Resource]string) {
+	// hash => API version
+	candidates := map[string]string{}
+
+	for _, apiGroup := range api.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, resource := range apiVersion.Resources {
+				candidates[StorageVersionHash(apiGroup.Name, apiVersion.Version, resource.Kind)] = apiVersionString(apiGroup.Name, apiVersion.Version)
+			}
+		}
+	}
+
+	for resource, hash := range hashes {
+		resource.StorageVersion = candidates[hash]
+	}
+}
+
+// StorageVersionHash computes the hash the API server publishes in the
+// discovery API for a resource persisted as the given group, version and
+// kind (see k8s.io/apiserver/pkg/endpoints/discovery).
+func StorageVersionHash(group string, version string, kind string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", group, version, kind)))
+	return base64.StdEncoding.EncodeToString(sum[:8])
+}
+
+func apiVersionString(group string, version string) string {
+	if group == "" {
+		return version
+	}
+
+	return fmt.Sprintf("%s/%s", group, version)
+}
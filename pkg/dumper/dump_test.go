@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package dumper
+
+import (
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+func TestResolveStorageVersions(t *testing.T) {
+	api := &types.KubernetesAPI{
+		APIGroups: []types.APIGroup{
+			{
+				Name: "",
+				APIVersions: []types.APIVersion{
+					{Version: "v1", Resources: []types.Resource{{Kind: "Pod", Plural: "pods"}}},
+				},
+			},
+			{
+				Name: "apps",
+				APIVersions: []types.APIVersion{
+					{Version: "v1", Resources: []types.Resource{{Kind: "Deployment", Plural: "deployments"}}},
+				},
+			},
+			{
+				Name: "extensions",
+				APIVersions: []types.APIVersion{
+					{Version: "v1beta1", Resources: []types.Resource{{Kind: "Deployment", Plural: "deployments"}}},
+				},
+			},
+		},
+	}
+
+	pod := &api.APIGroups[0].APIVersions[0].Resources[0]
+	deployment := &api.APIGroups[2].APIVersions[0].Resources[0]
+
+	// hashes as published by a real API server
+	resolveStorageVersions(api, map[*types.Resource]string{
+		pod:        "xPOwRZ+Yhw8=",
+		deployment: "8aSe+NMegvE=",
+	})
+
+	if pod.StorageVersion != "v1" {
+		t.Errorf("Expected pods to be stored as v1, but got %q.", pod.StorageVersion)
+	}
+
+	// deployments in the extensions group are persisted as apps/v1
+	if deployment.StorageVersion != "apps/v1" {
+		t.Errorf("Expected deployments to be stored as apps/v1, but got %q.", deployment.StorageVersion)
+	}
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package dumper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+var releasePattern = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+
+// WriteReleaseDirectory stores a dumped API as a release in the database
+// in dataDir (i.e. in "<dataDir>/releases/<release>/"), so that clusters of
+// private forks or distributions can be rendered like upstream releases.
+// The release date is only written if the release does not have one yet.
+// The path of the release directory is returned.
+func WriteReleaseDirectory(dataDir string, api *types.KubernetesAPI, releaseDate time.Time) (string, error) {
+	if !releasePattern.MatchString(api.Release) {
+		return "", fmt.Errorf("%q is not a minor release like \"1.29\"", api.Release)
+	}
+
+	releaseDir := filepath.Join(dataDir, "releases", api.Release)
+	if err := os.MkdirAll(releaseDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create release directory: %w", err)
+	}
+
+	encoded, err := json.MarshalIndent(api, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode API: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(releaseDir, "api.json"), append(encoded, '\n'), 0644); err != nil {
+		return "", err
+	}
+
+	if api.Version != "" {
+		if err := os.WriteFile(filepath.Join(releaseDir, "latest.txt"), []byte(api.Version+"\n"), 0644); err != nil {
+			return "", err
+		}
+	}
+
+	releasedFile := filepath.Join(releaseDir, "released.txt")
+	if _, err := os.Stat(releasedFile); errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(releasedFile, []byte(releaseDate.Format("2006-01-02")+"\n"), 0644); err != nil {
+			return "", err
+		}
+	}
+
+	return releaseDir, nil
+}
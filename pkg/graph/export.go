@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT writes the graph in Graphviz' DOT format.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var sb strings.Builder
+
+	sb.WriteString("digraph kubernetes {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box, fontname=\"sans-serif\"];\n")
+
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&sb, "  %q [label=%q];\n", node.ID, fmt.Sprintf("%s\n%s – %s", node.ID, node.FirstRelease, node.LastRelease))
+	}
+
+	for _, edge := range g.Edges {
+		style := "solid"
+		if edge.Type == Replacement {
+			style = "dashed"
+		}
+
+		fmt.Fprintf(&sb, "  %q -> %q [label=%q, style=%s];\n", edge.From, edge.To, strings.Join(edge.Resources, ", "), style)
+	}
+
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+type cytoscapeElement struct {
+	Group string `json:"group"`
+	Data  any    `json:"data"`
+}
+
+type cytoscapeEdge struct {
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Edge
+}
+
+// WriteCytoscape writes the graph as a list of Cytoscape.js elements.
+func (g *Graph) WriteCytoscape(w io.Writer) error {
+	elements := []cytoscapeElement{}
+
+	for _, node := range g.Nodes {
+		elements = append(elements, cytoscapeElement{Group: "nodes", Data: node})
+	}
+
+	for _, edge := range g.Edges {
+		elements = append(elements, cytoscapeElement{
+			Group: "edges",
+			Data: cytoscapeEdge{
+				ID:     fmt.Sprintf("%s->%s", edge.From, edge.To),
+				Source: edge.From,
+				Target: edge.To,
+				Edge:   edge,
+			},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(map[string]any{"elements": elements})
+}
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package graph derives the evolution of the Kubernetes API (which API
+// versions graduated into or were replaced by which others) from a timeline.
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// EdgeType describes how two API versions are related.
+type EdgeType = timeline.SuccessionType
+
+const (
+	// Graduation means a newer version in the same API group took over.
+	Graduation = timeline.Graduation
+	// Replacement means a version in another API group took over.
+	Replacement = timeline.Replacement
+)
+
+// Node is a single API version, like "apps/v1".
+type Node struct {
+	ID           string `json:"id"`
+	Group        string `json:"group"`
+	Version      string `json:"version"`
+	FirstRelease string `json:"firstRelease"`
+	LastRelease  string `json:"lastRelease"`
+}
+
+// Edge points from an API version to its successor.
+type Edge struct {
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	Type      EdgeType `json:"type"`
+	Resources []string `json:"resources"`
+}
+
+// Graph is the evolution graph of all API versions.
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// Build computes the evolution graph for the timeline.
+func Build(tl *timeline.Timeline) (*Graph, error) {
+	releaseIndex := map[string]int{}
+	for i, rel := range tl.Releases {
+		releaseIndex[rel.Version] = i
+	}
+
+	g := &Graph{
+		Nodes: []Node{},
+		Edges: []Edge{},
+	}
+
+	for _, apiGroup := range tl.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			first, last := releaseRange(apiVersion.Releases, releaseIndex)
+			if first < 0 {
+				continue
+			}
+
+			g.Nodes = append(g.Nodes, Node{
+				ID:           nodeID(apiGroup.Name, apiVersion.Version),
+				Group:        apiGroup.Name,
+				Version:      apiVersion.Version,
+				FirstRelease: tl.Releases[first].Version,
+				LastRelease:  tl.Releases[last].Version,
+			})
+		}
+	}
+
+	nodes := map[string]*Node{}
+	for i := range g.Nodes {
+		nodes[g.Nodes[i].ID] = &g.Nodes[i]
+	}
+
+	// from => to => edge
+	edges := map[string]map[string]*Edge{}
+
+	// the timeline already knows which API version took over each resource
+	for _, apiGroup := range tl.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			from := nodeID(apiGroup.Name, apiVersion.Version)
+			if _, exists := nodes[from]; !exists {
+				continue
+			}
+
+			for _, apiResource := range apiVersion.Resources {
+				successor := apiResource.SucceededBy
+				if successor == nil {
+					continue
+				}
+
+				// the successor might have been filtered out of the timeline
+				to := nodeID(successor.Group, successor.Version)
+				if _, exists := nodes[to]; !exists {
+					continue
+				}
+
+				if edges[from] == nil {
+					edges[from] = map[string]*Edge{}
+				}
+
+				edge, exists := edges[from][to]
+				if !exists {
+					edge = &Edge{From: from, To: to, Type: successor.Type}
+					edges[from][to] = edge
+				}
+
+				edge.Resources = append(edge.Resources, apiResource.Kind)
+			}
+		}
+	}
+
+	for _, targets := range edges {
+		for _, edge := range targets {
+			edge.Resources = sets.List(sets.New(edge.Resources...))
+			g.Edges = append(g.Edges, *edge)
+		}
+	}
+
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+
+		return g.Edges[i].To < g.Edges[j].To
+	})
+
+	return g, nil
+}
+
+func releaseRange(releases []string, releaseIndex map[string]int) (int, int) {
+	first, last := -1, -1
+
+	for _, release := range releases {
+		idx, exists := releaseIndex[release]
+		if !exists {
+			continue
+		}
+
+		if first < 0 || idx < first {
+			first = idx
+		}
+
+		if idx > last {
+			last = idx
+		}
+	}
+
+	return first, last
+}
+
+func nodeID(group, version string) string {
+	if group == "core" {
+		return version
+	}
+
+	return fmt.Sprintf("%s/%s", group, version)
+}
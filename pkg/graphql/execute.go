@@ -0,0 +1,345 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+/*
+Package graphql implements a small, read-only subset of GraphQL to query Go
+data structures like the timeline.
+
+The schema is derived from the Go types via reflection: struct fields become
+GraphQL fields (using their JSON name or, if there is none, the camel-cased Go
+name, so APIGroups turns into apiGroups). Arguments on list fields filter the
+list by the elements' fields, e.g. `apiGroups(name: "apps")`; for list fields
+of the elements, like releases, an element matches if the list contains the
+value.
+
+Queries can declare and use variables and aliases. Fragments, directives,
+mutations and introspection are not supported.
+*/
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Response is the result of a query, ready to be encoded as JSON.
+type Response struct {
+	Data   any     `json:"data"`
+	Errors []Error `json:"errors,omitempty"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+}
+
+// Execute runs the query against root, which must be a struct or a pointer
+// to one. Variables are the values for the query's variables, as decoded from
+// JSON.
+func Execute(root any, query string, variables map[string]any) *Response {
+	op, err := parse(query)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	vars := map[string]any{}
+	for name, value := range op.Defaults {
+		vars[name] = value
+	}
+
+	for name, value := range variables {
+		vars[name] = value
+	}
+
+	e := &executor{variables: vars}
+
+	data, err := e.resolveObject(reflect.ValueOf(root), op.Selections)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	return &Response{Data: data}
+}
+
+func errorResponse(err error) *Response {
+	return &Response{Errors: []Error{{Message: err.Error()}}}
+}
+
+type executor struct {
+	variables map[string]any
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (e *executor) resolve(v reflect.Value, f field) (any, error) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	switch {
+	case isObject(v.Type()):
+		if len(f.Selections) == 0 {
+			return nil, fmt.Errorf("field %q of type %s must have a selection of subfields", f.Name, v.Type().Name())
+		}
+
+		return e.resolveObject(v, f.Selections)
+
+	case v.Kind() == reflect.Slice && isObject(elemType(v.Type())):
+		if len(f.Selections) == 0 {
+			return nil, fmt.Errorf("field %q must have a selection of subfields", f.Name)
+		}
+
+		result := []any{}
+
+		for i := 0; i < v.Len(); i++ {
+			item := indirect(v.Index(i))
+
+			matches, err := e.matches(item, f.Arguments)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", f.Name, err)
+			}
+
+			if !matches {
+				continue
+			}
+
+			resolved, err := e.resolveObject(item, f.Selections)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, resolved)
+		}
+
+		return result, nil
+
+	default:
+		if len(f.Selections) > 0 {
+			return nil, fmt.Errorf("field %q is a scalar and cannot have subfields", f.Name)
+		}
+
+		if len(f.Arguments) > 0 {
+			return nil, fmt.Errorf("field %q does not accept arguments", f.Name)
+		}
+
+		return v.Interface(), nil
+	}
+}
+
+func (e *executor) resolveObject(v reflect.Value, selections []field) (any, error) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	if !isObject(v.Type()) {
+		return nil, fmt.Errorf("cannot select fields on %s", v.Type())
+	}
+
+	result := &object{values: map[string]any{}}
+
+	for _, sel := range selections {
+		var (
+			resolved any
+			err      error
+		)
+
+		if sel.Name == "__typename" {
+			resolved = v.Type().Name()
+		} else {
+			fieldValue, ok := lookupField(v, sel.Name)
+			if !ok {
+				return nil, fmt.Errorf("unknown field %q on type %s", sel.Name, v.Type().Name())
+			}
+
+			if len(sel.Arguments) > 0 && !(fieldValue.Kind() == reflect.Slice && isObject(elemType(fieldValue.Type()))) {
+				return nil, fmt.Errorf("field %q does not accept arguments", sel.Name)
+			}
+
+			resolved, err = e.resolve(fieldValue, sel)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result.set(sel.responseKey(), resolved)
+	}
+
+	return result, nil
+}
+
+// matches checks whether a list element fulfills all argument filters.
+func (e *executor) matches(item reflect.Value, args map[string]value) (bool, error) {
+	for name, arg := range args {
+		expected := arg.Literal
+		if arg.Variable != "" {
+			expected = e.variables[arg.Variable]
+		}
+
+		// null arguments (or unset variables) do not filter
+		if expected == nil {
+			continue
+		}
+
+		fieldValue, ok := lookupField(item, name)
+		if !ok {
+			return false, fmt.Errorf("unknown argument %q", name)
+		}
+
+		fieldValue = indirect(fieldValue)
+		if !fieldValue.IsValid() {
+			return false, nil
+		}
+
+		if fieldValue.Kind() == reflect.Slice && !isObject(elemType(fieldValue.Type())) {
+			found := false
+			for i := 0; i < fieldValue.Len(); i++ {
+				found = found || equal(fieldValue.Index(i), expected)
+			}
+
+			if !found {
+				return false, nil
+			}
+
+			continue
+		}
+
+		if !equal(fieldValue, expected) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func equal(v reflect.Value, expected any) bool {
+	// numbers decoded from JSON variables are float64s
+	if f, ok := expected.(float64); ok && f == float64(int64(f)) {
+		expected = int64(f)
+	}
+
+	return fmt.Sprint(indirect(v).Interface()) == fmt.Sprint(expected)
+}
+
+func lookupField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		if fieldName(sf) == name {
+			return v.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// fieldName returns the GraphQL name of a struct field.
+func fieldName(sf reflect.StructField) string {
+	if tag, _, _ := strings.Cut(sf.Tag.Get("json"), ","); tag != "" && tag != "-" {
+		return tag
+	}
+
+	return camelCase(sf.Name)
+}
+
+// camelCase lowercases the leading upper case letters of a Go name, keeping
+// acronyms intact: "APIGroups" becomes "apiGroups", "Kind" becomes "kind".
+func camelCase(name string) string {
+	runes := []rune(name)
+
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		// the last upper case letter of an acronym starts the next word
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	return string(runes)
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	t = t.Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// isObject returns true for structs, except for types that are encoded as
+// scalars.
+func isObject(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+// object is a JSON object that keeps its keys in the order of the query.
+type object struct {
+	keys   []string
+	values map[string]any
+}
+
+func (o *object) set(key string, value any) {
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+
+	o.values[key] = value
+}
+
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		encodedValue, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(encodedValue)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
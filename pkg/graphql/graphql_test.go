@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package graphql
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type testRoot struct {
+	APIGroups []testGroup
+	Latest    *testRelease `json:"latestRelease"`
+}
+
+type testGroup struct {
+	Name     string
+	Releases []string
+	Stable   bool
+}
+
+type testRelease struct {
+	Version string
+}
+
+func TestExecute(t *testing.T) {
+	root := &testRoot{
+		APIGroups: []testGroup{
+			{Name: "apps", Releases: []string{"1.27", "1.28"}, Stable: true},
+			{Name: "batch", Releases: []string{"1.28"}, Stable: true},
+			{Name: "resource.k8s.io", Releases: []string{"1.27"}},
+		},
+		Latest: &testRelease{Version: "1.28"},
+	}
+
+	testcases := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		expected  string
+		invalid   bool
+	}{
+		{
+			name:     "shorthand query",
+			query:    `{ apiGroups { name } }`,
+			expected: `{"apiGroups":[{"name":"apps"},{"name":"batch"},{"name":"resource.k8s.io"}]}`,
+		},
+		{
+			name:     "keeps field order and supports aliases",
+			query:    `query Groups { latest: latestRelease { version } groups: apiGroups(name: "batch") { releases, name } }`,
+			expected: `{"latest":{"version":"1.28"},"groups":[{"releases":["1.28"],"name":"batch"}]}`,
+		},
+		{
+			name:     "filters by list membership and booleans",
+			query:    `{ apiGroups(releases: "1.27", stable: true) { name } }`,
+			expected: `{"apiGroups":[{"name":"apps"}]}`,
+		},
+		{
+			name:      "variables",
+			query:     `query ($release: String!, $stable: Boolean = false) { apiGroups(releases: $release, stable: $stable) { name } }`,
+			variables: map[string]any{"release": "1.27"},
+			expected:  `{"apiGroups":[{"name":"resource.k8s.io"}]}`,
+		},
+		{
+			name:     "unset variables do not filter",
+			query:    `query ($name: String) { apiGroups(name: $name) { __typename } }`,
+			expected: `{"apiGroups":[{"__typename":"testGroup"},{"__typename":"testGroup"},{"__typename":"testGroup"}]}`,
+		},
+		{
+			name:    "unknown field",
+			query:   `{ apiGroups { owner } }`,
+			invalid: true,
+		},
+		{
+			name:    "missing subfields",
+			query:   `{ apiGroups }`,
+			invalid: true,
+		},
+		{
+			name:    "subfields on scalars",
+			query:   `{ apiGroups { name { length } } }`,
+			invalid: true,
+		},
+		{
+			name:    "unknown argument",
+			query:   `{ apiGroups(owner: "me") { name } }`,
+			invalid: true,
+		},
+		{
+			name:    "fragments",
+			query:   `{ apiGroups { ...groupFields } }`,
+			invalid: true,
+		},
+		{
+			name:    "mutations",
+			query:   `mutation { deleteGroup(name: "apps") { name } }`,
+			invalid: true,
+		},
+		{
+			name:    "syntax error",
+			query:   `{ apiGroups { name }`,
+			invalid: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := Execute(root, tc.query, tc.variables)
+
+			if tc.invalid {
+				if len(response.Errors) == 0 {
+					t.Fatalf("Expected an error, but got %+v.", response.Data)
+				}
+
+				return
+			}
+
+			if len(response.Errors) > 0 {
+				t.Fatalf("Query failed: %v", response.Errors)
+			}
+
+			encoded, err := json.Marshal(response.Data)
+			if err != nil {
+				t.Fatalf("Failed to encode response: %v", err)
+			}
+
+			if string(encoded) != tc.expected {
+				t.Errorf("Expected\n%s\nbut got\n%s", tc.expected, encoded)
+			}
+		})
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	testcases := map[string]string{
+		"APIGroups":          "apiGroups",
+		"ReleasesOfInterest": "releasesOfInterest",
+		"Kind":               "kind",
+		"ID":                 "id",
+		"DefaultEnabled":     "defaultEnabled",
+	}
+
+	for name, expected := range testcases {
+		if result := camelCase(name); result != expected {
+			t.Errorf("Expected %q to become %q, but got %q.", name, expected, result)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// field is a single field in a selection set, like `apiGroups(name: "apps") { name }`.
+type field struct {
+	Alias      string
+	Name       string
+	Arguments  map[string]value
+	Selections []field
+}
+
+func (f field) responseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+
+	return f.Name
+}
+
+// value is an argument value; either a literal or a reference to a variable.
+type value struct {
+	Literal  any
+	Variable string
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenName
+	tokenString
+	tokenNumber
+	tokenPunctuator
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// operation is a parsed query operation.
+type operation struct {
+	Selections []field
+	// default values of declared variables; the variable types are not
+	// checked, as the values are only compared against the data
+	Defaults map[string]any
+}
+
+// parse parses a query document containing a single query operation.
+func parse(query string) (*operation, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	op := &operation{Defaults: map[string]any{}}
+
+	// shorthand queries start directly with the selection set
+	if p.peek().kind == tokenName {
+		switch keyword := p.next().value; keyword {
+		case "query":
+			if p.peek().kind == tokenName {
+				p.next() // operation name
+			}
+
+			if p.peek().value == "(" {
+				if err := p.parseVariableDefinitions(op.Defaults); err != nil {
+					return nil, err
+				}
+			}
+
+		case "mutation", "subscription":
+			return nil, fmt.Errorf("%s operations are not supported", keyword)
+
+		case "fragment":
+			return nil, fmt.Errorf("fragments are not supported")
+
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", keyword, p.tokens[p.pos-1].pos)
+		}
+	}
+
+	op.Selections, err = p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != tokenEOF {
+		return nil, p.errorf("only a single operation is supported")
+	}
+
+	return op, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) expect(punctuator string) error {
+	if t := p.next(); t.kind != tokenPunctuator || t.value != punctuator {
+		return fmt.Errorf("expected %q at position %d, got %q", punctuator, t.pos, t.value)
+	}
+
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%s at position %d", fmt.Sprintf(format, args...), p.peek().pos)
+}
+
+func (p *parser) parseSelectionSet() ([]field, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	selections := []field{}
+
+	for !(p.peek().kind == tokenPunctuator && p.peek().value == "}") {
+		if p.peek().value == "..." {
+			return nil, p.errorf("fragments are not supported")
+		}
+
+		f, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+
+		selections = append(selections, f)
+	}
+
+	p.next()
+
+	if len(selections) == 0 {
+		return nil, p.errorf("empty selection set")
+	}
+
+	return selections, nil
+}
+
+func (p *parser) parseField() (field, error) {
+	name := p.next()
+	if name.kind != tokenName {
+		return field{}, fmt.Errorf("expected field name at position %d, got %q", name.pos, name.value)
+	}
+
+	f := field{Name: name.value}
+
+	if p.peek().value == ":" {
+		p.next()
+
+		name = p.next()
+		if name.kind != tokenName {
+			return field{}, fmt.Errorf("expected field name at position %d, got %q", name.pos, name.value)
+		}
+
+		f.Alias = f.Name
+		f.Name = name.value
+	}
+
+	if p.peek().value == "(" {
+		args, err := p.parseArguments()
+		if err != nil {
+			return field{}, err
+		}
+
+		f.Arguments = args
+	}
+
+	if p.peek().value == "@" {
+		return field{}, p.errorf("directives are not supported")
+	}
+
+	if p.peek().value == "{" {
+		selections, err := p.parseSelectionSet()
+		if err != nil {
+			return field{}, err
+		}
+
+		f.Selections = selections
+	}
+
+	return f, nil
+}
+
+// parseVariableDefinitions parses `($name: Type = default, ...)` and
+// records the default values.
+func (p *parser) parseVariableDefinitions(defaults map[string]any) error {
+	p.next() // (
+
+	for p.peek().value != ")" {
+		if err := p.expect("$"); err != nil {
+			return err
+		}
+
+		name := p.next()
+		if name.kind != tokenName {
+			return fmt.Errorf("expected variable name at position %d, got %q", name.pos, name.value)
+		}
+
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+
+		// skip the type, like "[String!]!"
+		for {
+			t := p.peek()
+			if t.kind == tokenEOF {
+				return p.errorf("unterminated variable definitions")
+			}
+
+			if t.kind == tokenPunctuator && (t.value == "$" || t.value == "=" || t.value == ")") {
+				break
+			}
+
+			p.next()
+		}
+
+		if p.peek().value == "=" {
+			p.next()
+
+			v, err := p.parseValue()
+			if err != nil {
+				return err
+			}
+
+			if v.Variable != "" {
+				return fmt.Errorf("default value of $%s must not be a variable", name.value)
+			}
+
+			defaults[name.value] = v.Literal
+		}
+	}
+
+	p.next()
+
+	return nil
+}
+
+func (p *parser) parseArguments() (map[string]value, error) {
+	p.next() // (
+
+	args := map[string]value{}
+
+	for p.peek().value != ")" {
+		name := p.next()
+		if name.kind != tokenName {
+			return nil, fmt.Errorf("expected argument name at position %d, got %q", name.pos, name.value)
+		}
+
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		args[name.value] = v
+	}
+
+	p.next()
+
+	return args, nil
+}
+
+func (p *parser) parseValue() (value, error) {
+	t := p.next()
+
+	switch {
+	case t.kind == tokenPunctuator && t.value == "$":
+		name := p.next()
+		if name.kind != tokenName {
+			return value{}, fmt.Errorf("expected variable name at position %d", name.pos)
+		}
+
+		return value{Variable: name.value}, nil
+
+	case t.kind == tokenString:
+		return value{Literal: t.value}, nil
+
+	case t.kind == tokenNumber:
+		if i, err := strconv.ParseInt(t.value, 10, 64); err == nil {
+			return value{Literal: int(i)}, nil
+		}
+
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return value{}, fmt.Errorf("invalid number %q at position %d", t.value, t.pos)
+		}
+
+		return value{Literal: f}, nil
+
+	case t.kind == tokenName && (t.value == "true" || t.value == "false"):
+		return value{Literal: t.value == "true"}, nil
+
+	case t.kind == tokenName && t.value == "null":
+		return value{Literal: nil}, nil
+
+	default:
+		return value{}, fmt.Errorf("unsupported argument value %q at position %d", t.value, t.pos)
+	}
+}
+
+func tokenize(query string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		// commas are insignificant in GraphQL
+		case unicode.IsSpace(r) || r == ',' || r == '\uFEFF':
+			i++
+
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+
+		case r == '.':
+			if !strings.HasPrefix(string(runes[i:]), "...") {
+				return nil, fmt.Errorf("unexpected %q at position %d", r, i)
+			}
+
+			tokens = append(tokens, token{kind: tokenPunctuator, value: "...", pos: i})
+			i += 3
+
+		case strings.ContainsRune("{}():$@!=[]", r):
+			tokens = append(tokens, token{kind: tokenPunctuator, value: string(r), pos: i})
+			i++
+
+		case r == '"':
+			start := i
+			i++
+
+			var sb strings.Builder
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+
+				sb.WriteRune(runes[i])
+			}
+
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+
+			tokens = append(tokens, token{kind: tokenString, value: sb.String(), pos: start})
+			i++
+
+		case r == '-' || unicode.IsDigit(r):
+			start := i
+			i++
+
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || strings.ContainsRune(".eE+-", runes[i])) {
+				i++
+			}
+
+			tokens = append(tokens, token{kind: tokenNumber, value: string(runes[start:i]), pos: start})
+
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+
+			tokens = append(tokens, token{kind: tokenName, value: string(runes[start:i]), pos: start})
+
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", r, i)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
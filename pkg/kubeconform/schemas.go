@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+/*
+Package kubeconform generates JSON Schemas for all kinds served by a
+Kubernetes release, in the layout kubeconform (and kubeval) expect for
+schema registries:
+
+	v1.29-standalone/cronjob-batch-v1.json
+
+The database does not contain the OpenAPI specs of the resources, so the
+schemas only check the apiVersion and kind. This is enough to detect objects
+using APIs that are not served by a release, because the schema validators
+fail if there is no schema for an object:
+
+	kubeconform -kubernetes-version 1.29 \
+	  -schema-location 'https://kube-api.ninja/kubeconform/{{ .NormalizedKubernetesVersion }}-standalone/{{ .ResourceKind }}{{ .KindSuffix }}.json' \
+	  deployment.yaml
+*/
+package kubeconform
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+// Directory returns the directory of a release's schemas, like
+// "v1.29-standalone".
+func Directory(release string) string {
+	return fmt.Sprintf("v%s-standalone", release)
+}
+
+// Filename returns the filename of a kind's schema, like
+// "cronjob-batch-v1.json". Like in kubeconform, only the first part of the
+// group name is used.
+func Filename(apiVersion string, kind string) string {
+	suffix := ""
+
+	if group, version, found := strings.Cut(apiVersion, "/"); found {
+		group, _, _ = strings.Cut(group, ".")
+		suffix = "-" + strings.ToLower(group) + "-" + strings.ToLower(version)
+	} else {
+		suffix = "-" + strings.ToLower(apiVersion)
+	}
+
+	return strings.ToLower(kind) + suffix + ".json"
+}
+
+// Schema is a JSON Schema that only accepts objects of a single kind.
+type Schema struct {
+	Description      string                    `json:"description"`
+	Type             string                    `json:"type"`
+	Required         []string                  `json:"required"`
+	Properties       map[string]SchemaProperty `json:"properties"`
+	GroupVersionKind []GroupVersionKind        `json:"x-kubernetes-group-version-kind"`
+}
+
+type SchemaProperty struct {
+	Type string   `json:"type"`
+	Enum []string `json:"enum,omitempty"`
+}
+
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// Schemas returns the schemas of all kinds served by the release, keyed by
+// their filename.
+func Schemas(tl *timeline.Timeline, release string) (map[string]*Schema, error) {
+	if !tl.HasRelease(release) {
+		return nil, fmt.Errorf("%w %q", timeline.ErrUnknownRelease, release)
+	}
+
+	schemas := map[string]*Schema{}
+
+	for _, apiGroup := range tl.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			if !apiVersion.HasRelease(release) {
+				continue
+			}
+
+			group := apiGroup.Name
+			groupVersion := apiVersion.Version
+
+			if group == "core" {
+				group = ""
+			} else {
+				groupVersion = group + "/" + groupVersion
+			}
+
+			for _, apiResource := range apiVersion.Resources {
+				if !apiResource.HasRelease(release) {
+					continue
+				}
+
+				schemas[Filename(groupVersion, apiResource.Kind)] = &Schema{
+					Description: fmt.Sprintf("%s %s as served by Kubernetes %s; generated by kube-api.ninja, only the apiVersion and kind are validated.", groupVersion, apiResource.Kind, release),
+					Type:        "object",
+					Required:    []string{"apiVersion", "kind"},
+					Properties: map[string]SchemaProperty{
+						"apiVersion": {Type: "string", Enum: []string{groupVersion}},
+						"kind":       {Type: "string", Enum: []string{apiResource.Kind}},
+						"metadata":   {Type: "object"},
+					},
+					GroupVersionKind: []GroupVersionKind{{
+						Group:   group,
+						Version: apiVersion.Version,
+						Kind:    apiResource.Kind,
+					}},
+				}
+			}
+		}
+	}
+
+	return schemas, nil
+}
+
+// WriteSchemas writes the schemas of a release into its directory below
+// targetDir and returns the number of schemas written.
+func WriteSchemas(targetDir string, tl *timeline.Timeline, release string) (int, error) {
+	schemas, err := Schemas(tl, release)
+	if err != nil {
+		return 0, err
+	}
+
+	dir := filepath.Join(targetDir, Directory(release))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create %s directory: %w", dir, err)
+	}
+
+	for filename, schema := range schemas {
+		encoded, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode %s: %w", filename, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, filename), encoded, 0644); err != nil {
+			return 0, fmt.Errorf("failed to write %s: %w", filename, err)
+		}
+	}
+
+	return len(schemas), nil
+}
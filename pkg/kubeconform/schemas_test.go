@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package kubeconform
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+func TestFilename(t *testing.T) {
+	testcases := map[string][2]string{
+		"service-v1.json":                {"v1", "Service"},
+		"cronjob-batch-v1beta1.json":     {"batch/v1beta1", "CronJob"},
+		"ingress-networking-v1.json":     {"networking.k8s.io/v1", "Ingress"},
+		"flowschema-flowcontrol-v1.json": {"flowcontrol.apiserver.k8s.io/v1", "FlowSchema"},
+	}
+
+	for expected, gvk := range testcases {
+		if filename := Filename(gvk[0], gvk[1]); filename != expected {
+			t.Errorf("Expected %s %s to be stored as %q, got %q.", gvk[0], gvk[1], expected, filename)
+		}
+	}
+}
+
+func TestWriteSchemas(t *testing.T) {
+	tl := &timeline.Timeline{
+		Releases: []timeline.ReleaseMetadata{
+			{Version: "1.24"},
+			{Version: "1.25"},
+		},
+		APIGroups: []timeline.APIGroup{
+			{
+				Name: "batch",
+				APIVersions: []timeline.APIVersion{
+					{
+						Version:  "v1",
+						Releases: []string{"1.24", "1.25"},
+						Resources: []timeline.APIResource{
+							{Kind: "CronJob", Releases: []string{"1.24", "1.25"}},
+						},
+					},
+					{
+						Version:  "v1beta1",
+						Releases: []string{"1.24"},
+						Resources: []timeline.APIResource{
+							{Kind: "CronJob", Releases: []string{"1.24"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "core",
+				APIVersions: []timeline.APIVersion{
+					{
+						Version:  "v1",
+						Releases: []string{"1.24", "1.25"},
+						Resources: []timeline.APIResource{
+							{Kind: "Service", Releases: []string{"1.24", "1.25"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	dir := t.TempDir()
+
+	count, err := WriteSchemas(dir, tl, "1.25")
+	if err != nil {
+		t.Fatalf("Failed to write schemas: %v", err)
+	}
+
+	if count != 2 {
+		t.Errorf("Expected 2 schemas, got %d.", count)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "v1.25-standalone"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filenames := []string{}
+	for _, entry := range entries {
+		filenames = append(filenames, entry.Name())
+	}
+	sort.Strings(filenames)
+
+	expected := []string{"cronjob-batch-v1.json", "service-v1.json"}
+	if !reflect.DeepEqual(filenames, expected) {
+		t.Errorf("Expected %v, got %v.", expected, filenames)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "v1.25-standalone", "service-v1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := Schema{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Failed to decode schema: %v", err)
+	}
+
+	if !reflect.DeepEqual(schema.Properties["apiVersion"].Enum, []string{"v1"}) {
+		t.Errorf("Expected the core group to be omitted from the apiVersion, got %v.", schema.Properties["apiVersion"].Enum)
+	}
+
+	if schema.GroupVersionKind[0] != (GroupVersionKind{Version: "v1", Kind: "Service"}) {
+		t.Errorf("Unexpected GVK %+v.", schema.GroupVersionKind[0])
+	}
+
+	if _, err := WriteSchemas(dir, tl, "1.99"); !errors.Is(err, timeline.ErrUnknownRelease) {
+		t.Errorf("Expected ErrUnknownRelease, got %v.", err)
+	}
+}
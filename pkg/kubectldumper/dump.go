@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package kubectldumper turns the copy-pasted output of `kubectl api-resources`
+// (with or without `-o wide`) or `kubectl api-versions` into API data.
+package kubectldumper
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+// Dump parses either kind of kubectl output. As kubectl does not print the
+// cluster version, the Version and Release fields of the result are empty.
+func Dump(output []byte) (*types.KubernetesAPI, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(output), []byte("NAME ")) {
+		return DumpAPIResources(output)
+	}
+
+	return DumpAPIVersions(output)
+}
+
+// DumpAPIResources parses the table printed by `kubectl api-resources`.
+// Since the SHORTNAMES column is often empty and the VERBS column (in wide
+// mode) contains spaces, columns are determined based on the table header.
+func DumpAPIResources(output []byte) (*types.KubernetesAPI, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+
+	var columns map[string]int
+
+	builder := newAPIBuilder()
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" {
+			continue
+		}
+
+		if columns == nil {
+			columns = parseHeader(line)
+
+			if _, ok := columns["APIGROUP"]; ok {
+				return nil, errors.New("output does not contain API versions, use kubectl 1.20 or newer")
+			}
+
+			for _, column := range []string{"NAME", "APIVERSION", "NAMESPACED", "KIND"} {
+				if _, ok := columns[column]; !ok {
+					return nil, fmt.Errorf("output has no %s column", column)
+				}
+			}
+
+			continue
+		}
+
+		apiVersion := cell(line, columns, "APIVERSION")
+		kind := cell(line, columns, "KIND")
+		plural := cell(line, columns, "NAME")
+
+		if apiVersion == "" || kind == "" || plural == "" {
+			return nil, fmt.Errorf("malformed line %q", line)
+		}
+
+		group, ver := splitAPIVersion(apiVersion)
+
+		builder.add(group, ver, &types.Resource{
+			Kind:       kind,
+			Namespaced: cell(line, columns, "NAMESPACED") == "true",
+			Singular:   strings.ToLower(kind),
+			Plural:     plural,
+			ShortNames: listCell(line, columns, "SHORTNAMES"),
+			Categories: listCell(line, columns, "CATEGORIES"),
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if columns == nil {
+		return nil, errors.New("output is empty")
+	}
+
+	return builder.build()
+}
+
+// DumpAPIVersions parses the list printed by `kubectl api-versions`. The
+// result contains API versions, but no resources.
+func DumpAPIVersions(output []byte) (*types.KubernetesAPI, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	builder := newAPIBuilder()
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.ContainsAny(line, " \t") {
+			return nil, fmt.Errorf("malformed line %q", line)
+		}
+
+		group, ver := splitAPIVersion(line)
+		builder.add(group, ver, nil)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return builder.build()
+}
+
+func parseHeader(line string) map[string]int {
+	columns := map[string]int{}
+
+	for i := 0; i < len(line); i++ {
+		if line[i] == ' ' || (i > 0 && line[i-1] != ' ') {
+			continue
+		}
+
+		end := strings.IndexByte(line[i:], ' ')
+		if end < 0 {
+			end = len(line) - i
+		}
+
+		columns[line[i:i+end]] = i
+	}
+
+	return columns
+}
+
+// cell returns the content of a column, which ends where the next column begins.
+func cell(line string, columns map[string]int, name string) string {
+	start := columns[name]
+	if start >= len(line) {
+		return ""
+	}
+
+	end := len(line)
+	for _, pos := range columns {
+		if pos > start && pos < end {
+			end = pos
+		}
+	}
+
+	end = min(end, len(line))
+
+	return strings.TrimSpace(line[start:end])
+}
+
+// listCell returns the comma-separated values of an optional column (like
+// SHORTNAMES or the CATEGORIES printed with "-o wide").
+func listCell(line string, columns map[string]int, name string) []string {
+	if _, ok := columns[name]; !ok {
+		return nil
+	}
+
+	var values []string
+	for _, value := range strings.Split(cell(line, columns, name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// splitAPIVersion turns "apps/v1" into "apps" and "v1"; the core API group is
+// represented by an empty string, like in the rest of the database.
+func splitAPIVersion(apiVersion string) (string, string) {
+	group, ver, found := strings.Cut(apiVersion, "/")
+	if !found {
+		return "", apiVersion
+	}
+
+	return group, ver
+}
+
+type apiBuilder struct {
+	groups map[string]map[string][]types.Resource
+}
+
+func newAPIBuilder() *apiBuilder {
+	return &apiBuilder{
+		groups: map[string]map[string][]types.Resource{},
+	}
+}
+
+func (b *apiBuilder) add(group, apiVersion string, resource *types.Resource) {
+	if b.groups[group] == nil {
+		b.groups[group] = map[string][]types.Resource{}
+	}
+
+	if b.groups[group][apiVersion] == nil {
+		b.groups[group][apiVersion] = []types.Resource{}
+	}
+
+	if resource != nil {
+		b.groups[group][apiVersion] = append(b.groups[group][apiVersion], *resource)
+	}
+}
+
+func (b *apiBuilder) build() (*types.KubernetesAPI, error) {
+	result := &types.KubernetesAPI{
+		APIGroups: []types.APIGroup{},
+	}
+
+	for groupName, versions := range b.groups {
+		group := types.APIGroup{
+			Name:        groupName,
+			APIVersions: []types.APIVersion{},
+		}
+
+		apiVersions := []string{}
+		for ver, resources := range versions {
+			apiVersions = append(apiVersions, ver)
+			group.APIVersions = append(group.APIVersions, types.APIVersion{
+				Version:   ver,
+				Resources: resources,
+			})
+		}
+
+		preferred, err := version.PreferredAPIVersion(apiVersions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API group %q: %w", groupName, err)
+		}
+
+		group.PreferredVersion = preferred.String()
+		result.APIGroups = append(result.APIGroups, group)
+	}
+
+	result.Sort()
+
+	return result, nil
+}
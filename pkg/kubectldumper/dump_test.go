@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package kubectldumper
+
+import (
+	"reflect"
+	"testing"
+)
+
+const apiResourcesWide = `NAME                  SHORTNAMES   APIVERSION        NAMESPACED   KIND                 VERBS                                                        CATEGORIES
+bindings                           v1                true         Binding              [create]
+configmaps            cm           v1                true         ConfigMap            [create delete deletecollection get list patch update watch]
+namespaces            ns           v1                false        Namespace            [create delete get list patch update watch]
+cronjobs              cj           batch/v1          true         CronJob              [create delete deletecollection get list patch update watch]   all
+cronjobs              cj           batch/v1beta1     true         CronJob              [create delete deletecollection get list patch update watch]   all
+`
+
+func TestDumpAPIResources(t *testing.T) {
+	api, err := Dump([]byte(apiResourcesWide))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	if len(api.APIGroups) != 2 {
+		t.Fatalf("Expected 2 API groups, got %+v", api.APIGroups)
+	}
+
+	core := api.APIGroups[0]
+	if core.Name != "" || len(core.APIVersions) != 1 || len(core.APIVersions[0].Resources) != 3 {
+		t.Fatalf("Expected core API group with 3 resources, got %+v", core)
+	}
+
+	if ns := core.APIVersions[0].Resources[2]; ns.Kind != "Namespace" || ns.Plural != "namespaces" || ns.Namespaced {
+		t.Errorf("Expected cluster-scoped Namespace resource, got %+v", ns)
+	}
+
+	batch := api.APIGroups[1]
+	if batch.Name != "batch" || len(batch.APIVersions) != 2 || batch.PreferredVersion != "v1" {
+		t.Fatalf("Expected batch API group with 2 versions, got %+v", batch)
+	}
+
+	if cj := batch.APIVersions[0].Resources[0]; cj.Kind != "CronJob" || !cj.Namespaced {
+		t.Errorf("Expected namespaced CronJob resource, got %+v", cj)
+	}
+
+	if cm := core.APIVersions[0].Resources[1]; !reflect.DeepEqual(cm.ShortNames, []string{"cm"}) || cm.Categories != nil {
+		t.Errorf("Expected ConfigMap with short name and without categories, got %+v", cm)
+	}
+
+	if cj := batch.APIVersions[0].Resources[0]; !reflect.DeepEqual(cj.Categories, []string{"all"}) {
+		t.Errorf("Expected CronJob in category \"all\", got %+v", cj)
+	}
+}
+
+func TestDumpAPIVersions(t *testing.T) {
+	api, err := Dump([]byte("apps/v1\nbatch/v1\nbatch/v1beta1\nv1\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	if len(api.APIGroups) != 3 {
+		t.Fatalf("Expected 3 API groups, got %+v", api.APIGroups)
+	}
+
+	if batch := api.APIGroups[2]; batch.Name != "batch" || len(batch.APIVersions) != 2 {
+		t.Errorf("Expected batch API group with 2 versions, got %+v", batch)
+	}
+}
+
+func TestDumpOutdatedKubectl(t *testing.T) {
+	output := "NAME         SHORTNAMES   APIGROUP   NAMESPACED   KIND\nbindings                             true         Binding\n"
+
+	if _, err := Dump([]byte(output)); err == nil {
+		t.Fatal("Expected error for output without API versions")
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// HelmOptions configures how Helm charts are rendered (via "helm template")
+// before their objects are checked.
+type HelmOptions struct {
+	// Binary is the helm executable (default "helm").
+	Binary string
+	// ValuesFiles are passed as --values.
+	ValuesFiles []string
+	// Values are passed as --set, like "ingress.enabled=true".
+	Values []string
+	// KubeVersion is the Kubernetes version charts are rendered for (like
+	// "1.29"), used for .Capabilities.KubeVersion.
+	KubeVersion string
+	// APIVersions are the API versions available to charts, used for
+	// .Capabilities.APIVersions.
+	APIVersions []string
+}
+
+// IsChart returns true if dir contains a Helm chart.
+func IsChart(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, "Chart.yaml"))
+	return err == nil
+}
+
+// RenderChart renders a chart via "helm template" and returns its objects.
+// Their source points to the template they were rendered from.
+func RenderChart(ctx context.Context, chartDir string, opts HelmOptions) ([]Object, error) {
+	binary := opts.Binary
+	if binary == "" {
+		binary = "helm"
+	}
+
+	args := []string{"template", "kube-api-ninja-audit", chartDir}
+
+	for _, file := range opts.ValuesFiles {
+		args = append(args, "--values", file)
+	}
+
+	for _, value := range opts.Values {
+		args = append(args, "--set", value)
+	}
+
+	if opts.KubeVersion != "" {
+		args = append(args, "--kube-version", opts.KubeVersion)
+	}
+
+	for _, apiVersion := range opts.APIVersions {
+		args = append(args, "--api-versions", apiVersion)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("failed to render chart %s: %s is not installed", chartDir, binary)
+		}
+
+		return nil, fmt.Errorf("failed to render chart %s: %w: %s", chartDir, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseRenderedChart(&stdout, chartDir)
+}
+
+// parseRenderedChart splits the output of "helm template" into documents and
+// uses their "# Source: chart/templates/…" comments to find the template
+// each object was rendered from.
+func parseRenderedChart(r io.Reader, chartDir string) ([]Object, error) {
+	reader := yaml.NewYAMLReader(bufio.NewReader(r))
+	objects := []Object{}
+
+	for {
+		doc, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("failed to read rendered chart %s: %w", chartDir, err)
+		}
+
+		source := chartDir
+		for _, line := range strings.Split(string(doc), "\n") {
+			if template, found := strings.CutPrefix(line, "# Source: "); found {
+				// the first element is the chart's name
+				if _, relative, found := strings.Cut(strings.TrimSpace(template), "/"); found {
+					source = filepath.Join(chartDir, filepath.FromSlash(relative))
+				}
+
+				break
+			}
+		}
+
+		parsed, err := Parse(bytes.NewReader(doc), source)
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, parsed...)
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package manifest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const renderedChart = `---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+# Source: web/charts/cache/templates/cronjob.yaml
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: flush
+`
+
+// fakeHelm creates a script that records its arguments and prints the output
+// of "helm template" for a chart named "web".
+func fakeHelm(t *testing.T, dir string) (string, string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake helm binary is a shell script")
+	}
+
+	argsFile := filepath.Join(dir, "args.txt")
+	outputFile := filepath.Join(dir, "output.yaml")
+
+	if err := os.WriteFile(outputFile, []byte(renderedChart), 0644); err != nil {
+		t.Fatalf("Failed to create fake output: %v", err)
+	}
+
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\ncat " + outputFile + "\n"
+
+	binary := filepath.Join(dir, "helm")
+	if err := os.WriteFile(binary, []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake helm: %v", err)
+	}
+
+	return binary, argsFile
+}
+
+func TestParseFilesAndCharts(t *testing.T) {
+	dir := t.TempDir()
+	binary, argsFile := fakeHelm(t, t.TempDir())
+
+	files := map[string]string{
+		"web/Chart.yaml":                "name: web",
+		"web/templates/deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: {{ .Release.Name }\n",
+		"plain/service.yaml":            "apiVersion: v1\nkind: Service\nmetadata:\n  name: web\n",
+	}
+
+	for name, content := range files {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := HelmOptions{
+		Binary:      binary,
+		ValuesFiles: []string{"prod.yaml"},
+		Values:      []string{"replicas=3"},
+		KubeVersion: "1.29",
+		APIVersions: []string{"batch/v1", "batch/v1/CronJob"},
+	}
+
+	objects, err := ParseFilesAndCharts(context.Background(), opts, dir)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	expected := []Object{
+		{Source: filepath.Join(dir, "plain", "service.yaml"), APIVersion: "v1", Kind: "Service", Name: "web"},
+		{Source: filepath.Join(dir, "web", "templates", "deployment.yaml"), APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+		{Source: filepath.Join(dir, "web", "charts", "cache", "templates", "cronjob.yaml"), APIVersion: "batch/v1beta1", Kind: "CronJob", Name: "flush"},
+	}
+
+	if !reflect.DeepEqual(objects, expected) {
+		t.Errorf("Expected %+v, got %+v.", expected, objects)
+	}
+
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("Fake helm was not called: %v", err)
+	}
+
+	expectedArgs := "template kube-api-ninja-audit " + filepath.Join(dir, "web") + " --values prod.yaml --set replicas=3 --kube-version 1.29 --api-versions batch/v1 --api-versions batch/v1/CronJob"
+	if got := strings.TrimSpace(string(args)); got != expectedArgs {
+		t.Errorf("Expected helm to be called with %q, got %q.", expectedArgs, got)
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// BuildKustomization renders a kustomization (like "kustomize build dir")
+// and returns the resulting objects. Since patches and generators make it
+// impossible to attribute objects to single files, their source is the
+// kustomization directory.
+func BuildKustomization(dir string) ([]Object, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+
+	resources, err := kustomizer.Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization %s: %w", dir, err)
+	}
+
+	rendered, err := resources.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode kustomization %s: %w", dir, err)
+	}
+
+	return Parse(bytes.NewReader(rendered), dir)
+}
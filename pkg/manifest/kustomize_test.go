@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBuildKustomization(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"base/kustomization.yaml": "resources:\n  - cronjob.yaml\n",
+		"base/cronjob.yaml":       "apiVersion: batch/v1\nkind: CronJob\nmetadata:\n  name: flush\n",
+		"overlays/prod/kustomization.yaml": `resources:
+  - ../../base
+namePrefix: prod-
+patches:
+  - target:
+      kind: CronJob
+    patch: |
+      - op: replace
+        path: /apiVersion
+        value: batch/v1beta1
+`,
+	}
+
+	for name, content := range files {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	overlay := filepath.Join(dir, "overlays", "prod")
+
+	objects, err := BuildKustomization(overlay)
+	if err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	expected := []Object{
+		{Source: overlay, APIVersion: "batch/v1beta1", Kind: "CronJob", Name: "prod-flush"},
+	}
+
+	if !reflect.DeepEqual(objects, expected) {
+		t.Errorf("Expected %+v, got %+v.", expected, objects)
+	}
+}
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Object is the identifying part of a Kubernetes object found in a manifest.
+type Object struct {
+	// Source is the file the object was read from.
+	Source     string
+	APIVersion string
+	Kind       string
+	Name       string
+}
+
+// Group returns the object's API group ("core" for the legacy core group).
+func (o Object) Group() string {
+	if group, _, found := strings.Cut(o.APIVersion, "/"); found {
+		return group
+	}
+
+	return "core"
+}
+
+// Version returns the object's API version without the group, like "v1".
+func (o Object) Version() string {
+	if _, version, found := strings.Cut(o.APIVersion, "/"); found {
+		return version
+	}
+
+	return o.APIVersion
+}
+
+func (o Object) String() string {
+	if o.Name == "" {
+		return fmt.Sprintf("%s %s", o.APIVersion, o.Kind)
+	}
+
+	return fmt.Sprintf("%s %s %s", o.APIVersion, o.Kind, o.Name)
+}
+
+type document struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Items []document `json:"items"`
+}
+
+// Parse reads all objects from a YAML (multiple documents are supported) or
+// JSON stream. Items of lists (like "kind: List") are returned individually.
+func Parse(r io.Reader, source string) ([]Object, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	objects := []Object{}
+
+	for {
+		doc := document{}
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+		}
+
+		objects = append(objects, doc.objects(source)...)
+	}
+
+	return objects, nil
+}
+
+func (d *document) objects(source string) []Object {
+	// empty documents, e.g. a trailing "---"
+	if d.APIVersion == "" && d.Kind == "" {
+		return nil
+	}
+
+	if strings.HasSuffix(d.Kind, "List") && d.Items != nil {
+		result := []Object{}
+		for _, item := range d.Items {
+			result = append(result, item.objects(source)...)
+		}
+
+		return result
+	}
+
+	return []Object{{
+		Source:     source,
+		APIVersion: d.APIVersion,
+		Kind:       d.Kind,
+		Name:       d.Metadata.Name,
+	}}
+}
+
+// ParseFiles reads all objects from the given files; directories are searched
+// recursively for .yaml, .yml and .json files.
+func ParseFiles(paths ...string) ([]Object, error) {
+	return parseFiles(context.Background(), nil, paths)
+}
+
+// ParseFilesAndCharts is like ParseFiles, but renders Helm charts (i.e.
+// directories containing a Chart.yaml) instead of parsing their templates.
+func ParseFilesAndCharts(ctx context.Context, helm HelmOptions, paths ...string) ([]Object, error) {
+	return parseFiles(ctx, &helm, paths)
+}
+
+func parseFiles(ctx context.Context, helm *HelmOptions, paths []string) ([]Object, error) {
+	objects := []Object{}
+
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				if helm == nil || !IsChart(path) {
+					return nil
+				}
+
+				rendered, err := RenderChart(ctx, path, *helm)
+				if err != nil {
+					return err
+				}
+
+				objects = append(objects, rendered...)
+
+				return fs.SkipDir
+			}
+
+			// explicitly given files are always parsed
+			if path != root && !isManifestFile(path) {
+				return nil
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			parsed, err := Parse(f, path)
+			if err != nil {
+				return err
+			}
+
+			objects = append(objects, parsed...)
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return objects, nil
+}
+
+func isManifestFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
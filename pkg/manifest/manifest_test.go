@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package manifest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	input := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+# only a comment
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: v1
+    kind: Service
+    metadata:
+      name: web
+  - apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: web
+`
+
+	objects, err := Parse(strings.NewReader(input), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	expected := []Object{
+		{Source: "test.yaml", APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+		{Source: "test.yaml", APIVersion: "v1", Kind: "Service", Name: "web"},
+		{Source: "test.yaml", APIVersion: "networking.k8s.io/v1", Kind: "Ingress", Name: "web"},
+	}
+
+	if !reflect.DeepEqual(objects, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, objects)
+	}
+
+	if group := objects[1].Group(); group != "core" {
+		t.Errorf("Expected core group, got %q", group)
+	}
+
+	if version := objects[2].Version(); version != "v1" {
+		t.Errorf("Expected version v1, got %q", version)
+	}
+}
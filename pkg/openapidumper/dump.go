@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package openapidumper builds API data from the OpenAPI v3 documents that
+// Kubernetes publishes per API group version (since 1.24), both in its
+// repository (api/openapi-spec/v3/) and via the apiserver's /openapi/v3
+// endpoint. Compared to the single Swagger 2.0 spec, these documents carry
+// more precise per-version data.
+package openapidumper
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+// MinimumRelease is the first Kubernetes release that published
+// OpenAPI v3 documents.
+const MinimumRelease = "1.24"
+
+// define just enough of the OpenAPI v3 spec to parse what we need :)
+
+type openapiSpec struct {
+	Paths      map[string]openapiPathSpec `json:"paths"`
+	Components struct {
+		Schemas map[string]openapiSchemaSpec `json:"schemas"`
+	} `json:"components"`
+}
+
+type openapiPathSpec struct {
+	Get  *openapiOperationSpec `json:"get"`
+	Post *openapiOperationSpec `json:"post"`
+}
+
+type openapiOperationSpec struct {
+	Action        string     `json:"x-kubernetes-action"`
+	KubernetesGVK openapiGVK `json:"x-kubernetes-group-version-kind"`
+	Deprecated    bool       `json:"deprecated"`
+}
+
+type openapiSchemaSpec struct {
+	Description    string       `json:"description"`
+	KubernetesGVKs []openapiGVK `json:"x-kubernetes-group-version-kind"`
+}
+
+type openapiGVK struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// matches "/api/v1/pods" and "/apis/apps/v1/namespaces/{namespace}/deployments",
+// but not subresources or watch paths
+var resourcePath = regexp.MustCompile(`^/apis?/(?:[^/]+/)?v[^/]+/(namespaces/\{namespace\}/)?([^/{]+)$`)
+
+// Kubernetes does not consistently set the OpenAPI "deprecated" flag, but
+// mentions deprecations in the descriptions, like "Deprecated in 1.21" or
+// "Deprecated: This API is deprecated in v1.19+".
+var deprecationNote = regexp.MustCompile(`(^|\.\s+)Deprecated[:\s]`)
+
+// DumpOpenAPISpecs reads all OpenAPI v3 documents (*.json) from the given
+// directory and combines them into a single API description.
+func DumpOpenAPISpecs(directory string, kubernetesVersion string) (*types.KubernetesAPI, error) {
+	kubeVersion, err := version.ParseSemver(kubernetesVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Kubernetes version: %w", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	filenames, err := filepath.Glob(filepath.Join(directory, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OpenAPI documents: %w", err)
+	}
+
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("no OpenAPI documents found in %s", directory)
+	}
+
+	specs := []*openapiSpec{}
+	for _, filename := range filenames {
+		spec, err := loadSpec(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", filepath.Base(filename), err)
+		}
+
+		specs = append(specs, spec)
+	}
+
+	result := dumpSpecs(logger, specs)
+	result.Version = kubeVersion.String()
+	result.Release = kubeVersion.MajorMinor()
+
+	return result, nil
+}
+
+func loadSpec(filename string) (*openapiSpec, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	spec := &openapiSpec{}
+	if err := json.NewDecoder(f).Decode(spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	return spec, nil
+}
+
+// dumpSpecs combines the parsed documents. Documents that do not describe an
+// API group version (like /version or /apis) are ignored.
+func dumpSpecs(logger *slog.Logger, specs []*openapiSpec) *types.KubernetesAPI {
+	// group => version => resources
+	groups := map[string]map[string][]types.Resource{}
+
+	for _, spec := range specs {
+		for path, pathSpec := range spec.Paths {
+			match := resourcePath.FindStringSubmatch(path)
+			if match == nil {
+				continue
+			}
+
+			operation := pathSpec.Post
+			if operation == nil {
+				operation = pathSpec.Get
+			}
+
+			// paths like /api/v1/componentstatuses/{name} are already excluded,
+			// but some paths have no GVK (e.g. /apis/authentication.k8s.io/v1/)
+			if operation == nil || operation.KubernetesGVK.Kind == "" {
+				continue
+			}
+
+			gvk := operation.KubernetesGVK
+			plural := match[2]
+			namespaced := match[1] != ""
+
+			if groups[gvk.Group] == nil {
+				groups[gvk.Group] = map[string][]types.Resource{}
+			}
+
+			// resources can be reachable both via their namespaced and
+			// their cluster-wide list paths (e.g. /api/v1/pods)
+			resources := groups[gvk.Group][gvk.Version]
+			if idx := findResource(resources, plural); idx >= 0 {
+				resources[idx].Namespaced = resources[idx].Namespaced || namespaced
+				continue
+			}
+
+			description := getResourceDescription(spec, gvk)
+			deprecated := operation.Deprecated || deprecationNote.MatchString(description)
+
+			logger.Info("Found resource.", "group", gvk.Group, "version", gvk.Version, "resource", gvk.Kind, "namespaced", namespaced, "deprecated", deprecated)
+
+			groups[gvk.Group][gvk.Version] = append(resources, types.Resource{
+				Kind:        gvk.Kind,
+				Namespaced:  namespaced,
+				Plural:      plural,
+				Singular:    strings.ToLower(gvk.Kind),
+				Description: description,
+				Deprecated:  deprecated,
+			})
+		}
+	}
+
+	result := &types.KubernetesAPI{
+		APIGroups: []types.APIGroup{},
+	}
+
+	for groupName, versions := range groups {
+		group := types.APIGroup{
+			Name:        groupName,
+			APIVersions: []types.APIVersion{},
+		}
+
+		apiVersions := []string{}
+		for ver, resources := range versions {
+			apiVersions = append(apiVersions, ver)
+			group.APIVersions = append(group.APIVersions, types.APIVersion{
+				Version:   ver,
+				Resources: resources,
+			})
+		}
+
+		preferred, err := version.PreferredAPIVersion(apiVersions)
+		if err != nil {
+			panic(err)
+		}
+
+		group.PreferredVersion = preferred.String()
+		result.APIGroups = append(result.APIGroups, group)
+	}
+
+	return result
+}
+
+func findResource(resources []types.Resource, plural string) int {
+	for i, res := range resources {
+		if res.Plural == plural {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func getResourceDescription(spec *openapiSpec, gvk openapiGVK) string {
+	for _, schema := range spec.Components.Schemas {
+		for _, schemaGVK := range schema.KubernetesGVKs {
+			if schemaGVK == gvk {
+				return schema.Description
+			}
+		}
+	}
+
+	return ""
+}
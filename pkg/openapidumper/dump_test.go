@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package openapidumper
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+const testSpec = `{
+  "paths": {
+    "/apis/policy/v1beta1/podsecuritypolicies": {
+      "get": {"x-kubernetes-action": "list", "x-kubernetes-group-version-kind": {"group": "policy", "version": "v1beta1", "kind": "PodSecurityPolicy"}}
+    },
+    "/apis/policy/v1beta1/namespaces/{namespace}/poddisruptionbudgets": {
+      "get": {"x-kubernetes-action": "list", "x-kubernetes-group-version-kind": {"group": "policy", "version": "v1beta1", "kind": "PodDisruptionBudget"}, "deprecated": true}
+    },
+    "/apis/policy/v1beta1/poddisruptionbudgets": {
+      "get": {"x-kubernetes-action": "list", "x-kubernetes-group-version-kind": {"group": "policy", "version": "v1beta1", "kind": "PodDisruptionBudget"}}
+    },
+    "/apis/policy/v1beta1/namespaces/{namespace}/poddisruptionbudgets/{name}/status": {
+      "get": {"x-kubernetes-action": "get", "x-kubernetes-group-version-kind": {"group": "policy", "version": "v1beta1", "kind": "PodDisruptionBudget"}}
+    }
+  },
+  "components": {
+    "schemas": {
+      "io.k8s.api.policy.v1beta1.PodSecurityPolicy": {
+        "description": "PodSecurityPolicy governs the ability to make requests that affect the Security Context. Deprecated in 1.21.",
+        "x-kubernetes-group-version-kind": [{"group": "policy", "version": "v1beta1", "kind": "PodSecurityPolicy"}]
+      }
+    }
+  }
+}`
+
+func TestDumpSpecs(t *testing.T) {
+	spec := &openapiSpec{}
+	if err := json.Unmarshal([]byte(testSpec), spec); err != nil {
+		t.Fatal(err)
+	}
+
+	api := dumpSpecs(slog.New(slog.NewTextHandler(io.Discard, nil)), []*openapiSpec{spec})
+	api.Sort()
+
+	if len(api.APIGroups) != 1 || len(api.APIGroups[0].APIVersions) != 1 {
+		t.Fatalf("Expected a single API group version, got %+v", api.APIGroups)
+	}
+
+	resources := api.APIGroups[0].APIVersions[0].Resources
+	if len(resources) != 2 {
+		t.Fatalf("Expected 2 resources, got %+v", resources)
+	}
+
+	pdb, psp := resources[0], resources[1]
+
+	if !pdb.Namespaced || !pdb.Deprecated {
+		t.Errorf("Expected PodDisruptionBudget to be namespaced and deprecated, got %+v", pdb)
+	}
+
+	if psp.Namespaced || !psp.Deprecated || psp.Description == "" {
+		t.Errorf("Expected PodSecurityPolicy to be cluster-scoped and deprecated, got %+v", psp)
+	}
+}
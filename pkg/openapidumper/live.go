@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package openapidumper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+
+	"k8s.io/client-go/openapi"
+)
+
+// DumpLiveOpenAPI fetches the OpenAPI v3 documents of all API group versions
+// from a running apiserver's /openapi/v3 endpoint (available since 1.24 and
+// enabled by default since 1.27) and combines them into a single API
+// description.
+func DumpLiveOpenAPI(ctx context.Context, client openapi.Client, kubernetesVersion string) (*types.KubernetesAPI, error) {
+	kubeVersion, err := version.ParseSemver(kubernetesVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Kubernetes version: %w", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	paths, err := client.Paths()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OpenAPI documents: %w", err)
+	}
+
+	names := []string{}
+	for name := range paths {
+		// only API group versions are of interest, not "version" or "openid/..."
+		if strings.HasPrefix(name, "api/") || strings.HasPrefix(name, "apis/") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("apiserver does not publish any OpenAPI v3 documents")
+	}
+
+	specs := []*openapiSpec{}
+	for _, name := range names {
+		// the openapi client has no context support, so at least
+		// check for cancellation in between the requests
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		logger.Info("Fetching OpenAPI document…", "path", name)
+
+		data, err := paths[name].Schema("application/json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", name, err)
+		}
+
+		spec := &openapiSpec{}
+		if err := json.Unmarshal(data, spec); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+
+		specs = append(specs, spec)
+	}
+
+	result := dumpSpecs(logger, specs)
+	result.Version = kubeVersion.String()
+	result.Release = kubeVersion.MajorMinor()
+
+	return result, nil
+}
@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package pdf writes simple, text-only PDF documents. It only uses the
+// standard fonts every PDF reader has to provide, so no fonts need to be
+// embedded, and is just enough to produce printable reports.
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 in points
+const (
+	pageWidth    = 595.0
+	pageHeight   = 842.0
+	margin       = 50.0
+	footerHeight = 20.0
+	lineSpacing  = 1.4
+)
+
+type Font string
+
+const (
+	Regular   Font = "F1"
+	Bold      Font = "F2"
+	Monospace Font = "F3"
+)
+
+var baseFonts = map[Font]string{
+	Regular:   "Helvetica",
+	Bold:      "Helvetica-Bold",
+	Monospace: "Courier",
+}
+
+// Document is a sequence of text lines that are flowed onto A4 pages.
+type Document struct {
+	title string
+	pages []*bytes.Buffer
+	y     float64
+}
+
+// New returns an empty document. The title is stored in the document
+// metadata and printed in the footer of every page.
+func New(title string) *Document {
+	return &Document{
+		title: title,
+	}
+}
+
+// Heading adds a large bold line, with some space above it.
+func (d *Document) Heading(text string) {
+	d.space(10)
+	d.line(Bold, 16, text)
+	d.space(4)
+}
+
+// Subheading adds a bold line, with some space above it.
+func (d *Document) Subheading(text string) {
+	d.space(8)
+	d.line(Bold, 12, text)
+	d.space(2)
+}
+
+// Text adds a paragraph, which is wrapped to fit the page.
+func (d *Document) Text(text string) {
+	// Helvetica has no fixed width, so this assumes a slightly wider than
+	// average character to stay within the margins
+	d.wrapped(Regular, 10, text, 0.55)
+}
+
+// Code adds a paragraph in a monospace font, which is useful for tabular
+// data, because padded columns stay aligned.
+func (d *Document) Code(text string) {
+	d.wrapped(Monospace, 9, text, 0.6)
+}
+
+// Pages returns the number of pages of the document.
+func (d *Document) Pages() int {
+	return len(d.pages)
+}
+
+func (d *Document) wrapped(font Font, size float64, text string, charWidth float64) {
+	maxChars := int((pageWidth - 2*margin) / (size * charWidth))
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		for _, line := range wrap(paragraph, maxChars) {
+			d.line(font, size, line)
+		}
+	}
+}
+
+func (d *Document) space(height float64) {
+	// no need for space at the top of a page
+	if len(d.pages) > 0 && d.y < pageHeight-margin {
+		d.y -= height
+	}
+}
+
+func (d *Document) line(font Font, size float64, text string) {
+	height := size * lineSpacing
+
+	if len(d.pages) == 0 || d.y-height < margin+footerHeight {
+		d.pages = append(d.pages, &bytes.Buffer{})
+		d.y = pageHeight - margin
+	}
+
+	d.y -= height
+
+	page := d.pages[len(d.pages)-1]
+	fmt.Fprintf(page, "BT /%s %.1f Tf %.1f %.1f Td (%s) Tj ET\n", font, size, margin, d.y, escape(text))
+}
+
+// Write serializes the document. A document without any content still
+// consists of a single, empty page.
+func (d *Document) Write(w io.Writer) error {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*bytes.Buffer{{}}
+	}
+
+	bw := &countingWriter{w: bufio.NewWriter(w)}
+	offsets := []int{}
+
+	// objects are numbered from 1: the catalog, the page tree, the info
+	// dictionary, the fonts and then a page and its content for each page
+	fontObjects := map[Font]int{Regular: 4, Bold: 5, Monospace: 6}
+	firstPage := 7
+
+	object := func(content string) {
+		offsets = append(offsets, bw.n)
+		fmt.Fprintf(bw, "%d 0 obj\n%s\nendobj\n", len(offsets), content)
+	}
+
+	fmt.Fprint(bw, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	kids := []string{}
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPage+2*i))
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object(fmt.Sprintf("<< /Title (%s) /Producer (kube-api.ninja) >>", escape(d.title)))
+
+	for _, font := range []Font{Regular, Bold, Monospace} {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", baseFonts[font]))
+	}
+
+	fonts := []string{}
+	for _, font := range []Font{Regular, Bold, Monospace} {
+		fonts = append(fonts, fmt.Sprintf("/%s %d 0 R", font, fontObjects[font]))
+	}
+
+	for i, page := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << %s >> >> /Contents %d 0 R >>", pageWidth, pageHeight, strings.Join(fonts, " "), firstPage+2*i+1))
+
+		footer := fmt.Sprintf("%s - page %d of %d", d.title, i+1, len(pages))
+		content := page.String() + fmt.Sprintf("BT /%s 8 Tf %.1f %.1f Td (%s) Tj ET\n", Regular, margin, margin-footerHeight, escape(footer))
+
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := bw.n
+	fmt.Fprintf(bw, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(bw, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(bw, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	if bw.err != nil {
+		return bw.err
+	}
+
+	return bw.w.Flush()
+}
+
+// escape encodes the text as a PDF string literal in WinAnsiEncoding, which
+// matches Latin-1 for all characters this package cares about.
+func escape(text string) string {
+	var buf strings.Builder
+
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			buf.WriteRune('\\')
+			buf.WriteRune(r)
+		case r == '→':
+			buf.WriteString("->")
+		case r == '…':
+			buf.WriteString("...")
+		case r < 0x20:
+			buf.WriteByte(' ')
+		case r > 0xff:
+			buf.WriteByte('?')
+		default:
+			buf.WriteByte(byte(r))
+		}
+	}
+
+	return buf.String()
+}
+
+func wrap(text string, maxChars int) []string {
+	lines := []string{}
+	current := ""
+
+	for _, word := range strings.Split(text, " ") {
+		switch {
+		case current == "":
+			current = word
+		case len([]rune(current))+1+len([]rune(word)) <= maxChars:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+
+	return append(lines, current)
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	n, err := c.w.Write(p)
+	c.n += n
+	c.err = err
+
+	return n, err
+}
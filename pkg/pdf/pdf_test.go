@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	doc := New("Report (draft)")
+	doc.Heading("Kubernetes API changes from 1.24 → 1.25")
+
+	for i := 0; i < 150; i++ {
+		doc.Code(fmt.Sprintf("removed  batch/v1beta1 CronJob %d", i))
+	}
+
+	if doc.Pages() != 3 {
+		t.Errorf("Expected 150 lines to need 3 pages, got %d.", doc.Pages())
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		t.Fatalf("Failed to write document: %v", err)
+	}
+
+	output := buf.String()
+
+	if !strings.HasPrefix(output, "%PDF-1.4\n") {
+		t.Errorf("Expected PDF header, got %q.", output[:10])
+	}
+
+	for _, expected := range []string{
+		"(Kubernetes API changes from 1.24 -> 1.25) Tj",
+		"(removed  batch/v1beta1 CronJob 149) Tj",
+		"(Report \\(draft\\) - page 3 of 3) Tj",
+		"/Count 3",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q.", expected)
+		}
+	}
+
+	// every entry in the cross-reference table must point to its object
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(output)
+	if match == nil {
+		t.Fatal("Output has no startxref.")
+	}
+
+	xref, _ := strconv.Atoi(match[1])
+	if !strings.HasPrefix(output[xref:], "xref\n") {
+		t.Fatalf("startxref does not point to the cross-reference table.")
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(output[xref:], -1)
+	if len(entries) != 12 {
+		t.Fatalf("Expected 12 objects, got %d.", len(entries))
+	}
+
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(entry[1])
+		if prefix := fmt.Sprintf("%d 0 obj\n", i+1); !strings.HasPrefix(output[offset:], prefix) {
+			t.Errorf("Expected object %d at offset %d.", i+1, offset)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	testcases := []struct {
+		text     string
+		expected []string
+	}{
+		{
+			text:     "",
+			expected: []string{""},
+		},
+		{
+			text:     "short line",
+			expected: []string{"short line"},
+		},
+		{
+			text:     "this line is too long",
+			expected: []string{"this line", "is too", "long"},
+		},
+		{
+			text:     "overlylongwords are kept",
+			expected: []string{"overlylongwords", "are kept"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		lines := wrap(testcase.text, 10)
+		if strings.Join(lines, "|") != strings.Join(testcase.expected, "|") {
+			t.Errorf("Expected %q to be wrapped as %q, got %q.", testcase.text, testcase.expected, lines)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+/*
+Package rego creates Open Policy Agent bundles that list the removed and
+deprecated APIs of a Kubernetes release, so that Gatekeeper, Conftest and
+other OPA-based tools can use the data in their policies.
+
+A bundle contains the data below data.kube_api_ninja, keyed by apiVersion
+and kind:
+
+	data.kube_api_ninja.removed["batch/v1beta1"]["CronJob"]
+	  => {"release": "1.25", "replacement": "batch/v1"}
+
+It also contains a policy in the same package with deny (removed APIs) and
+warn (deprecated APIs) rules, which can be used with Conftest as-is:
+
+	mkdir policy && tar -xzf 1.29.tar.gz -C policy
+	conftest test --policy policy --data policy --namespace kube_api_ninja deployment.yaml
+*/
+package rego
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/manifest"
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+// Root is the bundle root, i.e. the data and policies are available below
+// data.kube_api_ninja.
+const Root = "kube_api_ninja"
+
+//go:embed policy.rego
+var policy []byte
+
+// Data is the data document of a bundle.
+type Data struct {
+	Target     string                         `json:"target"`
+	Removed    map[string]map[string]APIEntry `json:"removed"`
+	Deprecated map[string]map[string]APIEntry `json:"deprecated"`
+}
+
+// APIEntry describes why an API is listed.
+type APIEntry struct {
+	// Release is the release in which the API was (or will be) removed.
+	Release string `json:"release"`
+	// Replacement is the API version ("group/version") that serves the kind
+	// in the target release instead, if any.
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// NewData lists the APIs that are not served anymore by the target release
+// or will be removed in a later one.
+func NewData(tl *timeline.Timeline, target string) (*Data, error) {
+	objects := []manifest.Object{}
+
+	for _, apiGroup := range tl.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				objects = append(objects, manifest.Object{
+					APIVersion: groupVersion(apiGroup.Name, apiVersion.Version),
+					Kind:       apiResource.Kind,
+				})
+			}
+		}
+	}
+
+	report, err := tl.Audit(objects, target)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &Data{
+		Target:     report.Target,
+		Removed:    map[string]map[string]APIEntry{},
+		Deprecated: map[string]map[string]APIEntry{},
+	}
+
+	for _, finding := range report.Findings {
+		var apis map[string]map[string]APIEntry
+
+		switch finding.Status {
+		case timeline.AuditRemoved:
+			apis = data.Removed
+		case timeline.AuditDeprecated:
+			apis = data.Deprecated
+		default:
+			continue
+		}
+
+		entry := APIEntry{Release: finding.Release}
+		if finding.Alternative != "" && finding.Alternative != finding.Object.Version() {
+			entry.Replacement = groupVersion(finding.Object.Group(), finding.Alternative)
+		}
+
+		if apis[finding.Object.APIVersion] == nil {
+			apis[finding.Object.APIVersion] = map[string]APIEntry{}
+		}
+
+		apis[finding.Object.APIVersion][finding.Object.Kind] = entry
+	}
+
+	return data, nil
+}
+
+type bundleManifest struct {
+	Revision string   `json:"revision"`
+	Roots    []string `json:"roots"`
+}
+
+// WriteBundle writes the gzipped bundle for the data. The bundle's revision
+// is derived from the data, so it only changes if the data changes.
+func WriteBundle(w io.Writer, data *Data) error {
+	encodedData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode data: %w", err)
+	}
+
+	checksum := sha256.Sum256(append(encodedData, policy...))
+
+	encodedManifest, err := json.Marshal(bundleManifest{
+		Revision: fmt.Sprintf("%s-%s", data.Target, hex.EncodeToString(checksum[:])[:12]),
+		Roots:    []string{Root},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{name: ".manifest", content: encodedManifest},
+		{name: Root + "/data.json", content: encodedData},
+		{name: Root + "/policy.rego", content: policy},
+	}
+
+	for _, file := range files {
+		// a fixed timestamp makes the bundles reproducible
+		header := &tar.Header{
+			Name:    file.name,
+			Mode:    0644,
+			Size:    int64(len(file.content)),
+			ModTime: time.Unix(0, 0),
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(tw, bytes.NewReader(file.content)); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+func groupVersion(group, version string) string {
+	if group == "core" {
+		return version
+	}
+
+	return fmt.Sprintf("%s/%s", group, version)
+}
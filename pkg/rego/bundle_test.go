@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package rego
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+func TestNewData(t *testing.T) {
+	tl := &timeline.Timeline{
+		Releases: []timeline.ReleaseMetadata{
+			{Version: "1.24"},
+			{Version: "1.25"},
+			{Version: "1.26"},
+		},
+		APIGroups: []timeline.APIGroup{
+			{
+				Name: "batch",
+				APIVersions: []timeline.APIVersion{
+					{
+						Version: "v1",
+						Resources: []timeline.APIResource{
+							{Kind: "CronJob", Releases: []string{"1.24", "1.25", "1.26"}},
+						},
+					},
+					{
+						Version: "v1beta1",
+						Resources: []timeline.APIResource{
+							{Kind: "CronJob", Releases: []string{"1.24"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "core",
+				APIVersions: []timeline.APIVersion{
+					{
+						Version: "v1",
+						Resources: []timeline.APIResource{
+							{Kind: "ComponentStatus", Releases: []string{"1.24", "1.25"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	data, err := NewData(tl, "1.25")
+	if err != nil {
+		t.Fatalf("Failed to create data: %v", err)
+	}
+
+	expected := &Data{
+		Target: "1.25",
+		Removed: map[string]map[string]APIEntry{
+			"batch/v1beta1": {"CronJob": {Release: "1.25", Replacement: "batch/v1"}},
+		},
+		Deprecated: map[string]map[string]APIEntry{
+			"v1": {"ComponentStatus": {Release: "1.26"}},
+		},
+	}
+
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Expected %+v, got %+v.", expected, data)
+	}
+
+	if _, err := NewData(tl, "1.99"); !errors.Is(err, timeline.ErrUnknownRelease) {
+		t.Errorf("Expected ErrUnknownRelease, got %v.", err)
+	}
+}
+
+func TestWriteBundle(t *testing.T) {
+	data := &Data{Target: "1.29"}
+
+	var first, second bytes.Buffer
+	if err := WriteBundle(&first, data); err != nil {
+		t.Fatalf("Failed to write bundle: %v", err)
+	}
+
+	if err := WriteBundle(&second, data); err != nil {
+		t.Fatalf("Failed to write bundle: %v", err)
+	}
+
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Error("Expected bundles to be reproducible.")
+	}
+
+	gz, err := gzip.NewReader(&first)
+	if err != nil {
+		t.Fatalf("Bundle is not gzipped: %v", err)
+	}
+
+	files := []string{}
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to read bundle: %v", err)
+		}
+
+		files = append(files, header.Name)
+	}
+
+	expected := []string{".manifest", "kube_api_ninja/data.json", "kube_api_ninja/policy.rego"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("Expected files %v, got %v.", expected, files)
+	}
+}
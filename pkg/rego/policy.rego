@@ -0,0 +1,27 @@
+# SPDX-FileCopyrightText: 2023 Christoph Mewes
+# SPDX-License-Identifier: MIT
+
+# This policy is part of the bundles published by kube-api.ninja and checks
+# Kubernetes objects against the data of the bundle's target release.
+
+package kube_api_ninja
+
+import rego.v1
+
+deny contains msg if {
+	api := data.kube_api_ninja.removed[input.apiVersion][input.kind]
+	msg := sprintf("%s %s %s was removed in Kubernetes %s%s", [input.apiVersion, input.kind, object_name, api.release, replacement_hint(api)])
+}
+
+warn contains msg if {
+	api := data.kube_api_ninja.deprecated[input.apiVersion][input.kind]
+	msg := sprintf("%s %s %s will be removed in Kubernetes %s%s", [input.apiVersion, input.kind, object_name, api.release, replacement_hint(api)])
+}
+
+object_name := name if {
+	name := input.metadata.name
+} else := "(unnamed)"
+
+replacement_hint(api) := hint if {
+	hint := sprintf(", use %s instead", [api.replacement])
+} else := ""
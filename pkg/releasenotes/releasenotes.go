@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package releasenotes extracts the API-related entries from the upstream
+// Kubernetes changelogs (CHANGELOG/CHANGELOG-1.x.md in kubernetes/kubernetes).
+package releasenotes
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+const (
+	KindUrgentUpgradeNote = "Urgent Upgrade Note"
+	KindDeprecation       = "Deprecation"
+	KindAPIChange         = "API Change"
+)
+
+var (
+	// e.g. "CHANGELOG-1.29.md"
+	filenamePattern = regexp.MustCompile(`CHANGELOG-([0-9]+\.[0-9]+)\.md$`)
+
+	// the attribution at the end of every entry, like
+	// "([#118764](https://github.com/kubernetes/kubernetes/pull/118764), [@someone](https://github.com/someone)) [SIG Apps and Testing]"
+	attributionPattern = regexp.MustCompile(`\s*\(\[#[0-9]+\]\((https://github\.com/[^)]+/pull/[0-9]+)\)[^()]*(\([^()]*\)[^()]*)*\)\s*(\[SIG[^\]]*\])?\s*$`)
+
+	whitespace = regexp.MustCompile(`\s+`)
+)
+
+// ReleaseFromFilename returns the minor release (like "1.29") of a changelog
+// file, or an empty string if the filename does not follow the upstream
+// naming scheme.
+func ReleaseFromFilename(filename string) string {
+	match := filenamePattern.FindStringSubmatch(filename)
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
+
+// Parse reads a changelog and returns the urgent upgrade notes, deprecations
+// and API changes of the given minor release (like "1.29"), i.e. the entries
+// of the "v1.29.0" section, which covers all changes since the previous
+// minor release. Patch releases and prereleases are ignored.
+func Parse(r io.Reader, release string) ([]types.ReleaseNote, error) {
+	notes := []types.ReleaseNote{}
+	heading := fmt.Sprintf("# v%s.0", release)
+
+	var (
+		inRelease bool
+		kind      string
+		current   *types.ReleaseNote
+		text      []string
+	)
+
+	flush := func() {
+		if current != nil {
+			current.Text = strings.TrimSpace(whitespace.ReplaceAllString(strings.Join(text, " "), " "))
+
+			if match := attributionPattern.FindStringSubmatchIndex(current.Text); match != nil {
+				current.PullRequest = current.Text[match[2]:match[3]]
+				current.Text = current.Text[:match[0]]
+			}
+
+			if current.Text != "" {
+				notes = append(notes, *current)
+			}
+		}
+
+		current = nil
+		text = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "# "):
+			flush()
+
+			// the sections of all releases follow each other, so the
+			// section ends with the next release's heading
+			if inRelease {
+				return notes, nil
+			}
+
+			inRelease = strings.TrimSpace(line) == heading
+			kind = ""
+
+		case !inRelease:
+			continue
+
+		case strings.HasPrefix(line, "## "):
+			flush()
+
+			kind = ""
+			if strings.TrimSpace(strings.TrimPrefix(line, "## ")) == "Urgent Upgrade Notes" {
+				kind = KindUrgentUpgradeNote
+			}
+
+		case strings.HasPrefix(line, "### "):
+			flush()
+
+			// the urgent upgrade notes have a single subsection
+			if kind == KindUrgentUpgradeNote {
+				continue
+			}
+
+			switch strings.TrimSpace(strings.TrimPrefix(line, "### ")) {
+			case KindDeprecation:
+				kind = KindDeprecation
+			case KindAPIChange:
+				kind = KindAPIChange
+			default:
+				kind = ""
+			}
+
+		case kind == "":
+			continue
+
+		case strings.HasPrefix(line, "- "):
+			flush()
+
+			current = &types.ReleaseNote{Kind: kind}
+			text = []string{strings.TrimPrefix(line, "- ")}
+
+		case current != nil && strings.TrimSpace(line) != "":
+			text = append(text, strings.TrimSpace(line))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	flush()
+
+	return notes, nil
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package releasenotes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+const testChangelog = `<!-- BEGIN MUNGE: GENERATED_TOC -->
+
+- [v1.29.1](#v1291)
+- [v1.29.0](#v1290)
+
+<!-- END MUNGE: GENERATED_TOC -->
+
+# v1.29.1
+
+## Changes by Kind
+
+### API Change
+
+- A patch release change. ([#1](https://github.com/kubernetes/kubernetes/pull/1), [@someone](https://github.com/someone)) [SIG Apps]
+
+# v1.29.0
+
+[Documentation](https://docs.k8s.io)
+
+## Changelog since v1.28.0
+
+## Urgent Upgrade Notes
+
+### (No, really, you MUST read this before you upgrade)
+
+- The flowcontrol.apiserver.k8s.io/v1beta2 API version of FlowSchema is no longer served. ([#118782](https://github.com/kubernetes/kubernetes/pull/118782), [@someone](https://github.com/someone)) [SIG API Machinery]
+
+## Changes by Kind
+
+### Deprecation
+
+- Deprecated the ` + "`status.nodeInfo.kubeProxyVersion`" + ` field of Node. ([#120954](https://github.com/kubernetes/kubernetes/pull/120954), [#121000](https://github.com/kubernetes/kubernetes/pull/121000), [@someone](https://github.com/someone)) [SIG API Machinery, Apps and Node]
+
+### API Change
+
+- Added a new ` + "`ipMode`" + ` field to the LoadBalancer status of a Service.
+  The field is alpha.
+  
+  - It requires the LoadBalancerIPMode feature gate.
+   ([#119937](https://github.com/kubernetes/kubernetes/pull/119937), [@someone](https://github.com/someone)) [SIG Apps and Network]
+- An entry without attribution
+
+### Feature
+
+- A feature that is not relevant. ([#2](https://github.com/kubernetes/kubernetes/pull/2), [@someone](https://github.com/someone)) [SIG Node]
+
+## Dependencies
+
+- Bumped something.
+
+# v1.29.0-rc.2
+
+## Changes by Kind
+
+### API Change
+
+- A prerelease change. ([#3](https://github.com/kubernetes/kubernetes/pull/3), [@someone](https://github.com/someone)) [SIG Apps]
+`
+
+func TestParse(t *testing.T) {
+	notes, err := Parse(strings.NewReader(testChangelog), "1.29")
+	if err != nil {
+		t.Fatalf("Failed to parse changelog: %v", err)
+	}
+
+	expected := []types.ReleaseNote{
+		{
+			Kind:        KindUrgentUpgradeNote,
+			Text:        "The flowcontrol.apiserver.k8s.io/v1beta2 API version of FlowSchema is no longer served.",
+			PullRequest: "https://github.com/kubernetes/kubernetes/pull/118782",
+		},
+		{
+			Kind:        KindDeprecation,
+			Text:        "Deprecated the `status.nodeInfo.kubeProxyVersion` field of Node.",
+			PullRequest: "https://github.com/kubernetes/kubernetes/pull/120954",
+		},
+		{
+			Kind:        KindAPIChange,
+			Text:        "Added a new `ipMode` field to the LoadBalancer status of a Service. The field is alpha. - It requires the LoadBalancerIPMode feature gate.",
+			PullRequest: "https://github.com/kubernetes/kubernetes/pull/119937",
+		},
+		{
+			Kind: KindAPIChange,
+			Text: "An entry without attribution",
+		},
+	}
+
+	if !reflect.DeepEqual(notes, expected) {
+		t.Errorf("Expected\n%+v\nbut got\n%+v", expected, notes)
+	}
+}
+
+func TestReleaseFromFilename(t *testing.T) {
+	testcases := []struct {
+		filename string
+		expected string
+	}{
+		{filename: "CHANGELOG/CHANGELOG-1.29.md", expected: "1.29"},
+		{filename: "CHANGELOG-1.9.md", expected: "1.9"},
+		{filename: "CHANGELOG.md", expected: ""},
+	}
+
+	for _, tc := range testcases {
+		if release := ReleaseFromFilename(tc.filename); release != tc.expected {
+			t.Errorf("Expected release %q for %s, got %q.", tc.expected, tc.filename, release)
+		}
+	}
+}
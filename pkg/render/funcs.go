@@ -6,10 +6,12 @@ package render
 import (
 	"fmt"
 	"html/template"
+	"math"
 	"strconv"
 	"strings"
 
 	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+	"go.xrstf.de/kube-api.ninja/pkg/types"
 	"go.xrstf.de/kube-api.ninja/pkg/version"
 )
 
@@ -18,20 +20,40 @@ var (
 		"add": func(a, b int) int {
 			return a + b
 		},
-		"getROIViewRange":              getROIViewRange,
-		"getVersionClass":              getVersionClass,
-		"getROIClass":                  getROIClass,
-		"getReleaseHeaderClass":        getReleaseHeaderClass,
-		"getAPIGroupBodyClass":         getAPIGroupBodyClass,
-		"getAPIGroupClass":             getAPIGroupClass,
-		"getAPIGroupReleaseClass":      getAPIGroupReleaseClass,
-		"getAPIVersionClass":           getAPIVersionClass,
-		"getAPIVersionReleaseClass":    getAPIVersionReleaseClass,
-		"getAPIVersionReleaseContent":  getAPIVersionReleaseContent,
-		"getAPIResourceClass":          getAPIResourceClass,
-		"getAPIResourceReleaseClass":   getAPIResourceReleaseClass,
-		"getAPIResourceReleaseContent": getAPIResourceReleaseContent,
-		"getResourceDocumentationLink": getResourceDocumentationLink,
+		"reverseReleases":                  reverseReleases,
+		"getReleasedReleases":              getReleasedReleases,
+		"getReleaseStatus":                 getReleaseStatus,
+		"getPreReleaseTitle":               getPreReleaseTitle,
+		"getPatchCadence":                  getPatchCadence,
+		"hasProjectedReleases":             hasProjectedReleases,
+		"hasConformanceData":               hasConformanceData,
+		"getAnnotationTitle":               getAnnotationTitle,
+		"getReleaseNotesTitle":             getReleaseNotesTitle,
+		"getSuccessionTitle":               getSuccessionTitle,
+		"getGroupLineageTitle":             getGroupLineageTitle,
+		"getMaturityTitle":                 getMaturityTitle,
+		"getMigrationTitle":                getMigrationTitle,
+		"getAPIVersionRange":               getAPIVersionRange,
+		"getDefaultEnablementNote":         getDefaultEnablementNote,
+		"getAddedAPIVersions":              getAddedAPIVersions,
+		"getRemovedAPIVersions":            getRemovedAPIVersions,
+		"getFeatureGatedAPIs":              getFeatureGatedAPIs,
+		"getROIViewRange":                  getROIViewRange,
+		"getVersionClass":                  getVersionClass,
+		"getROIClass":                      getROIClass,
+		"getReleaseHeaderClass":            getReleaseHeaderClass,
+		"getAPIGroupBodyClass":             getAPIGroupBodyClass,
+		"getAPIGroupClass":                 getAPIGroupClass,
+		"getAPIGroupReleaseClass":          getAPIGroupReleaseClass,
+		"getAPIVersionClass":               getAPIVersionClass,
+		"getAPIVersionReleaseClass":        getAPIVersionReleaseClass,
+		"getAPIVersionReleaseContent":      getAPIVersionReleaseContent,
+		"getAPIResourceClass":              getAPIResourceClass,
+		"getAPIResourceReleaseClass":       getAPIResourceReleaseClass,
+		"getAPIResourceReleaseContent":     getAPIResourceReleaseContent,
+		"getAPIResourceReleaseDescription": getAPIResourceReleaseDescription,
+		"getResourceDocumentationLink":     getResourceDocumentationLink,
+		"getResourceAnchor":                ResourceAnchor,
 	}
 )
 
@@ -46,6 +68,282 @@ func jumpMinorRelease(s string, minorSteps int) string {
 	return fmt.Sprintf("%s.%d", parts[0], minor+minorSteps)
 }
 
+func reverseReleases(releases []timeline.ReleaseMetadata) []timeline.ReleaseMetadata {
+	result := make([]timeline.ReleaseMetadata, len(releases))
+	for i, rel := range releases {
+		result[len(releases)-i-1] = rel
+	}
+
+	return result
+}
+
+// getReleasedReleases returns all releases that have already been
+// released, newest first.
+func getReleasedReleases(tl *timeline.Timeline) []timeline.ReleaseMetadata {
+	result := []timeline.ReleaseMetadata{}
+	for _, rel := range reverseReleases(tl.Releases) {
+		if rel.Released {
+			result = append(result, rel)
+		}
+	}
+
+	return result
+}
+
+// getReleaseStatus returns a short, human readable support status.
+func getReleaseStatus(release timeline.ReleaseMetadata) string {
+	switch {
+	case release.Projected:
+		return "projected"
+	case !release.Released:
+		return "upcoming"
+	case release.SupportPhase == timeline.SupportPhaseMaintenance:
+		return "maintenance mode"
+	case release.Supported:
+		return "supported"
+	default:
+		return "end of life"
+	}
+}
+
+// getPreReleaseTitle describes what the data of a pre-release is based on.
+func getPreReleaseTitle(release timeline.ReleaseMetadata) string {
+	switch release.PreReleaseStage {
+	case "alpha", "beta":
+		return fmt.Sprintf("pre-release, based on %s builds", release.PreReleaseStage)
+	case "rc":
+		return "pre-release, based on release candidates"
+	default:
+		return "pre-release"
+	}
+}
+
+func getPatchCadence(release timeline.ReleaseMetadata) string {
+	cadence := release.PatchCadence()
+	if cadence == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("every %d days", int(math.Round(cadence.Hours()/24)))
+}
+
+func hasProjectedReleases(tl *timeline.Timeline) bool {
+	for _, rel := range tl.Releases {
+		if rel.Projected {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasConformanceData returns true if the conformance coverage of at least one
+// release is known.
+func hasConformanceData(tl *timeline.Timeline) bool {
+	for _, apiGroup := range tl.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				if len(apiResource.ConformanceReleases) > 0 {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// getAnnotationTitle returns the tooltip for a user-provided annotation.
+func getAnnotationTitle(annotation *types.Annotation) string {
+	lines := []string{}
+
+	if annotation.Note != "" {
+		lines = append(lines, annotation.Note)
+	}
+
+	if annotation.Owner != "" {
+		lines = append(lines, fmt.Sprintf("Owner: %s", annotation.Owner))
+	}
+
+	if annotation.Ticket != "" {
+		lines = append(lines, fmt.Sprintf("Ticket: %s", annotation.Ticket))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func getReleaseNotesTitle(notes []types.ReleaseNote) string {
+	lines := []string{}
+
+	for _, note := range notes {
+		lines = append(lines, fmt.Sprintf("%s: %s", note.Kind, note.Text))
+	}
+
+	return strings.Join(lines, "\n\n")
+}
+
+// getSuccessionTitle describes where a resource moved to, e.g.
+// "graduated to apps/v1".
+func getSuccessionTitle(succession *timeline.ResourceSuccession) string {
+	verb := "graduated to"
+	if succession.Type == timeline.Replacement {
+		verb = "replaced by"
+	}
+
+	return fmt.Sprintf("%s %s", verb, groupVersion(succession.Group, succession.Version))
+}
+
+// getMigrationTitle describes where a resource moved to, e.g. "moved to
+// networking.k8s.io/v1 in 1.16".
+func getMigrationTitle(migration *timeline.ResourceMigration) string {
+	return fmt.Sprintf("moved to %s in %s", groupVersion(migration.Group, migration.Version), migration.Release)
+}
+
+// getGroupLineageTitle describes which groups an API group took over
+// resources from, e.g. "took over Deployment, ReplicaSet from extensions".
+func getGroupLineageTitle(links []timeline.GroupLink) string {
+	parts := []string{}
+	for _, link := range links {
+		parts = append(parts, fmt.Sprintf("%s from %s", strings.Join(link.Kinds, ", "), link.Group))
+	}
+
+	return "took over " + strings.Join(parts, "; ")
+}
+
+// getMaturityTitle describes when an API group reached which maturity, e.g.
+// "alpha in 1.18, beta in 1.20, GA in 1.21".
+func getMaturityTitle(progression timeline.MaturityProgression) string {
+	parts := []string{}
+
+	if progression.Alpha != "" {
+		parts = append(parts, "alpha in "+progression.Alpha)
+	}
+
+	if progression.Beta != "" {
+		parts = append(parts, "beta in "+progression.Beta)
+	}
+
+	if progression.GA != "" {
+		parts = append(parts, "GA in "+progression.GA)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// getAPIVersionRange returns the first and last release that contained
+// the given API version, e.g. "1.16 – 1.29".
+func getAPIVersionRange(apiVersion *timeline.APIVersion) string {
+	if len(apiVersion.Releases) == 0 {
+		return ""
+	}
+
+	first := apiVersion.Releases[0]
+	last := apiVersion.Releases[len(apiVersion.Releases)-1]
+
+	if first == last {
+		return first
+	}
+
+	return fmt.Sprintf("%s – %s", first, last)
+}
+
+// getDefaultEnablementNote describes since when an API version is disabled by
+// default, if it is in its most recent release.
+func getDefaultEnablementNote(apiVersion *timeline.APIVersion) string {
+	if len(apiVersion.Releases) == 0 || apiVersion.DefaultEnabled() {
+		return ""
+	}
+
+	since := ""
+	for _, release := range apiVersion.Releases {
+		if apiVersion.DefaultEnabledIn(release) {
+			since = ""
+		} else if since == "" {
+			since = release
+		}
+	}
+
+	if since == apiVersion.Releases[0] {
+		return "disabled by default"
+	}
+
+	return "disabled by default since " + since
+}
+
+// getAddedAPIVersions returns all group versions (like "apps/v1") that
+// appeared in the given release.
+func getAddedAPIVersions(tl *timeline.Timeline, release string) []string {
+	return getChangedAPIVersions(tl, release, func(apiVersion *timeline.APIVersion, previous string) bool {
+		return apiVersion.HasRelease(release) && (previous == "" || !apiVersion.HasRelease(previous))
+	})
+}
+
+// getRemovedAPIVersions returns all group versions that were available in
+// the previous release, but not anymore in the given one.
+func getRemovedAPIVersions(tl *timeline.Timeline, release string) []string {
+	return getChangedAPIVersions(tl, release, func(apiVersion *timeline.APIVersion, previous string) bool {
+		return previous != "" && apiVersion.HasRelease(previous) && !apiVersion.HasRelease(release)
+	})
+}
+
+func getChangedAPIVersions(tl *timeline.Timeline, release string, changed func(apiVersion *timeline.APIVersion, previous string) bool) []string {
+	previous := ""
+	for i, rel := range tl.Releases {
+		if rel.Version == release && i > 0 {
+			previous = tl.Releases[i-1].Version
+		}
+	}
+
+	result := []string{}
+	for _, apiGroup := range tl.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			if changed(&apiVersion, previous) {
+				result = append(result, groupVersion(apiGroup.Name, apiVersion.Version))
+			}
+		}
+	}
+
+	return result
+}
+
+type featureGatedAPI struct {
+	API  string // e.g. "resource.k8s.io/v1alpha2" or "certificates.k8s.io/v1alpha1 ClusterTrustBundle"
+	Gate string
+}
+
+// getFeatureGatedAPIs returns all API versions and resources that are only
+// served in the given release if a feature gate is enabled.
+func getFeatureGatedAPIs(tl *timeline.Timeline, release string) []featureGatedAPI {
+	result := []featureGatedAPI{}
+
+	for _, apiGroup := range tl.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			gv := groupVersion(apiGroup.Name, apiVersion.Version)
+
+			versionGate := apiVersion.FeatureGate(release)
+			if versionGate != "" {
+				result = append(result, featureGatedAPI{API: gv, Gate: versionGate})
+			}
+
+			for _, apiResource := range apiVersion.Resources {
+				if gate := apiResource.FeatureGate(release); gate != "" && gate != versionGate {
+					result = append(result, featureGatedAPI{API: fmt.Sprintf("%s %s", gv, apiResource.Kind), Gate: gate})
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func groupVersion(group, version string) string {
+	if group == "core" {
+		return version
+	}
+
+	return fmt.Sprintf("%s/%s", group, version)
+}
+
 func getROIViewRange(tl *timeline.Timeline, needle string, num int) []string {
 	var subset []timeline.ReleaseMetadata
 
@@ -79,6 +377,14 @@ func getReleaseHeaderClassNames(tl *timeline.Timeline, release *timeline.Release
 		classes = append(classes, "release-archived")
 	}
 
+	if release.Projected {
+		classes = append(classes, "release-projected")
+	}
+
+	if release.PreRelease {
+		classes = append(classes, "release-prerelease")
+	}
+
 	if release.Supported {
 		classes = append(classes, "release-supported")
 
@@ -159,11 +465,15 @@ func getAPIGroupReleaseClass(tl *timeline.Timeline, apiGroup *timeline.APIGroup,
 	preferred := apiGroup.PreferredVersion(release.Version)
 	if preferred == "" {
 		classes = append(classes, "a10y-missing")
+
+		if apiGroup.IsDisappearedIn(release.Version) {
+			classes = append(classes, "disappeared")
+		}
 	} else {
 		classes = append(classes, "a10y-exists")
 
 		v, _ := version.ParseAPIVersion(preferred)
-		if v.Prerelease() {
+		if !v.IsGA() {
 			classes = append(classes, "maturity-prerelease")
 		} else {
 			classes = append(classes, "maturity-stable")
@@ -224,9 +534,21 @@ func getAPIVersionReleaseClass(tl *timeline.Timeline, apiGroup *timeline.APIGrou
 
 	if !apiVersion.HasRelease(release.Version) {
 		classes = append(classes, "a10y-missing")
+
+		if apiVersion.IsDisappearedIn(release.Version) {
+			classes = append(classes, "disappeared")
+		}
 	} else {
 		classes = append(classes, "a10y-exists")
 
+		if !apiVersion.DefaultEnabledIn(release.Version) {
+			classes = append(classes, "default-disabled")
+		}
+
+		if apiVersion.IsDeprecatedIn(release.Version) {
+			classes = append(classes, "deprecated")
+		}
+
 		// is this version the preferred version in this release?
 
 		if apiGroup.PreferredVersions[release.Version] == apiVersion.Version {
@@ -300,6 +622,18 @@ func getAPIResourceReleaseClass(tl *timeline.Timeline, apiGroup *timeline.APIGro
 	} else {
 		classes = append(classes, "a10y-exists", "scope-"+strings.ToLower(apiResource.Scopes[release.Version]))
 
+		if apiResource.ConformanceCovered(release.Version) {
+			classes = append(classes, "conformance-covered")
+		}
+
+		if !apiResource.DefaultEnabledIn(release.Version) {
+			classes = append(classes, "default-disabled")
+		}
+
+		if apiResource.IsDeprecatedIn(release.Version) {
+			classes = append(classes, "deprecated")
+		}
+
 		// is this version the preferred version in this release?
 
 		if apiGroup.PreferredVersions[release.Version] == apiVersion.Version {
@@ -333,6 +667,17 @@ func getAPIResourceReleaseClass(tl *timeline.Timeline, apiGroup *timeline.APIGro
 	return strings.Join(classes, " ")
 }
 
+// getAPIResourceReleaseDescription returns the description of a resource in
+// the given release, but only if it differs from the most recent one (which
+// is shown by default), to not bloat the page.
+func getAPIResourceReleaseDescription(apiResource *timeline.APIResource, release *timeline.ReleaseMetadata) string {
+	if description := apiResource.DescriptionIn(release.Version); description != apiResource.Description {
+		return description
+	}
+
+	return ""
+}
+
 func getAPIResourceReleaseContent(tl *timeline.Timeline, apiGroup *timeline.APIGroup, apiVersion *timeline.APIVersion, apiResource *timeline.APIResource, release *timeline.ReleaseMetadata) template.HTML {
 	if !apiResource.HasRelease(release.Version) {
 		return template.HTML("&nbsp;")
@@ -351,6 +696,12 @@ func getAPIResourceReleaseContent(tl *timeline.Timeline, apiGroup *timeline.APIG
 	}
 }
 
+// ResourceAnchor returns the ID of the row of an API resource on the
+// timeline, which is the target of its permalinks.
+func ResourceAnchor(group, version, kind string) string {
+	return fmt.Sprintf("api-%s-%s-%s", group, version, kind)
+}
+
 // /apidocs/1.25/#storageclass-v1-storage-k8s-io
 
 func getResourceDocumentationLink(tl *timeline.Timeline, apiGroup *timeline.APIGroup, apiVersion *timeline.APIVersion, apiResource *timeline.APIResource) string {
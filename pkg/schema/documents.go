@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package schema
+
+import (
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+// Document is a published schema.
+type Document struct {
+	// Filename is the name of the schema file, e.g. "timeline.schema.json".
+	Filename string
+	Title    string
+	Type     any
+}
+
+// Documents lists all data formats for which schemas are published.
+var Documents = []Document{
+	{Filename: "timeline.schema.json", Title: "Kubernetes API Timeline", Type: timeline.Timeline{}},
+	{Filename: "api.schema.json", Title: "Kubernetes Release API (api.json)", Type: types.KubernetesAPI{}},
+	{Filename: "clients.schema.json", Title: "Client Library Versions (clients.json)", Type: types.ClientVersions{}},
+	{Filename: "runtimes.schema.json", Title: "Container Runtime Versions (runtimes.json)", Type: types.RuntimeVersions{}},
+	{Filename: "specs.schema.json", Title: "Specification Versions (specs.json)", Type: types.SpecVersions{}},
+	{Filename: "conformance.schema.json", Title: "Conformance Coverage (conformance.json)", Type: types.ConformanceCoverage{}},
+	{Filename: "patches.schema.json", Title: "Patch Releases (patches.json)", Type: []types.PatchRelease{}},
+	{Filename: "providers.schema.json", Title: "Managed Provider Support (providers.json)", Type: []types.ProviderSupport{}},
+	{Filename: "advisories.schema.json", Title: "Security Advisories (advisories.json)", Type: []types.Advisory{}},
+	{Filename: "releasenotes.schema.json", Title: "Release Notes (releasenotes.json)", Type: []types.ReleaseNote{}},
+	{Filename: "annotations.schema.json", Title: "Resource Annotations", Type: []types.Annotation{}},
+	{Filename: "deprecations.schema.json", Title: "Deprecation Notices (deprecations.yaml)", Type: []types.Deprecation{}},
+	{Filename: "featuregates.schema.json", Title: "Feature Gates (featuregates.yaml)", Type: []types.FeatureGate{}},
+	{Filename: "enablement.schema.json", Title: "Default Enablement (enablement.yaml)", Type: []types.DefaultEnablement{}},
+	{Filename: "keps.schema.json", Title: "Enhancement Proposals (keps.yaml)", Type: []types.KEP{}},
+}
+
+// Schema returns the schema for the document, with baseURL (e.g.
+// "https://kube-api.ninja/schemas/") used to construct its $id.
+func (d Document) Schema(baseURL string) *Schema {
+	return For(d.Type, baseURL+d.Filename, d.Title)
+}
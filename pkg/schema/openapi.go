@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package schema
+
+import (
+	"reflect"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+// OpenAPI 3.1 uses JSON Schema 2020-12, so the schemas generated by this
+// package can be used as-is.
+const openAPIVersion = "3.1.0"
+
+// OpenAPIDocument is a (small) subset of an OpenAPI 3.1 document, just
+// enough to describe the REST API served by "render -listen".
+type OpenAPIDocument struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       OpenAPIInfo                     `json:"info"`
+	Servers    []OpenAPIServer                 `json:"servers"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components OpenAPIComponents               `json:"components"`
+}
+
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type OpenAPIServer struct {
+	URL string `json:"url"`
+}
+
+type OpenAPIComponents struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Operation is a single HTTP method on a path.
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// OpenAPI returns the OpenAPI document describing the REST API, whose
+// endpoints are served below serverURL (e.g. "/api/v1").
+func OpenAPI(serverURL string) *OpenAPIDocument {
+	ref := func(name string) *Schema {
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+
+	ok := func(description string, s *Schema) map[string]Response {
+		return map[string]Response{
+			"200": jsonResponse(description, s),
+		}
+	}
+
+	notFound := func(description string, s *Schema) map[string]Response {
+		responses := ok(description, s)
+		responses["404"] = jsonResponse("unknown "+description, ref("Error"))
+
+		return responses
+	}
+
+	pathParameter := func(name string, description string) Parameter {
+		return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: &Schema{Type: "string"}}
+	}
+
+	queryParameter := func(name string, description string) Parameter {
+		return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string"}}
+	}
+
+	return &OpenAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: OpenAPIInfo{
+			Title:       "kube-api.ninja",
+			Description: "The availability of the Kubernetes APIs across releases.",
+			Version:     "v1",
+		},
+		Servers: []OpenAPIServer{{URL: serverURL}},
+		Paths: map[string]map[string]Operation{
+			"/timeline": {
+				"get": {
+					OperationID: "getTimeline",
+					Summary:     "Get the entire timeline, optionally filtered like a personalized view.",
+					Parameters: []Parameter{
+						queryParameter("view", "An encoded personalized view, as used by the website."),
+						queryParameter("groups", "Comma-separated list of API groups to include."),
+						queryParameter("pin", "Comma-separated list of API groups to show first."),
+						queryParameter("stable", "Set to \"true\" to only include stable API versions."),
+					},
+					Responses: map[string]Response{
+						"200": jsonResponse("the timeline", ref("Timeline")),
+						"400": jsonResponse("invalid view", ref("Error")),
+					},
+				},
+			},
+			"/releases": {
+				"get": {
+					OperationID: "listReleases",
+					Summary:     "List the metadata of all releases.",
+					Responses:   ok("all releases", &Schema{Type: "array", Items: ref("ReleaseMetadata")}),
+				},
+			},
+			"/releases/{release}": {
+				"get": {
+					OperationID: "getRelease",
+					Summary:     "Get the metadata of a single release.",
+					Parameters:  []Parameter{pathParameter("release", "The minor release, like \"1.29\".")},
+					Responses:   notFound("release", ref("ReleaseMetadata")),
+				},
+			},
+			"/groups": {
+				"get": {
+					OperationID: "listGroups",
+					Summary:     "List the names of all API groups.",
+					Responses:   ok("all API group names", &Schema{Type: "array", Items: &Schema{Type: "string"}}),
+				},
+			},
+			"/groups/{group}": {
+				"get": {
+					OperationID: "getGroup",
+					Summary:     "Get a single API group, including all its versions and resources.",
+					Parameters:  []Parameter{pathParameter("group", "The API group, like \"apps\" or \"core\".")},
+					Responses:   notFound("API group", ref("APIGroup")),
+				},
+			},
+			"/resources/{group}/{resource}": {
+				"get": {
+					OperationID: "getResource",
+					Summary:     "Get all versions of a resource in all releases (only if the server uses a resource index).",
+					Parameters: []Parameter{
+						pathParameter("group", "The API group, like \"apps\" or \"core\"."),
+						pathParameter("resource", "The kind (like \"Deployment\") or plural name (like \"deployments\")."),
+					},
+					Responses: notFound("resource", &Schema{Type: "array", Items: ref("IndexedResource")}),
+				},
+			},
+		},
+		Components: OpenAPIComponents{
+			Schemas: map[string]*Schema{
+				"Timeline":        forType(reflect.TypeOf(timeline.Timeline{})),
+				"ReleaseMetadata": forType(reflect.TypeOf(timeline.ReleaseMetadata{})),
+				"APIGroup":        forType(reflect.TypeOf(timeline.APIGroup{})),
+				"IndexedResource": forType(reflect.TypeOf(database.IndexedResource{})),
+				"Error": {
+					Type:                 "object",
+					Properties:           map[string]*Schema{"error": {Type: "string"}},
+					Required:             []string{"error"},
+					AdditionalProperties: false,
+				},
+			},
+		},
+	}
+}
+
+func jsonResponse(description string, s *Schema) Response {
+	return Response{
+		Description: description,
+		Content: map[string]MediaType{
+			"application/json": {Schema: s},
+		},
+	}
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package schema
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestOpenAPIReferences(t *testing.T) {
+	doc := OpenAPI("/api/v1")
+
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to encode document: %v", err)
+	}
+
+	refs := regexp.MustCompile(`"\$ref":"([^"]+)"`).FindAllStringSubmatch(string(encoded), -1)
+	if len(refs) == 0 {
+		t.Fatal("Expected the document to reference component schemas, but found none.")
+	}
+
+	for _, ref := range refs {
+		name, found := strings.CutPrefix(ref[1], "#/components/schemas/")
+		if !found {
+			t.Errorf("Expected local component reference, got %q.", ref[1])
+			continue
+		}
+
+		if _, exists := doc.Components.Schemas[name]; !exists {
+			t.Errorf("Reference %q points to unknown component schema.", ref[1])
+		}
+	}
+}
+
+func TestOpenAPIOperations(t *testing.T) {
+	doc := OpenAPI("/api/v1")
+	operationIDs := map[string]bool{}
+
+	for path, methods := range doc.Paths {
+		for method, operation := range methods {
+			if operationIDs[operation.OperationID] {
+				t.Errorf("Operation ID %q of %s %s is not unique.", operation.OperationID, method, path)
+			}
+			operationIDs[operation.OperationID] = true
+
+			for _, param := range operation.Parameters {
+				if param.In == "path" && !strings.Contains(path, "{"+param.Name+"}") {
+					t.Errorf("Path parameter %q of %s %s is not part of the path.", param.Name, method, path)
+				}
+			}
+
+			if _, exists := operation.Responses["200"]; !exists {
+				t.Errorf("Expected %s %s to have a 200 response.", method, path)
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package schema generates JSON Schema documents for the data formats
+// published by kube-api.ninja, based on the Go types that produce them.
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+const draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a (small) subset of JSON Schema, just enough to describe the
+// Go types used in this repository.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// For returns the schema document for the type of v.
+func For(v any, id string, title string) *Schema {
+	s := forType(reflect.TypeOf(v))
+	s.Schema = draft
+	s.ID = id
+	s.Title = title
+
+	return s
+}
+
+func forType(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+	}
+
+	s := &Schema{}
+
+	switch {
+	case t == timeType:
+		s.Type = "string"
+		s.Format = "date-time"
+
+	case t.Kind() == reflect.Struct:
+		s.Type = "object"
+		s.Properties = map[string]*Schema{}
+		s.AdditionalProperties = false
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name, omitempty, skip := jsonName(field)
+			if skip {
+				continue
+			}
+
+			s.Properties[name] = forType(field.Type)
+			if !omitempty {
+				s.Required = append(s.Required, name)
+			}
+		}
+
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		s.Type = "array"
+		s.Items = forType(t.Elem())
+		// nil slices are encoded as null
+		nullable = true
+
+	case t.Kind() == reflect.Map:
+		s.Type = "object"
+		s.AdditionalProperties = forType(t.Elem())
+		nullable = true
+
+	case t.Kind() == reflect.String:
+		s.Type = "string"
+
+	case t.Kind() == reflect.Bool:
+		s.Type = "boolean"
+
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		s.Type = "integer"
+
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		s.Type = "number"
+	}
+
+	if nullable && s.Type != nil {
+		s.Type = []string{s.Type.(string), "null"}
+	}
+
+	return s
+}
+
+func jsonName(field reflect.StructField) (name string, omitempty bool, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+
+	return name, omitempty, false
+}
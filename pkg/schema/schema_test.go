@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type testDocument struct {
+	Name     string `json:"name"`
+	Optional string `json:"optional,omitempty"`
+	Ignored  string `json:"-"`
+	Untagged int
+	Created  time.Time         `json:"created"`
+	Labels   map[string]string `json:"labels"`
+	Children []*testChild      `json:"children"`
+}
+
+type testChild struct {
+	Enabled bool `json:"enabled"`
+}
+
+func TestFor(t *testing.T) {
+	s := For(testDocument{}, "https://example.com/test.json", "Test")
+
+	encoded, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Failed to encode schema: %v", err)
+	}
+
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","$id":"https://example.com/test.json","title":"Test","type":"object",` +
+		`"properties":{` +
+		`"Untagged":{"type":"integer"},` +
+		`"children":{"type":["array","null"],"items":{"type":["object","null"],"properties":{"enabled":{"type":"boolean"}},"required":["enabled"],"additionalProperties":false}},` +
+		`"created":{"type":"string","format":"date-time"},` +
+		`"labels":{"type":["object","null"],"additionalProperties":{"type":"string"}},` +
+		`"name":{"type":"string"},` +
+		`"optional":{"type":"string"}` +
+		`},"required":["name","Untagged","created","labels","children"],"additionalProperties":false}`
+
+	if string(encoded) != expected {
+		t.Errorf("Expected\n%s\nbut got\n%s", expected, string(encoded))
+	}
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package search creates a compact index of all API resources, so that
+// frontends and external tools can look resources up without loading the
+// entire timeline.
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Index contains one entry per resource kind and API group.
+type Index struct {
+	Entries []Entry `json:"entries"`
+}
+
+type Entry struct {
+	Kind       string         `json:"kind"`
+	Plural     string         `json:"plural"`
+	ShortNames []string       `json:"shortNames,omitempty"`
+	Group      string         `json:"group"`
+	Versions   []EntryVersion `json:"versions"`
+}
+
+// EntryVersion lists the releases in which an API version offered the
+// resource.
+type EntryVersion struct {
+	Version  string   `json:"version"`
+	Releases []string `json:"releases"`
+}
+
+// NewIndex creates the search index for the timeline. Projected releases are
+// left out, as they do not contain any new information about resources.
+func NewIndex(tl *timeline.Timeline) *Index {
+	projected := sets.New[string]()
+	for _, release := range tl.Releases {
+		if release.Projected {
+			projected.Insert(release.Version)
+		}
+	}
+
+	index := &Index{
+		Entries: []Entry{},
+	}
+
+	for _, apiGroup := range tl.APIGroups {
+		entries := map[string]*Entry{}
+		kinds := []string{}
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				releases := sets.List(sets.New(apiResource.Releases...).Difference(projected))
+				if len(releases) == 0 {
+					continue
+				}
+
+				entry, exists := entries[apiResource.Kind]
+				if !exists {
+					entry = &Entry{
+						Kind:     apiResource.Kind,
+						Plural:   apiResource.Plural,
+						Group:    apiGroup.Name,
+						Versions: []EntryVersion{},
+					}
+
+					entries[apiResource.Kind] = entry
+					kinds = append(kinds, apiResource.Kind)
+				}
+
+				entry.Versions = append(entry.Versions, EntryVersion{
+					Version:  apiVersion.Version,
+					Releases: sortReleases(releases, tl),
+				})
+
+				shortNames := sets.New(entry.ShortNames...)
+				for _, names := range apiResource.ShortNames {
+					shortNames.Insert(names...)
+				}
+				entry.ShortNames = sets.List(shortNames)
+			}
+		}
+
+		for _, kind := range kinds {
+			index.Entries = append(index.Entries, *entries[kind])
+		}
+	}
+
+	sort.SliceStable(index.Entries, func(i, j int) bool {
+		return strings.ToLower(index.Entries[i].Kind) < strings.ToLower(index.Entries[j].Kind)
+	})
+
+	return index
+}
+
+// Lookup returns all entries whose kind, plural or short names match the
+// given term (case-insensitive), like "deploy" or "Deployment".
+func (i *Index) Lookup(term string) []Entry {
+	term = strings.ToLower(term)
+
+	result := []Entry{}
+	for _, entry := range i.Entries {
+		if strings.ToLower(entry.Kind) == term || entry.Plural == term || contains(entry.ShortNames, term) {
+			result = append(result, entry)
+		}
+	}
+
+	return result
+}
+
+// sortReleases sorts releases in the order of the timeline, as sorting them
+// as strings would put "1.10" before "1.9".
+func sortReleases(releases []string, tl *timeline.Timeline) []string {
+	present := sets.New(releases...)
+
+	result := []string{}
+	for _, release := range tl.Releases {
+		if present.Has(release.Version) {
+			result = append(result, release.Version)
+		}
+	}
+
+	return result
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+func TestNewIndex(t *testing.T) {
+	tl := &timeline.Timeline{
+		Releases: []timeline.ReleaseMetadata{
+			{Version: "1.9"},
+			{Version: "1.10"},
+			{Version: "1.11", Projected: true},
+		},
+		APIGroups: []timeline.APIGroup{
+			{
+				Name: "apps",
+				APIVersions: []timeline.APIVersion{
+					{
+						Version: "v1",
+						Resources: []timeline.APIResource{
+							{
+								Kind:       "Deployment",
+								Plural:     "deployments",
+								Releases:   []string{"1.10", "1.9", "1.11"},
+								ShortNames: map[string][]string{"1.10": {"deploy"}},
+							},
+						},
+					},
+					{
+						Version: "v1beta1",
+						Resources: []timeline.APIResource{
+							{
+								Kind:     "Deployment",
+								Plural:   "deployments",
+								Releases: []string{"1.9"},
+							},
+							{
+								// only available in projected releases
+								Kind:     "Future",
+								Plural:   "futures",
+								Releases: []string{"1.11"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	index := NewIndex(tl)
+
+	expected := []Entry{{
+		Kind:       "Deployment",
+		Plural:     "deployments",
+		ShortNames: []string{"deploy"},
+		Group:      "apps",
+		Versions: []EntryVersion{
+			{Version: "v1", Releases: []string{"1.9", "1.10"}},
+			{Version: "v1beta1", Releases: []string{"1.9"}},
+		},
+	}}
+
+	if !reflect.DeepEqual(index.Entries, expected) {
+		t.Fatalf("Expected %+v, got %+v.", expected, index.Entries)
+	}
+
+	for _, term := range []string{"deploy", "Deployments", "deployment"} {
+		if found := index.Lookup(term); len(found) != 1 {
+			t.Errorf("Expected to find %q, got %v.", term, found)
+		}
+	}
+
+	if found := index.Lookup("futures"); len(found) != 0 {
+		t.Errorf("Expected no results for projected resources, got %v.", found)
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package stats
+
+import (
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+const daysPerYear = 365.25
+
+// Churn describes how volatile each API group has been.
+type Churn struct {
+	// From and Until describe the observed period, i.e. the release dates of
+	// the first and latest released release.
+	From   time.Time    `json:"from"`
+	Until  time.Time    `json:"until"`
+	Groups []GroupChurn `json:"groups"`
+}
+
+type GroupChurn struct {
+	Name string `json:"name"`
+	// API versions that were introduced or removed during the observed period
+	// (versions that already existed in the first release do not count as
+	// introduced).
+	VersionsIntroduced int     `json:"versionsIntroduced"`
+	VersionsRemoved    int     `json:"versionsRemoved"`
+	IntroducedPerYear  float64 `json:"introducedPerYear"`
+	RemovedPerYear     float64 `json:"removedPerYear"`
+	// AverageDaysInBeta is the average number of days beta versions were
+	// served, or nil if no beta version was introduced in the observed period.
+	AverageDaysInBeta *int `json:"averageDaysInBeta,omitempty"`
+}
+
+// NewChurn computes the churn metrics for all API groups, based on the
+// released releases of the timeline.
+func NewChurn(tl *timeline.Timeline) *Churn {
+	churn := &Churn{
+		Groups: []GroupChurn{},
+	}
+
+	released := []timeline.ReleaseMetadata{}
+	for _, release := range tl.Releases {
+		if release.Released {
+			released = append(released, release)
+		}
+	}
+
+	if len(released) < 2 {
+		return churn
+	}
+
+	churn.From = released[0].ReleaseDate
+	churn.Until = released[len(released)-1].ReleaseDate
+	years := churn.Until.Sub(churn.From).Hours() / 24 / daysPerYear
+
+	for _, apiGroup := range tl.APIGroups {
+		group := GroupChurn{Name: apiGroup.Name}
+
+		betaDays := 0
+		betaVersions := 0
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			first, last := servedRange(released, &apiVersion)
+			if first < 0 {
+				continue
+			}
+
+			introduced := first > 0
+			removed := last < len(released)-1
+
+			if introduced {
+				group.VersionsIntroduced++
+			}
+
+			if removed {
+				group.VersionsRemoved++
+			}
+
+			parsed, err := version.ParseAPIVersion(apiVersion.Version)
+			if err != nil || !parsed.IsBeta() || !introduced {
+				continue
+			}
+
+			// a version is gone with the first release that does not serve it anymore
+			end := released[last].ReleaseDate
+			if removed {
+				end = released[last+1].ReleaseDate
+			}
+
+			betaDays += int(end.Sub(released[first].ReleaseDate).Hours() / 24)
+			betaVersions++
+		}
+
+		if years > 0 {
+			group.IntroducedPerYear = float64(group.VersionsIntroduced) / years
+			group.RemovedPerYear = float64(group.VersionsRemoved) / years
+		}
+
+		if betaVersions > 0 {
+			avg := betaDays / betaVersions
+			group.AverageDaysInBeta = &avg
+		}
+
+		churn.Groups = append(churn.Groups, group)
+	}
+
+	return churn
+}
+
+// servedRange returns the indices of the first and last release in which the
+// API version was served, or -1 if it was never served.
+func servedRange(releases []timeline.ReleaseMetadata, apiVersion *timeline.APIVersion) (int, int) {
+	first, last := -1, -1
+
+	for i, release := range releases {
+		if apiVersion.HasRelease(release.Version) {
+			if first < 0 {
+				first = i
+			}
+
+			last = i
+		}
+	}
+
+	return first, last
+}
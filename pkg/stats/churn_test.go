@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+func TestNewChurn(t *testing.T) {
+	tl := &timeline.Timeline{
+		Releases: []timeline.ReleaseMetadata{
+			{Version: "1.20", Released: true, ReleaseDate: date("2021-01-01")},
+			{Version: "1.21", Released: true, ReleaseDate: date("2021-07-01")},
+			{Version: "1.22", Released: true, ReleaseDate: date("2022-01-01")},
+			{Version: "1.23", Released: true, ReleaseDate: date("2023-01-01")},
+			{Version: "1.24", ReleaseDate: date("2023-07-01")},
+		},
+		APIGroups: []timeline.APIGroup{{
+			Name: "batch",
+			APIVersions: []timeline.APIVersion{
+				// existed before the observed period
+				{Version: "v1", Releases: []string{"1.20", "1.21", "1.22", "1.23", "1.24"}},
+				// removed in 1.23
+				{Version: "v2beta1", Releases: []string{"1.21", "1.22"}},
+				// introduced and still served
+				{Version: "v2beta2", Releases: []string{"1.22", "1.23", "1.24"}},
+				// unreleased releases are ignored
+				{Version: "v3alpha1", Releases: []string{"1.24"}},
+			},
+		}},
+	}
+
+	churn := NewChurn(tl)
+
+	if !churn.From.Equal(date("2021-01-01")) || !churn.Until.Equal(date("2023-01-01")) {
+		t.Errorf("Expected period 2021-01-01 to 2023-01-01, got %v to %v.", churn.From, churn.Until)
+	}
+
+	if len(churn.Groups) != 1 {
+		t.Fatalf("Expected 1 group, got %d.", len(churn.Groups))
+	}
+
+	group := churn.Groups[0]
+
+	if group.VersionsIntroduced != 2 || group.VersionsRemoved != 1 {
+		t.Errorf("Expected 2 introduced and 1 removed version, got %d and %d.", group.VersionsIntroduced, group.VersionsRemoved)
+	}
+
+	if group.IntroducedPerYear < 0.99 || group.IntroducedPerYear > 1.01 {
+		t.Errorf("Expected about 1 introduced version per year, got %f.", group.IntroducedPerYear)
+	}
+
+	// v2beta1 was served for 549 days (until 1.23), v2beta2 for 365 days (until the latest release)
+	if group.AverageDaysInBeta == nil || *group.AverageDaysInBeta != 457 {
+		t.Errorf("Expected 457 days in beta on average, got %v.", group.AverageDaysInBeta)
+	}
+}
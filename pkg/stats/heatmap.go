@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package stats computes aggregated statistics about a timeline.
+package stats
+
+import (
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+// Heatmap contains the number of API resources that were added to or removed
+// from each API group in each release.
+type Heatmap struct {
+	Releases []string       `json:"releases"`
+	Groups   []HeatmapGroup `json:"groups"`
+	// Max is the highest number of changes in a single cell.
+	Max int `json:"max"`
+}
+
+type HeatmapGroup struct {
+	Name  string        `json:"name"`
+	Cells []HeatmapCell `json:"cells"`
+}
+
+type HeatmapCell struct {
+	Release string `json:"release"`
+	Added   int    `json:"added"`
+	Removed int    `json:"removed"`
+}
+
+func (c HeatmapCell) Total() int {
+	return c.Added + c.Removed
+}
+
+// NewHeatmap computes the heatmap for the timeline. The first release is
+// skipped because there is nothing to compare it against; projected releases
+// are skipped because their changes are pure speculation.
+func NewHeatmap(tl *timeline.Timeline) *Heatmap {
+	heatmap := &Heatmap{
+		Releases: []string{},
+		Groups:   []HeatmapGroup{},
+	}
+
+	releases := []string{}
+	for _, release := range tl.Releases {
+		if !release.Projected {
+			releases = append(releases, release.Version)
+		}
+	}
+
+	if len(releases) < 2 {
+		return heatmap
+	}
+
+	heatmap.Releases = releases[1:]
+
+	for _, apiGroup := range tl.APIGroups {
+		group := HeatmapGroup{
+			Name:  apiGroup.Name,
+			Cells: []HeatmapCell{},
+		}
+
+		for i, release := range heatmap.Releases {
+			// heatmap releases are shifted by one
+			previous := releases[i]
+			cell := HeatmapCell{Release: release}
+
+			for _, apiVersion := range apiGroup.APIVersions {
+				for _, apiResource := range apiVersion.Resources {
+					wasAvailable := apiResource.HasRelease(previous)
+					isAvailable := apiResource.HasRelease(release)
+
+					switch {
+					case isAvailable && !wasAvailable:
+						cell.Added++
+					case wasAvailable && !isAvailable:
+						cell.Removed++
+					}
+				}
+			}
+
+			heatmap.Max = max(heatmap.Max, cell.Total())
+			group.Cells = append(group.Cells, cell)
+		}
+
+		heatmap.Groups = append(heatmap.Groups, group)
+	}
+
+	return heatmap
+}
+
+// Level returns the intensity (0-4) of a number of changes, relative to the
+// busiest cell in the heatmap.
+func (h *Heatmap) Level(changes int) int {
+	if changes == 0 || h.Max == 0 {
+		return 0
+	}
+
+	// any change should be visible, so levels start at 1
+	return 1 + (changes-1)*4/h.Max
+}
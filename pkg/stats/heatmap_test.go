@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package stats
+
+import (
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+func TestNewHeatmap(t *testing.T) {
+	tl := &timeline.Timeline{
+		Releases: []timeline.ReleaseMetadata{
+			{Version: "1.25"},
+			{Version: "1.26"},
+			{Version: "1.27"},
+			{Version: "1.28", Projected: true},
+		},
+		APIGroups: []timeline.APIGroup{{
+			Name: "batch",
+			APIVersions: []timeline.APIVersion{
+				{
+					Version: "v1",
+					Resources: []timeline.APIResource{
+						{Kind: "Job", Releases: []string{"1.25", "1.26", "1.27", "1.28"}},
+						{Kind: "CronJob", Releases: []string{"1.26", "1.27", "1.28"}},
+					},
+				},
+				{
+					Version: "v1beta1",
+					Resources: []timeline.APIResource{
+						{Kind: "CronJob", Releases: []string{"1.25"}},
+					},
+				},
+			},
+		}},
+	}
+
+	heatmap := NewHeatmap(tl)
+
+	if len(heatmap.Releases) != 2 || heatmap.Releases[0] != "1.26" || heatmap.Releases[1] != "1.27" {
+		t.Fatalf("Expected releases [1.26 1.27], got %v.", heatmap.Releases)
+	}
+
+	if len(heatmap.Groups) != 1 {
+		t.Fatalf("Expected 1 group, got %d.", len(heatmap.Groups))
+	}
+
+	expected := []HeatmapCell{
+		{Release: "1.26", Added: 1, Removed: 1},
+		{Release: "1.27"},
+	}
+
+	for i, cell := range heatmap.Groups[0].Cells {
+		if cell != expected[i] {
+			t.Errorf("Expected cell %d to be %+v, got %+v.", i, expected[i], cell)
+		}
+	}
+
+	if heatmap.Max != 2 {
+		t.Errorf("Expected max of 2, got %d.", heatmap.Max)
+	}
+}
+
+func TestHeatmapLevel(t *testing.T) {
+	heatmap := &Heatmap{Max: 8}
+
+	testcases := map[int]int{
+		0: 0,
+		1: 1,
+		2: 1,
+		3: 2,
+		8: 4,
+	}
+
+	for changes, expected := range testcases {
+		if level := heatmap.Level(changes); level != expected {
+			t.Errorf("Expected %d changes to be level %d, got %d.", changes, expected, level)
+		}
+	}
+}
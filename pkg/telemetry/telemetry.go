@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package telemetry implements the strictly opt-in usage reporting of the
+// apininja CLI. Only the command name, the Kubernetes releases it was used
+// with and the CLI version are ever sent; no file paths, cluster data or
+// other arguments.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// reporting must never noticeably slow down the CLI
+const timeout = 2 * time.Second
+
+// ErrNoEndpoint is returned when reporting is enabled without an endpoint;
+// there is no public collector, so every installation must run its own.
+var ErrNoEndpoint = errors.New("no telemetry endpoint configured")
+
+var releasePattern = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+
+type Event struct {
+	Command    string   `json:"command"`
+	Releases   []string `json:"releases,omitempty"`
+	CLIVersion string   `json:"cliVersion"`
+}
+
+// NewEvent creates an event for the given command. From the arguments, only
+// those that look like a Kubernetes minor release (e.g. "1.29") are kept,
+// everything else is discarded.
+func NewEvent(command string, args []string, cliVersion string) Event {
+	releases := []string{}
+	for _, arg := range args {
+		if releasePattern.MatchString(arg) {
+			releases = append(releases, arg)
+		}
+	}
+
+	return Event{
+		Command:    command,
+		Releases:   releases,
+		CLIVersion: cliVersion,
+	}
+}
+
+type Reporter interface {
+	Report(ctx context.Context, event Event) error
+}
+
+// NewReporter returns a reporter that sends events to the given endpoint if
+// enabled is true, and a no-op reporter otherwise.
+func NewReporter(enabled bool, endpoint string) (Reporter, error) {
+	if !enabled {
+		return noopReporter{}, nil
+	}
+
+	if endpoint == "" {
+		return nil, ErrNoEndpoint
+	}
+
+	return &httpReporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+type noopReporter struct{}
+
+func (noopReporter) Report(context.Context, Event) error {
+	return nil
+}
+
+type httpReporter struct {
+	endpoint string
+	client   *http.Client
+}
+
+func (r *httpReporter) Report(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewEvent(t *testing.T) {
+	testcases := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "releases are kept",
+			args:     []string{"1.27", "1.29"},
+			expected: []string{"1.27", "1.29"},
+		},
+		{
+			name:     "file paths are dropped",
+			args:     []string{"-data", "/home/user/data", "manifests/1.29/deployment.yaml", "./1.29", "1.29"},
+			expected: []string{"1.29"},
+		},
+		{
+			name:     "kubeconfig values are dropped",
+			args:     []string{"-kubeconfig", "/home/user/.kube/config", "--kubeconfig=prod.kubeconfig", "-context", "prod-cluster"},
+			expected: []string{},
+		},
+		{
+			name:     "flags are dropped, even with release values",
+			args:     []string{"-release=1.29", "--to=1.28", "-strict", "1.28"},
+			expected: []string{"1.28"},
+		},
+		{
+			name:     "patch releases and versions are dropped",
+			args:     []string{"1.29.1", "v1.29", "apps/v1"},
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := NewEvent("audit", tc.args, "v1.0.0")
+
+			expected := Event{Command: "audit", Releases: tc.expected, CLIVersion: "v1.0.0"}
+			if !reflect.DeepEqual(event, expected) {
+				t.Errorf("Expected %+v, got %+v.", expected, event)
+			}
+		})
+	}
+}
+
+func TestNewReporter(t *testing.T) {
+	if _, err := NewReporter(true, ""); !errors.Is(err, ErrNoEndpoint) {
+		t.Errorf("Expected ErrNoEndpoint without an endpoint, got %v.", err)
+	}
+
+	reporter, err := NewReporter(false, "")
+	if err != nil {
+		t.Fatalf("Expected disabled reporter to not need an endpoint, got %v.", err)
+	}
+
+	if _, ok := reporter.(noopReporter); !ok {
+		t.Errorf("Expected a no-op reporter, got %T.", reporter)
+	}
+
+	var received Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode event: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	reporter, err = NewReporter(true, srv.URL)
+	if err != nil {
+		t.Fatalf("Failed to create reporter: %v", err)
+	}
+
+	event := NewEvent("diff", []string{"1.28", "/tmp/manifests", "1.29"}, "v1.0.0")
+	if err := reporter.Report(context.Background(), event); err != nil {
+		t.Fatalf("Failed to report event: %v", err)
+	}
+
+	if !reflect.DeepEqual(received, event) {
+		t.Errorf("Expected %+v to be sent, got %+v.", event, received)
+	}
+}
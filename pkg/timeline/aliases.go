@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// AliasChange describes how the short names and categories of a resource
+// changed compared to the previous release that knew them.
+type AliasChange struct {
+	Release           string
+	AddedShortNames   []string
+	RemovedShortNames []string
+	AddedCategories   []string
+	RemovedCategories []string
+}
+
+// HasShortName returns true if the resource was known by the given short
+// name (like "deploy") in any release.
+func (o *APIResource) HasShortName(name string) bool {
+	return hasAlias(o.ShortNames, name)
+}
+
+// HasCategory returns true if the resource was part of the given category
+// (like "all") in any release.
+func (o *APIResource) HasCategory(category string) bool {
+	return hasAlias(o.Categories, category)
+}
+
+// HasCategoryIn returns true if the resource is part of the given category in
+// the given release.
+func (o *APIResource) HasCategoryIn(category string, release string) bool {
+	return contains(o.Categories[release], category)
+}
+
+func hasAlias(aliases map[string][]string, name string) bool {
+	for _, names := range aliases {
+		if contains(names, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func calculateAliasChanges(tl *Timeline) {
+	for i, apiGroup := range tl.APIGroups {
+		for j, apiVersion := range apiGroup.APIVersions {
+			for k, apiResource := range apiVersion.Resources {
+				tl.APIGroups[i].APIVersions[j].Resources[k].AliasChanges = aliasChanges(&apiResource, tl.Releases)
+			}
+		}
+	}
+}
+
+func aliasChanges(apiResource *APIResource, releases []ReleaseMetadata) []AliasChange {
+	var (
+		changes        []AliasChange
+		known          bool
+		lastShortNames sets.Set[string]
+		lastCategories sets.Set[string]
+	)
+
+	for _, release := range releases {
+		shortNames, hasData := apiResource.ShortNames[release.Version]
+		categories := apiResource.Categories[release.Version]
+
+		// releases without data (e.g. dumped from Swagger specs) are skipped
+		if !hasData {
+			continue
+		}
+
+		currentShortNames := sets.New(shortNames...)
+		currentCategories := sets.New(categories...)
+
+		if known {
+			change := AliasChange{
+				Release:           release.Version,
+				AddedShortNames:   sets.List(currentShortNames.Difference(lastShortNames)),
+				RemovedShortNames: sets.List(lastShortNames.Difference(currentShortNames)),
+				AddedCategories:   sets.List(currentCategories.Difference(lastCategories)),
+				RemovedCategories: sets.List(lastCategories.Difference(currentCategories)),
+			}
+
+			if len(change.AddedShortNames)+len(change.RemovedShortNames)+len(change.AddedCategories)+len(change.RemovedCategories) > 0 {
+				changes = append(changes, change)
+			}
+		}
+
+		known = true
+		lastShortNames = currentShortNames
+		lastCategories = currentCategories
+	}
+
+	return changes
+}
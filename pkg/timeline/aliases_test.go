@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAliasChanges(t *testing.T) {
+	releases := []ReleaseMetadata{
+		{Version: "1.20"},
+		{Version: "1.21"},
+		{Version: "1.22"},
+		{Version: "1.23"},
+		{Version: "1.24"},
+	}
+
+	resource := &APIResource{
+		Kind: "CronJob",
+		// 1.21 was dumped from a Swagger spec and has no aliases
+		ShortNames: map[string][]string{
+			"1.20": {},
+			"1.22": {"cj"},
+			"1.23": {"cj"},
+			"1.24": {"cj"},
+		},
+		Categories: map[string][]string{
+			"1.20": {"all"},
+			"1.22": {"all"},
+			"1.23": {"all", "batch"},
+			"1.24": {},
+		},
+	}
+
+	expected := []AliasChange{
+		{Release: "1.22", AddedShortNames: []string{"cj"}, RemovedShortNames: []string{}, AddedCategories: []string{}, RemovedCategories: []string{}},
+		{Release: "1.23", AddedShortNames: []string{}, RemovedShortNames: []string{}, AddedCategories: []string{"batch"}, RemovedCategories: []string{}},
+		{Release: "1.24", AddedShortNames: []string{}, RemovedShortNames: []string{}, AddedCategories: []string{}, RemovedCategories: []string{"all", "batch"}},
+	}
+
+	if changes := aliasChanges(resource, releases); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected\n%+v\nbut got\n%+v", expected, changes)
+	}
+
+	if !resource.HasShortName("cj") || resource.HasShortName("cron") {
+		t.Error("Expected CronJob to be known as \"cj\" only.")
+	}
+
+	if !resource.HasCategoryIn("all", "1.23") || resource.HasCategoryIn("all", "1.24") {
+		t.Error("Expected CronJob to be in category \"all\" in 1.23, but not in 1.24.")
+	}
+}
@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/manifest"
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+type AuditStatus string
+
+const (
+	// AuditRemoved means the API was served by earlier releases, but not
+	// anymore by the target release, or was removed in it according to the
+	// curated deprecation data.
+	AuditRemoved AuditStatus = "removed"
+	// AuditUnavailable means the API is only served by later releases.
+	AuditUnavailable AuditStatus = "unavailable"
+	// AuditDeprecated means the API is served by the target release, but
+	// is deprecated or removed in a later release.
+	AuditDeprecated AuditStatus = "deprecated"
+	// AuditSuperseded means the API is a prerelease version, while a stable
+	// version is served by the target release.
+	AuditSuperseded AuditStatus = "superseded"
+)
+
+// AuditReport is the result of checking manifests against a target release.
+type AuditReport struct {
+	Target   string
+	Findings []AuditFinding
+
+	// Unknown lists all objects whose API is not part of Kubernetes itself,
+	// like custom resources, which cannot be checked.
+	Unknown []manifest.Object
+}
+
+// AuditFinding is an object that uses a problematic API.
+type AuditFinding struct {
+	Object manifest.Object
+	Status AuditStatus
+	// Release is the release in which the API was removed, becomes
+	// available or will be removed, depending on the status. For deprecated
+	// APIs without a known removal, it is empty.
+	Release string
+	// Alternative is the most mature API version in the same API group that
+	// serves the kind in the target release (if any).
+	Alternative string
+}
+
+func (f AuditFinding) String() string {
+	var msg string
+
+	switch f.Status {
+	case AuditRemoved:
+		msg = fmt.Sprintf("removed in %s", f.Release)
+	case AuditUnavailable:
+		msg = "not available yet"
+		if f.Release != "" {
+			msg = fmt.Sprintf("not available before %s", f.Release)
+		}
+	case AuditDeprecated:
+		msg = "deprecated"
+		if f.Release != "" {
+			msg = fmt.Sprintf("deprecated, removed in %s", f.Release)
+		}
+	case AuditSuperseded:
+		msg = "prerelease version, a stable version is available"
+	}
+
+	if f.Alternative != "" && f.Alternative != f.Object.Version() {
+		msg += fmt.Sprintf(", use %s/%s instead", f.Object.Group(), f.Alternative)
+	}
+
+	return msg
+}
+
+// Failed returns true if the manifests cannot be applied to the target
+// release as-is.
+func (r *AuditReport) Failed() bool {
+	for _, finding := range r.Findings {
+		if finding.Status == AuditRemoved || finding.Status == AuditUnavailable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Audit checks which of the given objects use APIs that are not (or not
+// anymore) served by the target release, or that will be removed later.
+func (o *Timeline) Audit(objects []manifest.Object, target string) (*AuditReport, error) {
+	targetIdx := o.releaseIndex(target)
+	if targetIdx < 0 {
+		return nil, fmt.Errorf("%w %q", ErrUnknownRelease, target)
+	}
+
+	report := &AuditReport{
+		Target:   target,
+		Findings: []AuditFinding{},
+		Unknown:  []manifest.Object{},
+	}
+
+	for _, object := range objects {
+		apiGroup, apiResource := o.findResource(object.Group(), object.Version(), object.Kind)
+		if apiResource == nil {
+			report.Unknown = append(report.Unknown, object)
+			continue
+		}
+
+		finding := AuditFinding{
+			Object:      object,
+			Alternative: alternativeVersion(apiGroup, object.Kind, target),
+		}
+
+		// the curated removal wins over the API data, which can still list
+		// an API in the release that removed it
+		removed, err := isRemovedIn(apiResource.RemovedIn, target)
+		if err != nil {
+			return nil, err
+		}
+
+		if removed {
+			finding.Status = AuditRemoved
+			finding.Release = apiResource.RemovedIn
+			report.Findings = append(report.Findings, finding)
+			continue
+		}
+
+		if apiResource.HasRelease(target) {
+			// will the resource disappear in a later release?
+			for i := targetIdx + 1; i < len(o.Releases); i++ {
+				if !apiResource.HasRelease(o.Releases[i].Version) && !o.Releases[i].Projected {
+					finding.Status = AuditDeprecated
+					finding.Release = o.Releases[i].Version
+					break
+				}
+			}
+
+			// the removal can be planned for a release that is not known yet
+			if finding.Status == "" && (apiResource.RemovedIn != "" || apiResource.IsDeprecatedIn(target)) {
+				finding.Status = AuditDeprecated
+				finding.Release = apiResource.RemovedIn
+			}
+
+			if finding.Status == "" && isSuperseded(object.Version(), finding.Alternative) {
+				finding.Status = AuditSuperseded
+			}
+
+			if finding.Status != "" {
+				report.Findings = append(report.Findings, finding)
+			}
+
+			continue
+		}
+
+		// find the closest release around the target that serves the resource
+		finding.Status = AuditUnavailable
+		for i := targetIdx + 1; i < len(o.Releases); i++ {
+			if apiResource.HasRelease(o.Releases[i].Version) {
+				finding.Release = o.Releases[i].Version
+				break
+			}
+		}
+
+		for i := targetIdx - 1; i >= 0; i-- {
+			if apiResource.HasRelease(o.Releases[i].Version) {
+				finding.Status = AuditRemoved
+				finding.Release = o.Releases[i+1].Version
+				break
+			}
+		}
+
+		report.Findings = append(report.Findings, finding)
+	}
+
+	return report, nil
+}
+
+// isRemovedIn returns true if an API with the given removal release is not
+// served anymore in the given release.
+func isRemovedIn(removedIn string, release string) (bool, error) {
+	if removedIn == "" {
+		return false, nil
+	}
+
+	before, err := releaseLessThan(release, removedIn)
+	if err != nil {
+		return false, err
+	}
+
+	return !before, nil
+}
+
+func isSuperseded(apiVersion string, alternative string) bool {
+	if alternative == "" {
+		return false
+	}
+
+	current, err := version.ParseAPIVersion(apiVersion)
+	if err != nil {
+		return false
+	}
+
+	best, err := version.ParseAPIVersion(alternative)
+	if err != nil {
+		return false
+	}
+
+	return !current.IsGA() && best.IsGA()
+}
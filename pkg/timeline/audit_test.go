@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/manifest"
+)
+
+func TestAudit(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.24"},
+			{Version: "1.25"},
+			{Version: "1.26"},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "batch",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "Job", Releases: []string{"1.24", "1.25", "1.26"}},
+							{Kind: "CronJob", Releases: []string{"1.24", "1.25", "1.26"}},
+						},
+					},
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.24"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "flowcontrol.apiserver.k8s.io",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta3",
+						Resources: []APIResource{
+							{Kind: "FlowSchema", Releases: []string{"1.26"}},
+						},
+					},
+					{
+						Version: "v1beta2",
+						Resources: []APIResource{
+							{Kind: "FlowSchema", Releases: []string{"1.24", "1.25"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	objects := []manifest.Object{
+		{APIVersion: "batch/v1", Kind: "Job"},
+		{APIVersion: "batch/v1beta1", Kind: "CronJob"},
+		{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta3", Kind: "FlowSchema"},
+		{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta2", Kind: "FlowSchema"},
+		{APIVersion: "cert-manager.io/v1", Kind: "Certificate"},
+	}
+
+	report, err := tl.Audit(objects, "1.25")
+	if err != nil {
+		t.Fatalf("Failed to audit: %v", err)
+	}
+
+	expected := []AuditFinding{
+		{Object: objects[1], Status: AuditRemoved, Release: "1.25", Alternative: "v1"},
+		{Object: objects[2], Status: AuditUnavailable, Release: "1.26", Alternative: "v1beta2"},
+		{Object: objects[3], Status: AuditDeprecated, Release: "1.26", Alternative: "v1beta2"},
+	}
+
+	if len(report.Findings) != len(expected) {
+		t.Fatalf("Expected %d findings, got %+v", len(expected), report.Findings)
+	}
+
+	for i, finding := range report.Findings {
+		if finding != expected[i] {
+			t.Errorf("Finding %d: expected %+v, got %+v", i, expected[i], finding)
+		}
+	}
+
+	if len(report.Unknown) != 1 {
+		t.Errorf("Expected 1 unknown object, got %v", report.Unknown)
+	}
+
+	if !report.Failed() {
+		t.Error("Expected report to fail.")
+	}
+}
+
+func TestAuditCuratedDeprecations(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.28"},
+			{Version: "1.29"},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "flowcontrol.apiserver.k8s.io",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "FlowSchema", Releases: []string{"1.29"}},
+						},
+					},
+					{
+						Version: "v1beta3",
+						Resources: []APIResource{
+							{Kind: "FlowSchema", Releases: []string{"1.28", "1.29"}, DeprecatedIn: "1.29", RemovedIn: "1.32"},
+							{Kind: "PriorityLevelConfiguration", Releases: []string{"1.28", "1.29"}, DeprecatedIn: "1.29"},
+						},
+					},
+					{
+						Version: "v1beta2",
+						Resources: []APIResource{
+							// the API data still lists v1beta2 in 1.29
+							{Kind: "FlowSchema", Releases: []string{"1.28", "1.29"}, DeprecatedIn: "1.26", RemovedIn: "1.29"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	objects := []manifest.Object{
+		{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta3", Kind: "FlowSchema"},
+		{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta3", Kind: "PriorityLevelConfiguration"},
+		{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta2", Kind: "FlowSchema"},
+		{APIVersion: "flowcontrol.apiserver.k8s.io/v1", Kind: "FlowSchema"},
+	}
+
+	testcases := []struct {
+		target   string
+		expected []AuditFinding
+	}{
+		{
+			target: "1.28",
+			expected: []AuditFinding{
+				{Object: objects[0], Status: AuditDeprecated, Release: "1.32", Alternative: "v1beta3"},
+				{Object: objects[2], Status: AuditDeprecated, Release: "1.29", Alternative: "v1beta3"},
+				{Object: objects[3], Status: AuditUnavailable, Release: "1.29", Alternative: "v1beta3"},
+			},
+		},
+		{
+			target: "1.29",
+			expected: []AuditFinding{
+				{Object: objects[0], Status: AuditDeprecated, Release: "1.32", Alternative: "v1"},
+				{Object: objects[1], Status: AuditDeprecated, Release: "", Alternative: "v1beta3"},
+				{Object: objects[2], Status: AuditRemoved, Release: "1.29", Alternative: "v1"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.target, func(t *testing.T) {
+			report, err := tl.Audit(objects, tc.target)
+			if err != nil {
+				t.Fatalf("Failed to audit: %v", err)
+			}
+
+			if len(report.Findings) != len(tc.expected) {
+				t.Fatalf("Expected %d findings, got %+v", len(tc.expected), report.Findings)
+			}
+
+			for i, finding := range report.Findings {
+				if finding != tc.expected[i] {
+					t.Errorf("Finding %d: expected %+v, got %+v", i, tc.expected[i], finding)
+				}
+			}
+		})
+	}
+}
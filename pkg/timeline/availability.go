@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ResourceAvailability lists all API versions of a group that offer a
+// resource, e.g. for "deployments.apps".
+type ResourceAvailability struct {
+	Group    string
+	Kind     string
+	Plural   string
+	Versions []VersionAvailability
+}
+
+type VersionAvailability struct {
+	Version      string
+	Releases     []string
+	DeprecatedIn string
+	RemovedIn    string
+	// PredictedRemovalIn is only set if RemovedIn is unknown.
+	PredictedRemovalIn string
+	SucceededBy        *ResourceSuccession
+}
+
+// ServedIn returns the API versions that offer the resource in the given
+// release, most preferred first.
+func (a *ResourceAvailability) ServedIn(release string) []string {
+	result := []string{}
+	for _, v := range a.Versions {
+		if contains(v.Releases, release) {
+			result = append(result, v.Version)
+		}
+	}
+
+	return result
+}
+
+// Availability looks up a resource the way kubectl does, either by its
+// plural, singular, short name or kind, optionally qualified with the API group
+// ("deployments.apps"). Unqualified names must be unambiguous; if multiple
+// groups offer the resource, only groups still offering it in the latest
+// release are considered.
+func (o *Timeline) Availability(resource string) (*ResourceAvailability, error) {
+	name, group, _ := strings.Cut(strings.ToLower(resource), ".")
+	if name == "" {
+		return nil, fmt.Errorf("invalid resource %q", resource)
+	}
+
+	// group => kind; short names are not known for every release, so first
+	// find the kind and then collect all of its versions
+	kinds := map[string]string{}
+
+	for _, apiGroup := range o.APIGroups {
+		if group != "" && apiGroup.Name != group {
+			continue
+		}
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				if name == strings.ToLower(apiResource.Plural) || name == strings.ToLower(apiResource.Singular) || name == strings.ToLower(apiResource.Kind) || apiResource.HasShortName(name) {
+					kinds[apiGroup.Name] = apiResource.Kind
+				}
+			}
+		}
+	}
+
+	candidates := map[string]*ResourceAvailability{}
+
+	for _, apiGroup := range o.APIGroups {
+		kind, exists := kinds[apiGroup.Name]
+		if !exists {
+			continue
+		}
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				if apiResource.Kind != kind {
+					continue
+				}
+
+				availability, exists := candidates[apiGroup.Name]
+				if !exists {
+					availability = &ResourceAvailability{
+						Group:  apiGroup.Name,
+						Kind:   apiResource.Kind,
+						Plural: apiResource.Plural,
+					}
+					candidates[apiGroup.Name] = availability
+				}
+
+				availability.Versions = append(availability.Versions, VersionAvailability{
+					Version:            apiVersion.Version,
+					Releases:           o.sortedReleases(apiResource.Releases),
+					DeprecatedIn:       apiResource.DeprecatedIn,
+					RemovedIn:          apiResource.RemovedIn,
+					PredictedRemovalIn: apiResource.PredictedRemovalIn,
+					SucceededBy:        apiResource.SucceededBy,
+				})
+			}
+		}
+	}
+
+	if len(candidates) > 1 && len(o.Releases) > 0 {
+		latest := o.Releases[len(o.Releases)-1].Version
+
+		current := map[string]*ResourceAvailability{}
+		for name, candidate := range candidates {
+			if len(candidate.ServedIn(latest)) > 0 {
+				current[name] = candidate
+			}
+		}
+
+		if len(current) > 0 {
+			candidates = current
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("unknown resource %q", resource)
+
+	case 1:
+		for _, candidate := range candidates {
+			return candidate, nil
+		}
+	}
+
+	names := []string{}
+	for _, candidate := range candidates {
+		names = append(names, fmt.Sprintf("%s.%s", strings.ToLower(candidate.Plural), candidate.Group))
+	}
+	sort.Strings(names)
+
+	return nil, fmt.Errorf("resource %q is ambiguous, use one of %s", resource, strings.Join(names, ", "))
+}
+
+// CategoryMembers returns the resources (like "deployments.apps") that are
+// part of a category (like "all") in the given release.
+func (o *Timeline) CategoryMembers(category string, release string) []string {
+	members := sets.New[string]()
+
+	for _, apiGroup := range o.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				if !apiResource.HasCategoryIn(category, release) {
+					continue
+				}
+
+				name := strings.ToLower(apiResource.Plural)
+				if apiGroup.Name != "core" {
+					name = fmt.Sprintf("%s.%s", name, apiGroup.Name)
+				}
+
+				members.Insert(name)
+			}
+		}
+	}
+
+	return sets.List(members)
+}
+
+// ServedAPIVersions returns all API versions served by the given release, both
+// as "group/version" and "group/version/Kind" (like "apps/v1/Deployment"), in
+// the same format as Helm's .Capabilities.APIVersions. The core group has no
+// prefix, like "v1" and "v1/Pod".
+func (o *Timeline) ServedAPIVersions(release string) []string {
+	result := sets.New[string]()
+
+	for _, apiGroup := range o.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			if !apiVersion.HasRelease(release) {
+				continue
+			}
+
+			groupVersion := apiVersion.Version
+			if apiGroup.Name != "core" {
+				groupVersion = apiGroup.Name + "/" + groupVersion
+			}
+
+			result.Insert(groupVersion)
+
+			for _, apiResource := range apiVersion.Resources {
+				if apiResource.HasRelease(release) {
+					result.Insert(groupVersion + "/" + apiResource.Kind)
+				}
+			}
+		}
+	}
+
+	return sets.List(result)
+}
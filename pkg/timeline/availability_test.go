@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAvailability(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.15"},
+			{Version: "1.16"},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "apps",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "Deployment", Singular: "deployment", Plural: "deployments", Releases: []string{"1.15", "1.16"}, ShortNames: map[string][]string{"1.16": {"deploy"}}},
+						},
+					},
+					{
+						Version: "v1beta2",
+						Resources: []APIResource{
+							{Kind: "Deployment", Singular: "deployment", Plural: "deployments", Releases: []string{"1.15"}, RemovedIn: "1.16"},
+						},
+					},
+				},
+			},
+			{
+				Name: "extensions",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "Deployment", Singular: "deployment", Plural: "deployments", Releases: []string{"1.15"}},
+							{Kind: "Ingress", Singular: "ingress", Plural: "ingresses", Releases: []string{"1.15", "1.16"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "networking.k8s.io",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "Ingress", Singular: "ingress", Plural: "ingresses", Releases: []string{"1.15", "1.16"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		resource string
+		group    string
+		served   []string
+		invalid  bool
+	}{
+		{resource: "deployments.apps", group: "apps", served: []string{"v1", "v1beta2"}},
+		{resource: "Deployment.apps", group: "apps", served: []string{"v1", "v1beta2"}},
+		{resource: "deploy", group: "apps", served: []string{"v1", "v1beta2"}},
+		{resource: "deployments.extensions", group: "extensions", served: []string{"v1beta1"}},
+		// extensions does not offer deployments anymore in the latest release
+		{resource: "deployments", group: "apps", served: []string{"v1", "v1beta2"}},
+		// both groups still offer ingresses
+		{resource: "ingresses", invalid: true},
+		{resource: "ingresses.networking.k8s.io", group: "networking.k8s.io", served: []string{"v1beta1"}},
+		{resource: "pods", invalid: true},
+		{resource: "", invalid: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.resource, func(t *testing.T) {
+			availability, err := tl.Availability(tc.resource)
+			if tc.invalid {
+				if err == nil {
+					t.Fatalf("Expected an error, but got %+v.", availability)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to look up resource: %v", err)
+			}
+
+			if availability.Group != tc.group {
+				t.Errorf("Expected group %q, but got %q.", tc.group, availability.Group)
+			}
+
+			if served := availability.ServedIn("1.15"); !reflect.DeepEqual(served, tc.served) {
+				t.Errorf("Expected %v to be served in 1.15, but got %v.", tc.served, served)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+)
+
+// cacheFormat must be changed whenever the merged types (APIGroup and
+// everything below it) or the merge logic change, so that existing cache
+// entries are not mistaken for valid results.
+const cacheFormat = "2"
+
+// mergeCache stores the API groups as they are after merging a release
+// (and all releases before it) on disk. Each entry is keyed by a hash over
+// the checksums of all merged releases, so changing a release invalidates
+// the entries of that release and all following ones, but not the entries
+// of older releases.
+type mergeCache struct {
+	directory string
+	keys      []string
+}
+
+func newMergeCache(directory string, releases []*database.KubernetesRelease, overlays []*database.ReleaseDatabase) (*mergeCache, error) {
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	cache := &mergeCache{
+		directory: directory,
+		keys:      []string{},
+	}
+
+	previous := cacheFormat
+
+	for _, release := range releases {
+		hash := sha256.New()
+		hash.Write([]byte(previous))
+
+		checksum, err := release.Checksum()
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash release %s: %w", release.Version(), err)
+		}
+
+		hash.Write([]byte(checksum))
+
+		for _, overlay := range overlays {
+			overlayRelease, err := overlay.Release(release.Version())
+			if err != nil {
+				if errors.Is(err, database.ErrUnknownRelease) {
+					hash.Write([]byte("-"))
+					continue
+				}
+
+				return nil, fmt.Errorf("failed to load overlay release %s: %w", release.Version(), err)
+			}
+
+			checksum, err := overlayRelease.Checksum()
+			if err != nil {
+				return nil, fmt.Errorf("failed to hash overlay release %s: %w", release.Version(), err)
+			}
+
+			hash.Write([]byte(checksum))
+		}
+
+		previous = hex.EncodeToString(hash.Sum(nil))
+		cache.keys = append(cache.keys, previous)
+	}
+
+	return cache, nil
+}
+
+// load returns the API groups of the longest cached sequence of releases and
+// the number of releases in it. If nothing is cached, 0 is returned.
+func (c *mergeCache) load() ([]APIGroup, int, error) {
+	for i := len(c.keys) - 1; i >= 0; i-- {
+		data, err := os.ReadFile(c.filename(i))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, 0, err
+		}
+
+		groups := []APIGroup{}
+		if err := json.Unmarshal(data, &groups); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode %s: %w", c.filename(i), err)
+		}
+
+		return groups, i + 1, nil
+	}
+
+	return nil, 0, nil
+}
+
+// store remembers the API groups after the release with the given index has
+// been merged.
+func (c *mergeCache) store(idx int, groups []APIGroup) error {
+	data, err := json.Marshal(groups)
+	if err != nil {
+		return err
+	}
+
+	// write atomically, so that interrupted runs do not leave broken entries
+	f, err := os.CreateTemp(c.directory, ".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), c.filename(idx))
+}
+
+func (c *mergeCache) filename(idx int) string {
+	return filepath.Join(c.directory, c.keys[idx]+".json")
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+
+	fsys := fstest.MapFS{}
+	for _, release := range []string{"1.27", "1.28", "1.29"} {
+		fsys["releases/"+release+"/released.txt"] = &fstest.MapFile{Data: []byte("2023-01-01")}
+		fsys["releases/"+release+"/latest.txt"] = &fstest.MapFile{Data: []byte("v" + release + ".0")}
+		fsys["releases/"+release+"/api.json"] = &fstest.MapFile{Data: []byte(testAPI(release, "Job"))}
+	}
+
+	// returns the uncached and cached timeline, plus the number of releases
+	// that had to be merged for the cached one
+	createTimelines := func() (*Timeline, *Timeline, int) {
+		releases, err := database.NewReleaseDatabaseFromFS(fsys).LoadReleases(ctx)
+		if err != nil {
+			t.Fatalf("Failed to load releases: %v", err)
+		}
+
+		uncached, err := CreateTimeline(ctx, releases)
+		if err != nil {
+			t.Fatalf("Failed to create timeline: %v", err)
+		}
+
+		var log bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&log, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		cached, err := CreateTimeline(ctx, releases, WithCache(cacheDir), WithLogger(logger))
+		if err != nil {
+			t.Fatalf("Failed to create cached timeline: %v", err)
+		}
+
+		return uncached, cached, strings.Count(log.String(), "Merging release")
+	}
+
+	// the first run fills the cache, the second one uses it
+	for i, expected := range []int{3, 0} {
+		uncached, cached, merged := createTimelines()
+		if !reflect.DeepEqual(uncached, cached) {
+			t.Fatalf("Run %d: cached timeline differs from uncached one.", i+1)
+		}
+
+		if merged != expected {
+			t.Errorf("Run %d: expected %d releases to be merged, but %d were.", i+1, expected, merged)
+		}
+	}
+
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		t.Fatalf("Failed to read cache directory: %v", err)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 cache entries, got %d.", len(entries))
+	}
+
+	// changing the newest release must not reuse its stale entry
+	fsys["releases/1.29/api.json"] = &fstest.MapFile{Data: []byte(testAPI("1.29", "CronJob"))}
+
+	uncached, cached, merged := createTimelines()
+	if !reflect.DeepEqual(uncached, cached) {
+		t.Fatal("Cached timeline differs from uncached one after changing a release.")
+	}
+
+	if merged != 1 {
+		t.Errorf("Expected only the changed release to be merged, but %d were.", merged)
+	}
+
+	if resources := cached.APIGroups[0].APIVersions[0].Resources; len(resources) != 2 {
+		t.Errorf("Expected the changed release to be merged again, got %+v.", resources)
+	}
+}
+
+func testAPI(release string, kind string) string {
+	return fmt.Sprintf(`{
+		"release": %q,
+		"apiGroups": [{
+			"name": "batch",
+			"preferredVersion": "v1",
+			"apiVersions": [{
+				"version": "v1",
+				"resources": [{"kind": %q, "plural": "jobs", "namespaced": true, "shortNames": ["j"]}]
+			}]
+		}]
+	}`, release, kind)
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"go.xrstf.de/kube-api.ninja/pkg/manifest"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ReleaseIndex is a reverse index from API resources (identified by their
+// group, version and kind) to the releases serving them.
+type ReleaseIndex struct {
+	// releases in timeline order, without projected releases
+	releases []string
+	served   map[string]sets.Set[string]
+}
+
+// ReleaseIndex builds the reverse index for all API resources in the
+// timeline. Projected releases are ignored, as they are only guesses.
+func (o *Timeline) ReleaseIndex() *ReleaseIndex {
+	index := &ReleaseIndex{
+		releases: []string{},
+		served:   map[string]sets.Set[string]{},
+	}
+
+	for _, release := range o.Releases {
+		if !release.Projected {
+			index.releases = append(index.releases, release.Version)
+		}
+	}
+
+	for _, apiGroup := range o.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				index.served[indexKey(apiGroup.Name, apiVersion.Version, apiResource.Kind)] = sets.New(apiResource.Releases...)
+			}
+		}
+	}
+
+	return index
+}
+
+// Releases returns the releases serving the API resource, in timeline order.
+// The second return value is false if the resource is not part of the
+// timeline at all.
+func (i *ReleaseIndex) Releases(group, version, kind string) ([]string, bool) {
+	served, exists := i.served[indexKey(group, version, kind)]
+	if !exists {
+		return nil, false
+	}
+
+	result := []string{}
+	for _, release := range i.releases {
+		if served.Has(release) {
+			result = append(result, release)
+		}
+	}
+
+	return result, true
+}
+
+func indexKey(group, version, kind string) string {
+	return group + "/" + version + "/" + kind
+}
+
+// CompatibilityReport lists the releases that a set of manifests can be
+// applied to as-is.
+type CompatibilityReport struct {
+	// Releases are all releases that serve every API used by the objects,
+	// in timeline order.
+	Releases []string
+	// Gaps are the releases between the oldest and newest usable release
+	// that are not usable, because an API was removed and later served
+	// again. This is usually empty.
+	Gaps []string
+	// Constraints lists the releases serving each distinct API, in the order
+	// in which the APIs were first used.
+	Constraints []APIConstraint
+
+	// Unknown lists all objects whose API is not part of Kubernetes itself,
+	// like custom resources, which do not limit the releases.
+	Unknown []manifest.Object
+}
+
+// APIConstraint is an API used by the manifests and the releases serving it.
+type APIConstraint struct {
+	APIVersion string
+	Kind       string
+	Releases   []string
+}
+
+// Range returns the oldest and newest release that serve all APIs, or empty
+// strings if there is no such release.
+func (r *CompatibilityReport) Range() (string, string) {
+	if len(r.Releases) == 0 {
+		return "", ""
+	}
+
+	return r.Releases[0], r.Releases[len(r.Releases)-1]
+}
+
+// Compatibility computes the intersection of the releases in which all APIs
+// used by the objects are served.
+func (o *Timeline) Compatibility(objects []manifest.Object) *CompatibilityReport {
+	index := o.ReleaseIndex()
+
+	report := &CompatibilityReport{
+		Constraints: []APIConstraint{},
+		Unknown:     []manifest.Object{},
+	}
+
+	usable := sets.New(index.releases...)
+	seen := sets.New[string]()
+
+	for _, object := range objects {
+		key := indexKey(object.Group(), object.Version(), object.Kind)
+		if seen.Has(key) {
+			continue
+		}
+
+		releases, exists := index.Releases(object.Group(), object.Version(), object.Kind)
+		if !exists {
+			report.Unknown = append(report.Unknown, object)
+			continue
+		}
+
+		seen.Insert(key)
+		usable = usable.Intersection(sets.New(releases...))
+
+		report.Constraints = append(report.Constraints, APIConstraint{
+			APIVersion: object.APIVersion,
+			Kind:       object.Kind,
+			Releases:   releases,
+		})
+	}
+
+	report.Releases = []string{}
+	report.Gaps = []string{}
+	gaps := []string{}
+
+	for _, release := range index.releases {
+		switch {
+		case usable.Has(release):
+			report.Releases = append(report.Releases, release)
+			report.Gaps = append(report.Gaps, gaps...)
+			gaps = gaps[:0]
+		case len(report.Releases) > 0:
+			gaps = append(gaps, release)
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/manifest"
+)
+
+func TestCompatibility(t *testing.T) {
+	tl := testTimeline()
+	tl.Releases = append(tl.Releases, ReleaseMetadata{Version: "1.27"}, ReleaseMetadata{Version: "1.28", Projected: true})
+	tl.APIGroups = append(tl.APIGroups, APIGroup{
+		Name: "core",
+		APIVersions: []APIVersion{
+			{
+				Version: "v1",
+				Resources: []APIResource{
+					{Kind: "ConfigMap", Releases: []string{"1.24", "1.25", "1.26", "1.27", "1.28"}},
+					{Kind: "Flaky", Releases: []string{"1.24", "1.26", "1.27"}},
+				},
+			},
+		},
+	})
+
+	testcases := []struct {
+		name             string
+		objects          []manifest.Object
+		expectedReleases []string
+		expectedGaps     []string
+		expectedUnknown  int
+	}{
+		{
+			name:             "no objects",
+			expectedReleases: []string{"1.24", "1.25", "1.26", "1.27"},
+			expectedGaps:     []string{},
+		},
+		{
+			name: "intersection",
+			objects: []manifest.Object{
+				{APIVersion: "v1", Kind: "ConfigMap"},
+				{APIVersion: "batch/v1", Kind: "Job"},
+				{APIVersion: "batch/v1", Kind: "CronJob"},
+				{APIVersion: "batch/v1", Kind: "Job"},
+				{APIVersion: "example.com/v1", Kind: "Widget"},
+			},
+			expectedReleases: []string{"1.24", "1.25"},
+			expectedGaps:     []string{},
+			expectedUnknown:  1,
+		},
+		{
+			name: "single release",
+			objects: []manifest.Object{
+				{APIVersion: "batch/v1beta1", Kind: "CronJob"},
+				{APIVersion: "v1", Kind: "ConfigMap"},
+				{APIVersion: "batch/v1", Kind: "CronJob"},
+				{APIVersion: "v1", Kind: "Flaky"},
+				{APIVersion: "batch/v1", Kind: "Job"},
+			},
+			expectedReleases: []string{"1.24"},
+			expectedGaps:     []string{},
+		},
+		{
+			name: "gaps",
+			objects: []manifest.Object{
+				{APIVersion: "v1", Kind: "Flaky"},
+			},
+			expectedReleases: []string{"1.24", "1.26", "1.27"},
+			expectedGaps:     []string{"1.25"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			report := tl.Compatibility(testcase.objects)
+
+			if !reflect.DeepEqual(report.Releases, testcase.expectedReleases) {
+				t.Errorf("Expected releases %v, got %v.", testcase.expectedReleases, report.Releases)
+			}
+
+			if !reflect.DeepEqual(report.Gaps, testcase.expectedGaps) {
+				t.Errorf("Expected gaps %v, got %v.", testcase.expectedGaps, report.Gaps)
+			}
+
+			if len(report.Unknown) != testcase.expectedUnknown {
+				t.Errorf("Expected %d unknown objects, got %v.", testcase.expectedUnknown, report.Unknown)
+			}
+		})
+	}
+
+	report := tl.Compatibility([]manifest.Object{{APIVersion: "batch/v1beta1", Kind: "CronJob"}, {APIVersion: "batch/v1", Kind: "Job"}})
+	if first, last := report.Range(); first != "1.24" || last != "1.24" {
+		t.Errorf("Expected range 1.24–1.24, got %s–%s.", first, last)
+	}
+
+	expected := []APIConstraint{
+		{APIVersion: "batch/v1beta1", Kind: "CronJob", Releases: []string{"1.24"}},
+		{APIVersion: "batch/v1", Kind: "Job", Releases: []string{"1.24", "1.25"}},
+	}
+	if !reflect.DeepEqual(report.Constraints, expected) {
+		t.Errorf("Expected constraints %+v, got %+v.", expected, report.Constraints)
+	}
+}
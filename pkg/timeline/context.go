@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// releaseContext bundles the per-release data that is needed while merging
+// a single release into the timeline.
+type releaseContext struct {
+	version string
+
+	// set of "group/Kind" keys (see conformanceKey)
+	conformance sets.Set[string]
+
+	// whether the release's data source knows short names and categories
+	hasAliases bool
+}
+
+func newReleaseContext(release *database.KubernetesRelease) (*releaseContext, error) {
+	relCtx := &releaseContext{
+		version:     release.Version(),
+		conformance: sets.New[string](),
+	}
+
+	coverage, err := release.ConformanceCoverage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conformance coverage: %w", err)
+	}
+
+	if coverage != nil {
+		for _, res := range coverage.Resources {
+			relCtx.conformance.Insert(conformanceKey(res.Group, res.Kind))
+		}
+	}
+
+	return relCtx, nil
+}
+
+// hasAliases returns true if any resource has short names or categories;
+// data dumped from Swagger/OpenAPI specs has neither.
+func hasAliases(api *types.KubernetesAPI) bool {
+	for _, apiGroup := range api.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, resource := range apiVersion.Resources {
+				if len(resource.ShortNames) > 0 || len(resource.Categories) > 0 {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func conformanceKey(group string, kind string) string {
+	return fmt.Sprintf("%s/%s", group, kind)
+}
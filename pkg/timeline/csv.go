@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteCSV writes one row per API resource and API version, listing the
+// releases in which it is available. This is meant for spreadsheets and
+// quick analysis, the JSON representation of the timeline is more complete.
+func (o *Timeline) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"group", "version", "kind", "plural", "first_release", "last_release", "deprecated_in", "removed_in", "releases"}); err != nil {
+		return err
+	}
+
+	for _, apiGroup := range o.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				releases := o.sortedReleases(apiResource.Releases)
+				if len(releases) == 0 {
+					continue
+				}
+
+				err := writer.Write([]string{
+					apiGroup.Name,
+					apiVersion.Version,
+					apiResource.Kind,
+					apiResource.Plural,
+					releases[0],
+					releases[len(releases)-1],
+					apiResource.DeprecatedIn,
+					apiResource.RemovedIn,
+					strings.Join(releases, " "),
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// WriteAvailabilityCSV flattens the availability matrix into one row per API
+// resource, API version and release, so that it can be pivoted in
+// spreadsheets and BI tools. Unlike WriteCSV, releases in which a resource is
+// not available are included as well. Use ',' or '\t' as the separator to
+// get CSV or TSV output.
+func (o *Timeline) WriteAvailabilityCSV(w io.Writer, separator rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = separator
+
+	if err := writer.Write([]string{"group", "version", "kind", "release", "available", "scope", "preferred"}); err != nil {
+		return err
+	}
+
+	for _, apiGroup := range o.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				for _, release := range o.Releases {
+					// projected releases are guesses and would skew any statistics
+					if release.Projected {
+						continue
+					}
+
+					available := apiResource.HasRelease(release.Version)
+					scope := ""
+					preferred := false
+
+					if available {
+						scope = apiResource.Scopes[release.Version]
+						preferred = apiGroup.PreferredVersion(release.Version) == apiVersion.Version
+					}
+
+					err := writer.Write([]string{
+						apiGroup.Name,
+						apiVersion.Version,
+						apiResource.Kind,
+						release.Version,
+						strconv.FormatBool(available),
+						scope,
+						strconv.FormatBool(preferred),
+					})
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// sortedReleases returns the given releases that are part of the timeline,
+// in the timeline's order (the releases of resources are sorted
+// alphabetically, so "1.10" would come before "1.9").
+func (o *Timeline) sortedReleases(releases []string) []string {
+	result := []string{}
+
+	for _, rel := range o.Releases {
+		for _, r := range releases {
+			if r == rel.Version {
+				result = append(result, r)
+				break
+			}
+		}
+	}
+
+	return result
+}
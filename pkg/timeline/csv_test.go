@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteAvailabilityCSV(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.24"},
+			{Version: "1.25"},
+			{Version: "1.26", Projected: true},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name:              "batch",
+				PreferredVersions: map[string]string{"1.24": "v1", "1.25": "v1"},
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.24"}, Scopes: map[string]string{"1.24": "Namespaced"}},
+						},
+					},
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.24", "1.25", "1.26"}, Scopes: map[string]string{"1.24": "Namespaced", "1.25": "Namespaced"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name      string
+		separator rune
+		expected  string
+	}{
+		{
+			name:      "csv",
+			separator: ',',
+			expected: `group,version,kind,release,available,scope,preferred
+batch,v1beta1,CronJob,1.24,true,Namespaced,false
+batch,v1beta1,CronJob,1.25,false,,false
+batch,v1,CronJob,1.24,true,Namespaced,true
+batch,v1,CronJob,1.25,true,Namespaced,true
+`,
+		},
+		{
+			name:      "tsv",
+			separator: '\t',
+			expected: "group\tversion\tkind\trelease\tavailable\tscope\tpreferred\n" +
+				"batch\tv1beta1\tCronJob\t1.24\ttrue\tNamespaced\tfalse\n" +
+				"batch\tv1beta1\tCronJob\t1.25\tfalse\t\tfalse\n" +
+				"batch\tv1\tCronJob\t1.24\ttrue\tNamespaced\ttrue\n" +
+				"batch\tv1\tCronJob\t1.25\ttrue\tNamespaced\ttrue\n",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tl.WriteAvailabilityCSV(&buf, testcase.separator); err != nil {
+				t.Fatalf("Failed to write CSV: %v", err)
+			}
+
+			if buf.String() != testcase.expected {
+				t.Errorf("Expected\n%s\ngot\n%s", testcase.expected, buf.String())
+			}
+		})
+	}
+}
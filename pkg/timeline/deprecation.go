@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+// IsDeprecatedIn returns true if the API version is still served in the given
+// release, but has been deprecated.
+func (o *APIVersion) IsDeprecatedIn(release string) bool {
+	return o.HasRelease(release) && isDeprecatedIn(o.DeprecatedIn, release)
+}
+
+// IsDeprecatedIn returns true if the resource is still served in the given
+// release, but has been deprecated.
+func (o *APIResource) IsDeprecatedIn(release string) bool {
+	return o.HasRelease(release) && isDeprecatedIn(o.DeprecatedIn, release)
+}
+
+func isDeprecatedIn(deprecatedIn string, release string) bool {
+	if deprecatedIn == "" {
+		return false
+	}
+
+	before, err := releaseLessThan(release, deprecatedIn)
+
+	return err == nil && !before
+}
+
+func calculateDeprecations(tl *Timeline) error {
+	for i, apiGroup := range tl.APIGroups {
+		for j, apiVersion := range apiGroup.APIVersions {
+			if apiVersion.RemovedIn == "" {
+				tl.APIGroups[i].APIVersions[j].RemovedIn = removalRelease(tl, apiVersion.Releases)
+			}
+
+			apiVersion := tl.APIGroups[i].APIVersions[j]
+
+			for k, apiResource := range apiVersion.Resources {
+				resource := &tl.APIGroups[i].APIVersions[j].Resources[k]
+
+				// the earlier deprecation wins
+				if apiVersion.DeprecatedIn != "" {
+					if resource.DeprecatedIn == "" {
+						resource.DeprecatedIn = apiVersion.DeprecatedIn
+					} else {
+						earlier, err := releaseLessThan(apiVersion.DeprecatedIn, resource.DeprecatedIn)
+						if err != nil {
+							return err
+						}
+
+						if earlier {
+							resource.DeprecatedIn = apiVersion.DeprecatedIn
+						}
+					}
+				}
+
+				// resources can disappear before their API version does
+				if apiResource.RemovedIn == "" {
+					resource.RemovedIn = removalRelease(tl, apiResource.Releases)
+				}
+
+				if resource.RemovedIn == "" {
+					resource.RemovedIn = apiVersion.RemovedIn
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// removalRelease returns the release following the last release that serves
+// an API, or an empty string if the API is still served in the latest
+// (non-projected) release.
+func removalRelease(tl *Timeline, releases []string) string {
+	last := -1
+	for i, release := range tl.Releases {
+		if !release.Projected && contains(releases, release.Version) {
+			last = i
+		}
+	}
+
+	if last < 0 || last+1 >= len(tl.Releases) || tl.Releases[last+1].Projected {
+		return ""
+	}
+
+	return tl.Releases[last+1].Version
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"testing"
+)
+
+func TestCalculateDeprecations(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.20"},
+			{Version: "1.21"},
+			{Version: "1.22"},
+			{Version: "1.23"},
+			{Version: "1.24", Projected: true},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "batch",
+				APIVersions: []APIVersion{
+					{
+						Version:      "v1beta1",
+						Releases:     []string{"1.20", "1.21", "1.22"},
+						DeprecatedIn: "1.21",
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.20", "1.21", "1.22"}},
+							{Kind: "Job", Releases: []string{"1.20"}, DeprecatedIn: "1.22"},
+						},
+					},
+					{
+						Version:  "v1",
+						Releases: []string{"1.20", "1.21", "1.22", "1.23", "1.24"},
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.21", "1.22", "1.23", "1.24"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := calculateDeprecations(tl); err != nil {
+		t.Fatalf("Failed to calculate deprecations: %v", err)
+	}
+
+	testcases := []struct {
+		version      int
+		resource     int
+		deprecatedIn string
+		removedIn    string
+	}{
+		{version: 0, resource: 0, deprecatedIn: "1.21", removedIn: "1.23"},
+		{version: 0, resource: 1, deprecatedIn: "1.21", removedIn: "1.21"},
+		{version: 1, resource: 0, deprecatedIn: "", removedIn: ""},
+	}
+
+	for _, tc := range testcases {
+		resource := tl.APIGroups[0].APIVersions[tc.version].Resources[tc.resource]
+
+		if resource.DeprecatedIn != tc.deprecatedIn {
+			t.Errorf("Expected %s to be deprecated in %q, but got %q.", resource.Kind, tc.deprecatedIn, resource.DeprecatedIn)
+		}
+
+		if resource.RemovedIn != tc.removedIn {
+			t.Errorf("Expected %s to be removed in %q, but got %q.", resource.Kind, tc.removedIn, resource.RemovedIn)
+		}
+	}
+
+	if removedIn := tl.APIGroups[0].APIVersions[0].RemovedIn; removedIn != "1.23" {
+		t.Errorf("Expected batch/v1beta1 to be removed in 1.23, but got %q.", removedIn)
+	}
+
+	apiVersion := tl.APIGroups[0].APIVersions[0]
+	if apiVersion.IsDeprecatedIn("1.20") {
+		t.Error("Expected batch/v1beta1 not to be deprecated in 1.20.")
+	}
+
+	if !apiVersion.IsDeprecatedIn("1.22") {
+		t.Error("Expected batch/v1beta1 to be deprecated in 1.22.")
+	}
+
+	if apiVersion.IsDeprecatedIn("1.23") {
+		t.Error("Expected batch/v1beta1 not to be reported as deprecated after its removal.")
+	}
+}
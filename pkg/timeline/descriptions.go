@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+// DescriptionChange is a release in which the description of a resource
+// differs from the previous release that had one.
+type DescriptionChange struct {
+	Release     string
+	Description string
+}
+
+// DescriptionIn returns the description of the resource in the given
+// release, falling back to the most recent description if the release did
+// not provide one (e.g. because it is only projected).
+func (o *APIResource) DescriptionIn(release string) string {
+	if description, ok := o.Descriptions[release]; ok {
+		return description
+	}
+
+	return o.Description
+}
+
+// DescriptionChanges returns how the description of the resource evolved,
+// starting with the first release that provided one.
+func (o *APIResource) DescriptionChanges(releases []ReleaseMetadata) []DescriptionChange {
+	changes := []DescriptionChange{}
+	last := ""
+
+	for _, release := range releases {
+		description, ok := o.Descriptions[release.Version]
+		if !ok || description == last {
+			continue
+		}
+
+		changes = append(changes, DescriptionChange{
+			Release:     release.Version,
+			Description: description,
+		})
+		last = description
+	}
+
+	return changes
+}
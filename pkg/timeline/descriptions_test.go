@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDescriptionChanges(t *testing.T) {
+	releases := []ReleaseMetadata{
+		{Version: "1.20"},
+		{Version: "1.21"},
+		{Version: "1.22"},
+		{Version: "1.23"},
+		{Version: "1.24", Projected: true},
+	}
+
+	resource := &APIResource{
+		Kind:        "CronJob",
+		Description: "CronJob represents the configuration of a single cron job.",
+		// 1.21 was dumped by kubectl and has no description
+		Descriptions: map[string]string{
+			"1.20": "CronJob represents a cron job.",
+			"1.22": "CronJob represents the configuration of a single cron job.",
+			"1.23": "CronJob represents the configuration of a single cron job.",
+		},
+	}
+
+	expected := []DescriptionChange{
+		{Release: "1.20", Description: "CronJob represents a cron job."},
+		{Release: "1.22", Description: "CronJob represents the configuration of a single cron job."},
+	}
+
+	if changes := resource.DescriptionChanges(releases); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected\n%+v\nbut got\n%+v", expected, changes)
+	}
+
+	testcases := []struct {
+		release  string
+		expected string
+	}{
+		{release: "1.20", expected: "CronJob represents a cron job."},
+		{release: "1.21", expected: resource.Description},
+		{release: "1.24", expected: resource.Description},
+	}
+
+	for _, tc := range testcases {
+		if description := resource.DescriptionIn(tc.release); description != tc.expected {
+			t.Errorf("Expected description %q in %s, got %q.", tc.expected, tc.release, description)
+		}
+	}
+}
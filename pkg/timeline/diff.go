@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type DiffType string
+
+const (
+	DiffAdded   DiffType = "added"
+	DiffRemoved DiffType = "removed"
+	DiffChanged DiffType = "changed"
+)
+
+// ReleaseDiff lists the differences between the APIs of two releases.
+type ReleaseDiff struct {
+	From      string
+	To        string
+	Groups    []DiffEntry
+	Versions  []DiffEntry
+	Resources []DiffEntry
+}
+
+// Empty returns true if both releases offer the same APIs.
+func (d *ReleaseDiff) Empty() bool {
+	return len(d.Groups)+len(d.Versions)+len(d.Resources) == 0
+}
+
+// DiffEntry is a single added, removed or changed API group, version or
+// resource. Version and Kind are empty for groups, Kind is empty for versions.
+type DiffEntry struct {
+	Type    DiffType
+	Group   string
+	Version string
+	Kind    string
+	// Details describe what changed, e.g. "preferred version: v1beta1 → v1".
+	Details []string
+}
+
+// Count returns the number of added, removed or changed groups, versions and
+// resources.
+func (d *ReleaseDiff) Count(diffType DiffType) int {
+	count := 0
+
+	for _, list := range [][]DiffEntry{d.Groups, d.Versions, d.Resources} {
+		for _, entry := range list {
+			if entry.Type == diffType {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// DiffGroup contains all diff entries of a single API group.
+type DiffGroup struct {
+	Name    string
+	Entries []DiffEntry
+}
+
+// ByGroup returns the changed groups, versions and resources grouped by their
+// API group, in the order of the timeline.
+func (d *ReleaseDiff) ByGroup() []DiffGroup {
+	result := []DiffGroup{}
+	index := map[string]int{}
+
+	// the diff entries are already sorted by group, so keeping the order of
+	// their first appearance keeps the groups in timeline order
+	for _, list := range [][]DiffEntry{d.Groups, d.Versions, d.Resources} {
+		for _, entry := range list {
+			idx, exists := index[entry.Group]
+			if !exists {
+				idx = len(result)
+				index[entry.Group] = idx
+				result = append(result, DiffGroup{Name: entry.Group})
+			}
+
+			result[idx].Entries = append(result[idx].Entries, entry)
+		}
+	}
+
+	return result
+}
+
+// APIVersion returns the apiVersion as it is written in manifests (i.e.
+// without the group for the core API group), or an empty string for groups.
+func (e DiffEntry) APIVersion() string {
+	if e.Version == "" {
+		return ""
+	}
+
+	return groupVersion(e.Group, e.Version)
+}
+
+func groupVersion(group, version string) string {
+	if group == "core" {
+		return version
+	}
+
+	return group + "/" + version
+}
+
+func (e DiffEntry) String() string {
+	name := e.Group
+	if e.Version != "" {
+		name = fmt.Sprintf("%s/%s", e.Group, e.Version)
+	}
+
+	if e.Kind != "" {
+		name = fmt.Sprintf("%s %s", name, e.Kind)
+	}
+
+	if len(e.Details) == 0 {
+		return fmt.Sprintf("%s (%s)", name, e.Type)
+	}
+
+	return fmt.Sprintf("%s (%s: %s)", name, e.Type, strings.Join(e.Details, ", "))
+}
+
+// Diff compares the APIs of any two releases (which do not need to be
+// adjacent, and from can also be newer than to).
+func (o *Timeline) Diff(from, to string) (*ReleaseDiff, error) {
+	for _, release := range []string{from, to} {
+		if !o.HasRelease(release) {
+			return nil, fmt.Errorf("%w %q", ErrUnknownRelease, release)
+		}
+	}
+
+	diff := &ReleaseDiff{
+		From: from,
+		To:   to,
+	}
+
+	for _, apiGroup := range o.APIGroups {
+		inFrom, inTo := groupHasRelease(&apiGroup, from), groupHasRelease(&apiGroup, to)
+
+		switch {
+		case !inFrom && !inTo:
+			continue
+		case !inFrom:
+			diff.Groups = append(diff.Groups, DiffEntry{Type: DiffAdded, Group: apiGroup.Name})
+		case !inTo:
+			diff.Groups = append(diff.Groups, DiffEntry{Type: DiffRemoved, Group: apiGroup.Name})
+		default:
+			details := []string{}
+			details = appendChange(details, "preferred version", apiGroup.PreferredVersion(from), apiGroup.PreferredVersion(to))
+
+			if len(details) > 0 {
+				diff.Groups = append(diff.Groups, DiffEntry{Type: DiffChanged, Group: apiGroup.Name, Details: details})
+			}
+		}
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			diffAPIVersion(diff, &apiGroup, &apiVersion)
+		}
+	}
+
+	return diff, nil
+}
+
+func diffAPIVersion(diff *ReleaseDiff, apiGroup *APIGroup, apiVersion *APIVersion) {
+	from, to := diff.From, diff.To
+
+	switch inFrom, inTo := apiVersion.HasRelease(from), apiVersion.HasRelease(to); {
+	case !inFrom && !inTo:
+		return
+	case !inFrom:
+		diff.Versions = append(diff.Versions, DiffEntry{Type: DiffAdded, Group: apiGroup.Name, Version: apiVersion.Version})
+	case !inTo:
+		diff.Versions = append(diff.Versions, DiffEntry{Type: DiffRemoved, Group: apiGroup.Name, Version: apiVersion.Version})
+	default:
+		details := []string{}
+		details = appendFlagChange(details, "deprecated", apiVersion.IsDeprecatedIn(from), apiVersion.IsDeprecatedIn(to))
+		details = appendChange(details, "feature gate", apiVersion.FeatureGate(from), apiVersion.FeatureGate(to))
+
+		if len(details) > 0 {
+			diff.Versions = append(diff.Versions, DiffEntry{Type: DiffChanged, Group: apiGroup.Name, Version: apiVersion.Version, Details: details})
+		}
+	}
+
+	for _, apiResource := range apiVersion.Resources {
+		entry := DiffEntry{Group: apiGroup.Name, Version: apiVersion.Version, Kind: apiResource.Kind}
+
+		switch inFrom, inTo := apiResource.HasRelease(from), apiResource.HasRelease(to); {
+		case !inFrom && !inTo:
+			continue
+		case !inFrom:
+			entry.Type = DiffAdded
+		case !inTo:
+			entry.Type = DiffRemoved
+		default:
+			entry.Type = DiffChanged
+			entry.Details = resourceChanges(apiGroup, &apiResource, from, to)
+
+			if len(entry.Details) == 0 {
+				continue
+			}
+		}
+
+		diff.Resources = append(diff.Resources, entry)
+	}
+}
+
+func resourceChanges(apiGroup *APIGroup, apiResource *APIResource, from, to string) []string {
+	details := []string{}
+	details = appendChange(details, "scope", apiResource.Scopes[from], apiResource.Scopes[to])
+	details = appendFlagChange(details, "deprecated", apiResource.IsDeprecatedIn(from), apiResource.IsDeprecatedIn(to))
+	details = appendFlagChange(details, "conformance tested", apiResource.ConformanceCovered(from), apiResource.ConformanceCovered(to))
+	details = appendChange(details, "feature gate", apiResource.FeatureGate(from), apiResource.FeatureGate(to))
+
+	// these are only known for some data sources, so releases without them
+	// must not be mistaken for changes
+	if fromStorage, toStorage := apiGroup.StorageVersion(from, apiResource.Kind), apiGroup.StorageVersion(to, apiResource.Kind); fromStorage != "" && toStorage != "" {
+		details = appendChange(details, "storage version", fromStorage, toStorage)
+	}
+
+	fromShortNames, fromKnown := apiResource.ShortNames[from]
+	toShortNames, toKnown := apiResource.ShortNames[to]
+
+	if fromKnown && toKnown {
+		details = appendSetChange(details, "short names", fromShortNames, toShortNames)
+		details = appendSetChange(details, "categories", apiResource.Categories[from], apiResource.Categories[to])
+	}
+
+	return details
+}
+
+func groupHasRelease(apiGroup *APIGroup, release string) bool {
+	for _, apiVersion := range apiGroup.APIVersions {
+		if apiVersion.HasRelease(release) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func appendChange(details []string, name string, from, to string) []string {
+	if from == to {
+		return details
+	}
+
+	return append(details, fmt.Sprintf("%s: %s → %s", name, orNone(from), orNone(to)))
+}
+
+func appendFlagChange(details []string, name string, from, to bool) []string {
+	switch {
+	case !from && to:
+		return append(details, name)
+	case from && !to:
+		return append(details, "no longer "+name)
+	default:
+		return details
+	}
+}
+
+func appendSetChange(details []string, name string, from, to []string) []string {
+	fromSet, toSet := sets.New(from...), sets.New(to...)
+
+	if added := sets.List(toSet.Difference(fromSet)); len(added) > 0 {
+		details = append(details, fmt.Sprintf("added %s: %s", name, strings.Join(added, ", ")))
+	}
+
+	if removed := sets.List(fromSet.Difference(toSet)); len(removed) > 0 {
+		details = append(details, fmt.Sprintf("removed %s: %s", name, strings.Join(removed, ", ")))
+	}
+
+	return details
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+
+	return s
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tl := testTimeline()
+	tl.APIGroups[0].PreferredVersions = map[string]string{"1.24": "v1", "1.25": "v1", "1.26": "v1"}
+	tl.APIGroups[0].APIVersions[1].Resources[0].Scopes = map[string]string{"1.24": "Namespaced", "1.25": "Namespaced", "1.26": "Namespaced"}
+	tl.APIGroups[0].APIVersions[1].Resources[0].DeprecatedIn = "1.26"
+
+	diff, err := tl.Diff("1.24", "1.26")
+	if err != nil {
+		t.Fatalf("Failed to diff releases: %v", err)
+	}
+
+	if len(diff.Groups) != 0 {
+		t.Errorf("Expected no group changes, got %v.", diff.Groups)
+	}
+
+	expectedVersions := []string{"batch/v1beta1 (removed)"}
+	if versions := diffStrings(diff.Versions); !reflect.DeepEqual(versions, expectedVersions) {
+		t.Errorf("Expected versions %v, got %v.", expectedVersions, versions)
+	}
+
+	expectedResources := []string{
+		"batch/v1beta1 CronJob (removed)",
+		"batch/v1 CronJob (changed: deprecated)",
+		"batch/v1 Job (removed)",
+	}
+	if resources := diffStrings(diff.Resources); !reflect.DeepEqual(resources, expectedResources) {
+		t.Errorf("Expected resources %v, got %v.", expectedResources, resources)
+	}
+
+	// diffing backwards turns removals into additions
+	reverse, err := tl.Diff("1.26", "1.25")
+	if err != nil {
+		t.Fatalf("Failed to diff releases: %v", err)
+	}
+
+	expectedResources = []string{"batch/v1 CronJob (changed: no longer deprecated)", "batch/v1 Job (added)"}
+	if resources := diffStrings(reverse.Resources); !reflect.DeepEqual(resources, expectedResources) {
+		t.Errorf("Expected resources %v, got %v.", expectedResources, resources)
+	}
+
+	if _, err := tl.Diff("1.24", "1.99"); !errors.Is(err, ErrUnknownRelease) {
+		t.Errorf("Expected ErrUnknownRelease, got %v.", err)
+	}
+}
+
+func diffStrings(entries []DiffEntry) []string {
+	result := []string{}
+	for _, entry := range entries {
+		result = append(result, entry.String())
+	}
+
+	return result
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+// IsDisappearedIn returns true if the entire API group stopped being served
+// in the given release.
+func (o *APIGroup) IsDisappearedIn(release string) bool {
+	return contains(o.Disappearances, release)
+}
+
+// IsDisappearedIn returns true if the entire API version stopped being served
+// in the given release.
+func (o *APIVersion) IsDisappearedIn(release string) bool {
+	return contains(o.Disappearances, release)
+}
+
+// calculateDisappearances records the releases in which an entire API group
+// or version stopped being served. These are more severe than the removal of
+// single resources (see ReleasesOfInterest), because every client of the
+// group or version breaks. Projected releases are ignored.
+func calculateDisappearances(tl *Timeline) {
+	for i, apiGroup := range tl.APIGroups {
+		groupServed := false
+
+		for r, release := range tl.Releases {
+			if release.Projected {
+				break
+			}
+
+			served := false
+
+			for j, apiVersion := range apiGroup.APIVersions {
+				if apiVersion.HasRelease(release.Version) {
+					served = true
+					continue
+				}
+
+				if r > 0 && apiVersion.HasRelease(tl.Releases[r-1].Version) {
+					tl.APIGroups[i].APIVersions[j].Disappearances = append(tl.APIGroups[i].APIVersions[j].Disappearances, release.Version)
+				}
+			}
+
+			if groupServed && !served {
+				tl.APIGroups[i].Disappearances = append(tl.APIGroups[i].Disappearances, release.Version)
+			}
+
+			groupServed = served
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateDisappearances(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.18"},
+			{Version: "1.19"},
+			{Version: "1.20"},
+			{Version: "1.21"},
+			{Version: "1.22"},
+			{Version: "1.23", Projected: true},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "settings.k8s.io",
+				APIVersions: []APIVersion{
+					{
+						Version:  "v1alpha1",
+						Releases: []string{"1.18", "1.19"},
+					},
+				},
+			},
+			{
+				Name: "flowcontrol.apiserver.k8s.io",
+				APIVersions: []APIVersion{
+					{
+						Version:  "v1alpha1",
+						Releases: []string{"1.18", "1.19", "1.20"},
+					},
+					{
+						// gone for a release, then back
+						Version:  "v1beta1",
+						Releases: []string{"1.19", "1.21", "1.22"},
+					},
+					{
+						// only removed in a projected release
+						Version:  "v1beta2",
+						Releases: []string{"1.21", "1.22"},
+					},
+				},
+			},
+		},
+	}
+
+	calculateDisappearances(tl)
+
+	settings := tl.APIGroups[0]
+	if expected := []string{"1.20"}; !reflect.DeepEqual(settings.Disappearances, expected) {
+		t.Errorf("Expected settings.k8s.io to disappear in %v, got %v.", expected, settings.Disappearances)
+	}
+
+	if expected := []string{"1.20"}; !reflect.DeepEqual(settings.APIVersions[0].Disappearances, expected) {
+		t.Errorf("Expected settings.k8s.io/v1alpha1 to disappear in %v, got %v.", expected, settings.APIVersions[0].Disappearances)
+	}
+
+	flowcontrol := tl.APIGroups[1]
+	if flowcontrol.Disappearances != nil {
+		t.Errorf("Expected flowcontrol.apiserver.k8s.io to be served continuously, got %v.", flowcontrol.Disappearances)
+	}
+
+	for i, expected := range [][]string{{"1.21"}, {"1.20"}, nil} {
+		if actual := flowcontrol.APIVersions[i].Disappearances; !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Expected %s to disappear in %v, got %v.", flowcontrol.APIVersions[i].Version, expected, actual)
+		}
+	}
+
+	if !settings.IsDisappearedIn("1.20") || settings.IsDisappearedIn("1.21") {
+		t.Error("Expected settings.k8s.io to have disappeared in 1.20 only.")
+	}
+}
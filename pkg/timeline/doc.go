@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+/*
+Package timeline merges the API data of many Kubernetes releases into a
+single Timeline, which describes for every API group, version and resource
+in which releases it was available, plus derived information like releases
+with notable changes ("releases of interest") and archival status.
+
+	releases := []*database.KubernetesRelease{…}
+
+	tl, err := timeline.CreateTimeline(ctx, releases, timeline.WithRecentReleases(5))
+	if err != nil {
+		// handle error
+	}
+
+	for _, apiGroup := range tl.APIGroups {
+		fmt.Println(apiGroup.Name)
+	}
+
+This package is part of the supported Go API of kube-api.ninja and follows
+semantic versioning, see the README for details.
+*/
+package timeline
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Starting with this release, new beta APIs are no longer enabled by default
+// (see KEP-3136); beta APIs that existed before keep their previous behaviour.
+const betaDisabledByDefaultSince = "1.24"
+
+// calculateDefaultEnablement decides for every release whether an API version
+// or resource is served without configuring the API server, unless the value
+// was curated (see data/enablement.yaml).
+func calculateDefaultEnablement(tl *Timeline) error {
+	for i, apiGroup := range tl.APIGroups {
+		for j, apiVersion := range apiGroup.APIVersions {
+			parsed, err := version.ParseAPIVersion(apiVersion.Version)
+			if err != nil {
+				return fmt.Errorf("invalid API version %s/%s: %w", apiGroup.Name, apiVersion.Version, err)
+			}
+
+			enablement, err := defaultEnablement(parsed, apiVersion.Releases, apiVersion.DefaultEnablement, tl.Releases)
+			if err != nil {
+				return err
+			}
+
+			tl.APIGroups[i].APIVersions[j].DefaultEnablement = enablement
+
+			// resources are judged on their own, as a resource could have been
+			// added to an already existing beta version after the policy change
+			for k, apiResource := range apiVersion.Resources {
+				enablement, err := defaultEnablement(parsed, apiResource.Releases, apiResource.DefaultEnablement, tl.Releases)
+				if err != nil {
+					return err
+				}
+
+				tl.APIGroups[i].APIVersions[j].Resources[k].DefaultEnablement = enablement
+			}
+		}
+	}
+
+	return nil
+}
+
+// defaultEnablement completes the curated values for all given releases. An
+// API that disappears and comes back later (like admissionregistration.k8s.io
+// v1beta1 in 1.28) counts as a new API when it returns.
+func defaultEnablement(apiVersion *version.APIVersion, releases []string, curated map[string]bool, allReleases []ReleaseMetadata) (map[string]bool, error) {
+	served := sets.New(releases...)
+	enablement := map[string]bool{}
+	introduced := ""
+
+	for _, rel := range allReleases {
+		if !served.Has(rel.Version) {
+			introduced = ""
+			continue
+		}
+
+		if introduced == "" {
+			introduced = rel.Version
+		}
+
+		if enabled, ok := curated[rel.Version]; ok {
+			enablement[rel.Version] = enabled
+			continue
+		}
+
+		enabled, err := isEnabledByDefault(apiVersion, introduced)
+		if err != nil {
+			return nil, err
+		}
+
+		enablement[rel.Version] = enabled
+	}
+
+	return enablement, nil
+}
+
+// isEnabledByDefault applies the upstream rules to an API that has been
+// served continuously since the introduced release.
+func isEnabledByDefault(apiVersion *version.APIVersion, introduced string) (bool, error) {
+	switch {
+	case apiVersion.IsGA():
+		return true, nil
+
+	case apiVersion.IsBeta():
+		return releaseLessThan(introduced, betaDisabledByDefaultSince)
+
+	default:
+		// alpha APIs are never enabled by default
+		return false, nil
+	}
+}
+
+// DefaultEnabledIn returns true if the API server serves this version in the
+// given release without being configured.
+func (o *APIVersion) DefaultEnabledIn(release string) bool {
+	return o.DefaultEnablement[release]
+}
+
+// DefaultEnabled returns true if the API server serves this version without
+// being configured in the most recent release that has it.
+func (o *APIVersion) DefaultEnabled() bool {
+	return o.DefaultEnablement[latestRelease(o.Releases)]
+}
+
+// DefaultEnabledIn returns true if the API server serves this resource in the
+// given release without being configured.
+func (o *APIResource) DefaultEnabledIn(release string) bool {
+	return o.DefaultEnablement[release]
+}
+
+// DefaultEnabled returns true if the API server serves this resource without
+// being configured in the most recent release that has it.
+func (o *APIResource) DefaultEnabled() bool {
+	return o.DefaultEnablement[latestRelease(o.Releases)]
+}
+
+// latestRelease returns the newest of the given minor releases, ignoring
+// malformed ones.
+func latestRelease(releases []string) string {
+	latest := ""
+	for _, rel := range releases {
+		if latest == "" {
+			latest = rel
+			continue
+		}
+
+		if newer, err := releaseLessThan(latest, rel); err == nil && newer {
+			latest = rel
+		}
+	}
+
+	return latest
+}
+
+// releaseLessThan compares two minor releases like "1.9" and "1.24".
+func releaseLessThan(a, b string) (bool, error) {
+	aVersion, err := version.ParseSemver(fmt.Sprintf("v%s.0", a))
+	if err != nil {
+		return false, fmt.Errorf("%w: invalid release %q: %v", ErrMalformedVersion, a, err)
+	}
+
+	bVersion, err := version.ParseSemver(fmt.Sprintf("v%s.0", b))
+	if err != nil {
+		return false, fmt.Errorf("%w: invalid release %q: %v", ErrMalformedVersion, b, err)
+	}
+
+	return aVersion.LessThan(bVersion), nil
+}
+
+// FeatureGate returns the feature gate that must be enabled to serve this
+// version in the given release, if any.
+func (o *APIVersion) FeatureGate(release string) string {
+	return o.FeatureGates[release]
+}
+
+// FeatureGate returns the feature gate that must be enabled to serve this
+// resource in the given release, if any.
+func (o *APIResource) FeatureGate(release string) string {
+	return o.FeatureGates[release]
+}
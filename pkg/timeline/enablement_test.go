@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateDefaultEnablement(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.9"},
+			{Version: "1.21"},
+			{Version: "1.23"},
+			{Version: "1.24"},
+			{Version: "1.26"},
+			{Version: "1.28"},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "flowcontrol.apiserver.k8s.io",
+				APIVersions: []APIVersion{
+					{
+						// beta before the policy change
+						Version:  "v1beta2",
+						Releases: []string{"1.23", "1.24", "1.26", "1.28"},
+					},
+					{
+						// curated exception
+						Version:           "v1beta3",
+						Releases:          []string{"1.26", "1.28"},
+						DefaultEnablement: map[string]bool{"1.26": true, "1.28": true},
+						Resources: []APIResource{
+							{Kind: "FlowSchema", Releases: []string{"1.26", "1.28"}, DefaultEnablement: map[string]bool{"1.26": true, "1.28": true}},
+						},
+					},
+				},
+			},
+			{
+				Name: "admissionregistration.k8s.io",
+				APIVersions: []APIVersion{
+					{
+						// removed in 1.22 and back in 1.28
+						Version:  "v1beta1",
+						Releases: []string{"1.9", "1.21", "1.28"},
+						Resources: []APIResource{
+							{Kind: "MutatingWebhookConfiguration", Releases: []string{"1.9", "1.21"}},
+							{Kind: "ValidatingAdmissionPolicy", Releases: []string{"1.28"}},
+						},
+					},
+					{
+						Version:  "v1alpha1",
+						Releases: []string{"1.26", "1.28"},
+					},
+					{
+						Version:  "v1",
+						Releases: []string{"1.21", "1.23", "1.24", "1.26", "1.28"},
+					},
+				},
+			},
+		},
+	}
+
+	if err := calculateDefaultEnablement(tl); err != nil {
+		t.Fatalf("Failed to calculate default enablement: %v", err)
+	}
+
+	flowcontrol := tl.APIGroups[0]
+	admission := tl.APIGroups[1]
+
+	testcases := []struct {
+		name     string
+		actual   map[string]bool
+		expected map[string]bool
+	}{
+		{
+			name:     "beta introduced before 1.24",
+			actual:   flowcontrol.APIVersions[0].DefaultEnablement,
+			expected: map[string]bool{"1.23": true, "1.24": true, "1.26": true, "1.28": true},
+		},
+		{
+			name:     "curated beta",
+			actual:   flowcontrol.APIVersions[1].DefaultEnablement,
+			expected: map[string]bool{"1.26": true, "1.28": true},
+		},
+		{
+			name:     "resource of a curated beta",
+			actual:   flowcontrol.APIVersions[1].Resources[0].DefaultEnablement,
+			expected: map[string]bool{"1.26": true, "1.28": true},
+		},
+		{
+			name:     "beta that came back after 1.24",
+			actual:   admission.APIVersions[0].DefaultEnablement,
+			expected: map[string]bool{"1.9": true, "1.21": true, "1.28": false},
+		},
+		{
+			name:     "old resource of a beta",
+			actual:   admission.APIVersions[0].Resources[0].DefaultEnablement,
+			expected: map[string]bool{"1.9": true, "1.21": true},
+		},
+		{
+			name:     "new resource of a beta",
+			actual:   admission.APIVersions[0].Resources[1].DefaultEnablement,
+			expected: map[string]bool{"1.28": false},
+		},
+		{
+			name:     "alpha",
+			actual:   admission.APIVersions[1].DefaultEnablement,
+			expected: map[string]bool{"1.26": false, "1.28": false},
+		},
+		{
+			name:     "GA",
+			actual:   admission.APIVersions[2].DefaultEnablement,
+			expected: map[string]bool{"1.21": true, "1.23": true, "1.24": true, "1.26": true, "1.28": true},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.actual, tc.expected) {
+				t.Errorf("Expected %v, got %v.", tc.expected, tc.actual)
+			}
+		})
+	}
+
+	if beta := admission.APIVersions[0]; !beta.DefaultEnabledIn("1.21") || beta.DefaultEnabled() {
+		t.Error("Expected admissionregistration.k8s.io/v1beta1 to be enabled by default in 1.21, but not in its latest release.")
+	}
+}
@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import "go.xrstf.de/kube-api.ninja/pkg/database"
+
+// These are the same errors as in the database package, so callers can
+// use errors.Is() regardless of where an error originated.
+var (
+	ErrUnknownRelease   = database.ErrUnknownRelease
+	ErrMalformedVersion = database.ErrMalformedVersion
+	ErrMalformedDate    = database.ErrMalformedDate
+	ErrMissingEOL       = database.ErrMissingEOL
+)
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// WriteICalendar writes an iCalendar (RFC 5545) file with all-day events for
+// the release and end of life dates of all releases, so they can be
+// subscribed to from calendar applications. Projected releases are skipped,
+// their dates are only guesses.
+func (o *Timeline) WriteICalendar(w io.Writer, name string) error {
+	writer := bufio.NewWriter(w)
+
+	line := func(format string, args ...any) {
+		// iCalendar requires CRLF line endings
+		fmt.Fprintf(writer, format+"\r\n", args...)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//kube-api.ninja//Kubernetes Releases//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:%s", escapeICalText(name))
+
+	for _, release := range o.Releases {
+		if release.Projected {
+			continue
+		}
+
+		summary := fmt.Sprintf("Kubernetes %s release", release.Version)
+		status := "CONFIRMED"
+		if !release.Released {
+			// dates of upcoming releases can still change
+			status = "TENTATIVE"
+		}
+
+		writeICalEvent(line, "release-"+release.Version, release.ReleaseDate, summary, status)
+
+		if release.EndOfLifeDate != nil {
+			summary := fmt.Sprintf("Kubernetes %s end of life", release.Version)
+			writeICalEvent(line, "eol-"+release.Version, *release.EndOfLifeDate, summary, "CONFIRMED")
+		}
+	}
+
+	line("END:VCALENDAR")
+
+	return writer.Flush()
+}
+
+func writeICalEvent(line func(string, ...any), id string, date time.Time, summary string, status string) {
+	// the timestamp is derived from the date to keep the output reproducible
+	line("BEGIN:VEVENT")
+	line("UID:%s@kube-api.ninja", id)
+	line("DTSTAMP:%s", date.UTC().Format("20060102T150405Z"))
+	line("DTSTART;VALUE=DATE:%s", date.Format("20060102"))
+	line("DTEND;VALUE=DATE:%s", date.AddDate(0, 0, 1).Format("20060102"))
+	line("SUMMARY:%s", escapeICalText(summary))
+	line("STATUS:%s", status)
+	line("TRANSP:TRANSPARENT")
+	line("END:VEVENT")
+}
+
+func escapeICalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
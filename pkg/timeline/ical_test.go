@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteICalendar(t *testing.T) {
+	eol := time.Date(2024, 10, 28, 0, 0, 0, 0, time.UTC)
+
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.28", Released: true, ReleaseDate: time.Date(2023, 8, 15, 0, 0, 0, 0, time.UTC), EndOfLifeDate: &eol},
+			{Version: "1.29", ReleaseDate: time.Date(2023, 12, 13, 0, 0, 0, 0, time.UTC)},
+			{Version: "1.30", Projected: true, ReleaseDate: time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := tl.WriteICalendar(&buf, "Releases, mine"); err != nil {
+		t.Fatalf("Failed to write calendar: %v", err)
+	}
+
+	output := buf.String()
+
+	for _, expected := range []string{
+		"X-WR-CALNAME:Releases\\, mine\r\n",
+		"UID:release-1.28@kube-api.ninja\r\nDTSTAMP:20230815T000000Z\r\nDTSTART;VALUE=DATE:20230815\r\nDTEND;VALUE=DATE:20230816\r\n",
+		"SUMMARY:Kubernetes 1.28 end of life\r\n",
+		"SUMMARY:Kubernetes 1.29 release\r\nSTATUS:TENTATIVE\r\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	if strings.Contains(output, "1.30") {
+		t.Error("Expected projected release to be skipped.")
+	}
+
+	if events := strings.Count(output, "BEGIN:VEVENT"); events != 3 {
+		t.Errorf("Expected 3 events, got %d.", events)
+	}
+}
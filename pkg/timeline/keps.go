@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+// KEPLink points to a Kubernetes Enhancement Proposal.
+type KEPLink struct {
+	Number int
+	Title  string
+	URL    string
+}
+
+// KEPsIn returns the KEPs linked to the changes of the resource in the given
+// release, if it is one of its releases of interest.
+func (o *APIResource) KEPsIn(release string) []KEPLink {
+	return o.KEPs[release]
+}
+
+// applyKEPs links every KEP to the releases of interest of all resources it
+// applies to.
+func applyKEPs(tl *Timeline, keps []types.KEP) {
+	if len(keps) == 0 {
+		return
+	}
+
+	for i, apiGroup := range tl.APIGroups {
+		for j, apiVersion := range apiGroup.APIVersions {
+			for k, apiResource := range apiVersion.Resources {
+				links := []KEPLink{}
+
+				for _, kep := range keps {
+					if kep.Matches(apiGroup.Name, apiVersion.Version, apiResource.Kind) {
+						links = append(links, KEPLink{
+							Number: kep.Number,
+							Title:  kep.Title,
+							URL:    kep.Link(),
+						})
+					}
+				}
+
+				if len(links) == 0 || len(apiResource.ReleasesOfInterest) == 0 {
+					continue
+				}
+
+				resourceKEPs := map[string][]KEPLink{}
+				for _, release := range apiResource.ReleasesOfInterest {
+					resourceKEPs[release] = links
+				}
+
+				tl.APIGroups[i].APIVersions[j].Resources[k].KEPs = resourceKEPs
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+func TestApplyKEPs(t *testing.T) {
+	tl := &Timeline{
+		APIGroups: []APIGroup{{
+			Name: "batch",
+			APIVersions: []APIVersion{{
+				Version: "v1",
+				Resources: []APIResource{
+					{Kind: "CronJob", ReleasesOfInterest: []string{"1.21"}},
+					{Kind: "Job"},
+				},
+			}, {
+				Version: "v1beta1",
+				Resources: []APIResource{
+					{Kind: "CronJob", ReleasesOfInterest: []string{"1.8", "1.25"}},
+				},
+			}},
+		}},
+	}
+
+	keps := []types.KEP{
+		{
+			Number: 19,
+			Title:  "CronJobs",
+			APIs:   []types.KEPAPI{{Group: "batch", Kind: "CronJob"}},
+		},
+		{
+			Number: 4242,
+			Title:  "Custom link",
+			URL:    "https://example.com/kep",
+			APIs:   []types.KEPAPI{{Group: "batch", Version: "v1"}},
+		},
+	}
+
+	applyKEPs(tl, keps)
+
+	cronJobLink := KEPLink{Number: 19, Title: "CronJobs", URL: "https://github.com/kubernetes/enhancements/issues/19"}
+	customLink := KEPLink{Number: 4242, Title: "Custom link", URL: "https://example.com/kep"}
+
+	testcases := []struct {
+		version  int
+		resource int
+		release  string
+		expected []KEPLink
+	}{
+		{version: 0, resource: 0, release: "1.21", expected: []KEPLink{cronJobLink, customLink}},
+		{version: 0, resource: 0, release: "1.22", expected: nil},
+		// Job has no releases of interest
+		{version: 0, resource: 1, release: "1.21", expected: nil},
+		{version: 1, resource: 0, release: "1.25", expected: []KEPLink{cronJobLink}},
+	}
+
+	for _, tc := range testcases {
+		resource := tl.APIGroups[0].APIVersions[tc.version].Resources[tc.resource]
+
+		if links := resource.KEPsIn(tc.release); !reflect.DeepEqual(links, tc.expected) {
+			t.Errorf("Expected KEPs of %s %s in %s to be %+v, got %+v.", tl.APIGroups[0].APIVersions[tc.version].Version, resource.Kind, tc.release, tc.expected, links)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+// ResourceLifecycle summarizes the history of a resource kind within its API
+// group, across all of the group's API versions.
+type ResourceLifecycle struct {
+	// Introduced is the first release that offered the resource.
+	Introduced string
+	// VersionChanges lists the releases in which the preferred API version
+	// serving the resource changed.
+	VersionChanges []VersionChange
+	// Removed is the first release that did not offer the resource anymore;
+	// empty if the resource is still available in the latest release.
+	Removed string
+}
+
+type VersionChange struct {
+	Release string
+	From    string
+	To      string
+}
+
+func calculateLifecycles(tl *Timeline) error {
+	for i, apiGroup := range tl.APIGroups {
+		// collect the API versions per kind and release
+		versionsPerKind := map[string]map[string][]string{}
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				if versionsPerKind[apiResource.Kind] == nil {
+					versionsPerKind[apiResource.Kind] = map[string][]string{}
+				}
+
+				for _, release := range apiResource.Releases {
+					versionsPerKind[apiResource.Kind][release] = append(versionsPerKind[apiResource.Kind][release], apiVersion.Version)
+				}
+			}
+		}
+
+		lifecycles := map[string]*ResourceLifecycle{}
+		for kind, versions := range versionsPerKind {
+			lifecycle, err := calculateLifecycle(versions, tl.Releases)
+			if err != nil {
+				return fmt.Errorf("failed to determine lifecycle of %s/%s: %w", apiGroup.Name, kind, err)
+			}
+
+			lifecycles[kind] = lifecycle
+		}
+
+		for j, apiVersion := range apiGroup.APIVersions {
+			for k, apiResource := range apiVersion.Resources {
+				tl.APIGroups[i].APIVersions[j].Resources[k].Lifecycle = lifecycles[apiResource.Kind]
+			}
+		}
+	}
+
+	return nil
+}
+
+// calculateLifecycle takes the API versions that offer a resource in each
+// release and turns them into a summary.
+func calculateLifecycle(versions map[string][]string, releases []ReleaseMetadata) (*ResourceLifecycle, error) {
+	lifecycle := &ResourceLifecycle{
+		VersionChanges: []VersionChange{},
+	}
+
+	preferred := ""
+	for _, release := range releases {
+		// speculative releases are not part of the history
+		if release.Projected {
+			continue
+		}
+
+		available := versions[release.Version]
+
+		if len(available) == 0 {
+			if lifecycle.Introduced != "" && lifecycle.Removed == "" {
+				lifecycle.Removed = release.Version
+			}
+
+			continue
+		}
+
+		// a resource that comes back is not removed anymore
+		lifecycle.Removed = ""
+
+		parsed, err := version.PreferredAPIVersion(available)
+		if err != nil {
+			return nil, err
+		}
+		current := parsed.String()
+
+		if lifecycle.Introduced == "" {
+			lifecycle.Introduced = release.Version
+		} else if current != preferred {
+			lifecycle.VersionChanges = append(lifecycle.VersionChanges, VersionChange{
+				Release: release.Version,
+				From:    preferred,
+				To:      current,
+			})
+		}
+
+		preferred = current
+	}
+
+	return lifecycle, nil
+}
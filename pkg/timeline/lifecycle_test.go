@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateLifecycles(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.20"},
+			{Version: "1.21"},
+			{Version: "1.22"},
+			{Version: "1.23"},
+			{Version: "1.24", Projected: true},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "batch",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "Job", Releases: []string{"1.20", "1.21", "1.22", "1.23", "1.24"}},
+							{Kind: "CronJob", Releases: []string{"1.21", "1.22", "1.23", "1.24"}},
+						},
+					},
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.20", "1.21", "1.22", "1.23"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "extensions",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "Ingress", Releases: []string{"1.20", "1.21"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := calculateLifecycles(tl); err != nil {
+		t.Fatalf("Failed to calculate lifecycles: %v", err)
+	}
+
+	testcases := []struct {
+		group    int
+		version  int
+		resource int
+		expected ResourceLifecycle
+	}{
+		{
+			group: 0, version: 0, resource: 0,
+			expected: ResourceLifecycle{Introduced: "1.20", VersionChanges: []VersionChange{}},
+		},
+		{
+			group: 0, version: 0, resource: 1,
+			expected: ResourceLifecycle{Introduced: "1.20", VersionChanges: []VersionChange{{Release: "1.21", From: "v1beta1", To: "v1"}}},
+		},
+		{
+			group: 1, version: 0, resource: 0,
+			expected: ResourceLifecycle{Introduced: "1.20", VersionChanges: []VersionChange{}, Removed: "1.22"},
+		},
+	}
+
+	for _, tc := range testcases {
+		resource := tl.APIGroups[tc.group].APIVersions[tc.version].Resources[tc.resource]
+
+		if resource.Lifecycle == nil {
+			t.Errorf("%s: no lifecycle calculated", resource.Kind)
+			continue
+		}
+
+		if !reflect.DeepEqual(*resource.Lifecycle, tc.expected) {
+			t.Errorf("%s: expected %+v, got %+v", resource.Kind, tc.expected, *resource.Lifecycle)
+		}
+	}
+
+	// all versions of a kind share the same lifecycle
+	if tl.APIGroups[0].APIVersions[0].Resources[1].Lifecycle != tl.APIGroups[0].APIVersions[1].Resources[0].Lifecycle {
+		t.Error("Expected CronJob in v1 and v1beta1 to share the same lifecycle.")
+	}
+}
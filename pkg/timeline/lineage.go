@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// GroupLink connects two API groups whose resources moved from one to the
+// other, like "extensions" to "apps".
+type GroupLink struct {
+	Group string
+	// Kinds are the resources that moved, sorted alphabetically.
+	Kinds []string
+}
+
+// calculateGroupLineage links API groups that were renamed or split up, based
+// on the resources that were replaced by a version in another group or that
+// migrated to another group. This must happen after the successions and
+// migrations have been calculated.
+func calculateGroupLineage(tl *Timeline) {
+	// group => other group => moved kinds
+	successors := map[string]map[string]sets.Set[string]{}
+	predecessors := map[string]map[string]sets.Set[string]{}
+
+	link := func(links map[string]map[string]sets.Set[string], from, to, kind string) {
+		if links[from] == nil {
+			links[from] = map[string]sets.Set[string]{}
+		}
+
+		if links[from][to] == nil {
+			links[from][to] = sets.New[string]()
+		}
+
+		links[from][to].Insert(kind)
+	}
+
+	for _, apiGroup := range tl.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				if succession := apiResource.SucceededBy; succession != nil && succession.Type == Replacement && succession.Group != apiGroup.Name {
+					link(successors, apiGroup.Name, succession.Group, apiResource.Kind)
+					link(predecessors, succession.Group, apiGroup.Name, apiResource.Kind)
+				}
+
+				if migration := apiResource.MigratedTo; migration != nil {
+					link(successors, apiGroup.Name, migration.Group, apiResource.Kind)
+					link(predecessors, migration.Group, apiGroup.Name, apiResource.Kind)
+				}
+			}
+		}
+	}
+
+	for i, apiGroup := range tl.APIGroups {
+		tl.APIGroups[i].SucceededBy = groupLinks(successors[apiGroup.Name])
+		tl.APIGroups[i].Replaces = groupLinks(predecessors[apiGroup.Name])
+	}
+}
+
+func groupLinks(links map[string]sets.Set[string]) []GroupLink {
+	if len(links) == 0 {
+		return nil
+	}
+
+	result := []GroupLink{}
+	for group, kinds := range links {
+		result = append(result, GroupLink{
+			Group: group,
+			Kinds: sets.List(kinds),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Group < result[j].Group
+	})
+
+	return result
+}
+
+// GroupLineage returns all API groups that are (directly or indirectly)
+// linked to the given group, including the group itself, i.e. all groups
+// that form the same logical API. For example, "apps" returns "apps",
+// "extensions", "networking.k8s.io" and "policy".
+func (o *Timeline) GroupLineage(group string) []string {
+	groups := map[string]*APIGroup{}
+	for i, apiGroup := range o.APIGroups {
+		groups[apiGroup.Name] = &o.APIGroups[i]
+	}
+
+	lineage := sets.New(group)
+	queue := []string{group}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		apiGroup, exists := groups[current]
+		if !exists {
+			continue
+		}
+
+		for _, links := range [][]GroupLink{apiGroup.SucceededBy, apiGroup.Replaces} {
+			for _, link := range links {
+				if !lineage.Has(link.Group) {
+					lineage.Insert(link.Group)
+					queue = append(queue, link.Group)
+				}
+			}
+		}
+	}
+
+	return sets.List(lineage)
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateGroupLineage(t *testing.T) {
+	all := []string{"1.15", "1.16", "1.17"}
+
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.14"},
+			{Version: "1.15"},
+			{Version: "1.16"},
+			{Version: "1.17"},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "apps",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "DaemonSet", Releases: all},
+							{Kind: "Deployment", Releases: all},
+						},
+					},
+				},
+			},
+			{
+				Name: "extensions",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "DaemonSet", Releases: []string{"1.14", "1.15"}},
+							{Kind: "Deployment", Releases: []string{"1.14", "1.15"}},
+							{Kind: "PodSecurityPolicy", Releases: []string{"1.14", "1.15"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "policy",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "PodSecurityPolicy", Releases: all},
+						},
+					},
+				},
+			},
+			{
+				Name: "batch",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "Job", Releases: all},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := calculateSuccessions(tl); err != nil {
+		t.Fatalf("Failed to calculate successions: %v", err)
+	}
+
+	calculateGroupLineage(tl)
+
+	expected := []GroupLink{
+		{Group: "apps", Kinds: []string{"DaemonSet", "Deployment"}},
+		{Group: "policy", Kinds: []string{"PodSecurityPolicy"}},
+	}
+
+	if successors := tl.APIGroups[1].SucceededBy; !reflect.DeepEqual(successors, expected) {
+		t.Errorf("Expected extensions to be succeeded by %+v, got %+v.", expected, successors)
+	}
+
+	expected = []GroupLink{{Group: "extensions", Kinds: []string{"PodSecurityPolicy"}}}
+	if predecessors := tl.APIGroups[2].Replaces; !reflect.DeepEqual(predecessors, expected) {
+		t.Errorf("Expected policy to replace %+v, got %+v.", expected, predecessors)
+	}
+
+	if lineage := tl.GroupLineage("policy"); !reflect.DeepEqual(lineage, []string{"apps", "extensions", "policy"}) {
+		t.Errorf("Expected policy to be linked to apps and extensions, got %v.", lineage)
+	}
+
+	if lineage := tl.GroupLineage("batch"); !reflect.DeepEqual(lineage, []string{"batch"}) {
+		t.Errorf("Expected batch to stand alone, got %v.", lineage)
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+// MaturityProgression lists the releases in which an API group first served
+// an alpha, beta and GA version. Stages a group skipped (like groups that
+// started out as beta) are empty.
+type MaturityProgression struct {
+	Alpha string
+	Beta  string
+	GA    string
+}
+
+// Empty returns true if none of the group's versions was ever served, or
+// only versions with unusual maturities (like "v1gamma1").
+func (p MaturityProgression) Empty() bool {
+	return p.Alpha == "" && p.Beta == "" && p.GA == ""
+}
+
+// calculateMaturityProgressions records, per API group, the first release in
+// which its most mature served version was alpha, beta or GA. Projected
+// releases are ignored.
+func calculateMaturityProgressions(tl *Timeline) error {
+	for i, apiGroup := range tl.APIGroups {
+		parsed := map[string]*version.APIVersion{}
+		for _, apiVersion := range apiGroup.APIVersions {
+			p, err := version.ParseAPIVersion(apiVersion.Version)
+			if err != nil {
+				return fmt.Errorf("invalid API version %s/%s: %w", apiGroup.Name, apiVersion.Version, err)
+			}
+
+			parsed[apiVersion.Version] = p
+		}
+
+		progression := MaturityProgression{}
+
+		for _, release := range tl.Releases {
+			if release.Projected {
+				break
+			}
+
+			var alpha, beta, ga bool
+
+			for _, apiVersion := range apiGroup.APIVersions {
+				if !apiVersion.HasRelease(release.Version) {
+					continue
+				}
+
+				p := parsed[apiVersion.Version]
+				ga = ga || p.IsGA()
+				beta = beta || p.IsBeta()
+				alpha = alpha || p.IsAlpha()
+			}
+
+			switch {
+			case ga:
+				if progression.GA == "" {
+					progression.GA = release.Version
+				}
+			case beta:
+				if progression.Beta == "" {
+					progression.Beta = release.Version
+				}
+			case alpha:
+				if progression.Alpha == "" {
+					progression.Alpha = release.Version
+				}
+			}
+		}
+
+		tl.APIGroups[i].Maturity = progression
+	}
+
+	return nil
+}
+
+// calculateFirstGAReleases records, for every resource, the earliest release
+// in which its kind was served by a GA version of its API group. All versions
+// of a kind share the same release, so that prerelease versions point to
+// when the kind became safe to rely on. Projected releases are ignored.
+func calculateFirstGAReleases(tl *Timeline) error {
+	for i, apiGroup := range tl.APIGroups {
+		// kind => releases served by a GA version
+		gaReleases := map[string][]string{}
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			parsed, err := version.ParseAPIVersion(apiVersion.Version)
+			if err != nil {
+				return fmt.Errorf("invalid API version %s/%s: %w", apiGroup.Name, apiVersion.Version, err)
+			}
+
+			if !parsed.IsGA() {
+				continue
+			}
+
+			for _, apiResource := range apiVersion.Resources {
+				gaReleases[apiResource.Kind] = append(gaReleases[apiResource.Kind], apiResource.Releases...)
+			}
+		}
+
+		firstGA := map[string]string{}
+		for _, release := range tl.Releases {
+			if release.Projected {
+				break
+			}
+
+			for kind, releases := range gaReleases {
+				if _, exists := firstGA[kind]; !exists && contains(releases, release.Version) {
+					firstGA[kind] = release.Version
+				}
+			}
+		}
+
+		for j, apiVersion := range apiGroup.APIVersions {
+			for k, apiResource := range apiVersion.Resources {
+				tl.APIGroups[i].APIVersions[j].Resources[k].FirstGARelease = firstGA[apiResource.Kind]
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"testing"
+)
+
+func TestCalculateMaturityProgressions(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.18"},
+			{Version: "1.19"},
+			{Version: "1.20"},
+			{Version: "1.21"},
+			{Version: "1.22", Projected: true},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "flowcontrol.apiserver.k8s.io",
+				APIVersions: []APIVersion{
+					{Version: "v1", Releases: []string{"1.21", "1.22"}},
+					{Version: "v1beta1", Releases: []string{"1.20", "1.21"}},
+					{Version: "v1alpha1", Releases: []string{"1.18", "1.19", "1.20"}},
+				},
+			},
+			{
+				// started out as beta, and a later alpha does not count
+				Name: "autoscaling",
+				APIVersions: []APIVersion{
+					{Version: "v2alpha1", Releases: []string{"1.20"}},
+					{Version: "v1", Releases: []string{"1.19", "1.20", "1.21"}},
+					{Version: "v1beta1", Releases: []string{"1.18"}},
+				},
+			},
+			{
+				// only GA in a projected release
+				Name: "resource.k8s.io",
+				APIVersions: []APIVersion{
+					{Version: "v1", Releases: []string{"1.22"}},
+					{Version: "v1alpha1", Releases: []string{"1.21", "1.22"}},
+				},
+			},
+		},
+	}
+
+	if err := calculateMaturityProgressions(tl); err != nil {
+		t.Fatalf("Failed to calculate maturity progressions: %v", err)
+	}
+
+	expected := []MaturityProgression{
+		{Alpha: "1.18", Beta: "1.20", GA: "1.21"},
+		{Beta: "1.18", GA: "1.19"},
+		{Alpha: "1.21"},
+	}
+
+	for i, apiGroup := range tl.APIGroups {
+		if apiGroup.Maturity != expected[i] {
+			t.Errorf("Expected %s to progress like %+v, got %+v.", apiGroup.Name, expected[i], apiGroup.Maturity)
+		}
+	}
+}
+
+func TestCalculateFirstGAReleases(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.18"},
+			{Version: "1.19"},
+			{Version: "1.20"},
+			{Version: "1.21", Projected: true},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "autoscaling",
+				APIVersions: []APIVersion{
+					{
+						Version: "v2",
+						Resources: []APIResource{
+							{Kind: "HorizontalPodAutoscaler", Releases: []string{"1.20", "1.21"}},
+						},
+					},
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "HorizontalPodAutoscaler", Releases: []string{"1.19", "1.20", "1.21"}},
+							// only GA in a projected release
+							{Kind: "VerticalPodAutoscaler", Releases: []string{"1.21"}},
+						},
+					},
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "HorizontalPodAutoscaler", Releases: []string{"1.18", "1.19"}},
+							// never GA
+							{Kind: "Scale", Releases: []string{"1.18", "1.19"}},
+							{Kind: "VerticalPodAutoscaler", Releases: []string{"1.20"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := calculateFirstGAReleases(tl); err != nil {
+		t.Fatalf("Failed to calculate first GA releases: %v", err)
+	}
+
+	testcases := []struct {
+		version  int
+		resource int
+		expected string
+	}{
+		{version: 0, resource: 0, expected: "1.19"},
+		{version: 1, resource: 0, expected: "1.19"},
+		{version: 2, resource: 0, expected: "1.19"},
+		{version: 2, resource: 1, expected: ""},
+		{version: 2, resource: 2, expected: ""},
+		{version: 1, resource: 1, expected: ""},
+	}
+
+	for _, tc := range testcases {
+		apiVersion := tl.APIGroups[0].APIVersions[tc.version]
+		resource := apiVersion.Resources[tc.resource]
+
+		if resource.FirstGARelease != tc.expected {
+			t.Errorf("Expected %s %s to be GA in %q, got %q.", apiVersion.Version, resource.Kind, tc.expected, resource.FirstGARelease)
+		}
+	}
+}
@@ -4,8 +4,11 @@
 package timeline
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"go.xrstf.de/kube-api.ninja/pkg/database"
@@ -15,52 +18,254 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
-const (
-	// The number of most recent releases we consider to show by default,
-	// all older releases are "archived"; this is 11 because we want to
-	// show e.g. 1.19..1.29, just because I think it looks nice.
-	numRecentReleases = 11
-)
+// CreateTimeline merges the given releases into a single timeline. Without
+// any options, all releases are included, the 11 most recent ones are not
+// archived and the current time is used to determine the support status.
+func CreateTimeline(ctx context.Context, releases []*database.KubernetesRelease, opts ...Option) (*Timeline, error) {
+	o := newOptions(opts)
 
-func CreateTimeline(releases []*database.KubernetesRelease, now time.Time) (*Timeline, error) {
 	timeline := &Timeline{
 		Releases: []ReleaseMetadata{},
 	}
 
+	// releases outside of the range are only removed at the very end, so
+	// everything derived from the history (e.g. when a resource became GA)
+	// is the same regardless of the range; invalid limits fail early though
+	if err := validateReleaseRange(o.minRelease, o.maxRelease); err != nil {
+		return nil, fmt.Errorf("invalid release range: %w", err)
+	}
+
+	var err error
+
 	// sort releases to keep things consistent
 	sort.Slice(releases, func(i, j int) bool {
 		return releases[i].Semver().LessThan(releases[j].Semver())
 	})
 
+	if o.asOf {
+		releases, err = filterPlannedReleases(releases, o.now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine releases as of %s: %w", o.now.Format("2006-01-02"), err)
+		}
+	}
+
+	// skip merging the APIs of releases that have not changed since the
+	// last run; the metadata depends on the current time and is cheap to
+	// create, so it is never cached
+	var (
+		cache  *mergeCache
+		cached int
+	)
+
+	// release notes are only needed once the releases of interest are known
+	releaseNotes := map[string][]types.ReleaseNote{}
+
+	if o.cacheDirectory != "" {
+		cache, err = newMergeCache(o.cacheDirectory, releases, o.overlays)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare cache: %w", err)
+		}
+
+		timeline.APIGroups, cached, err = cache.load()
+		if err != nil {
+			o.logger.Warn("Failed to load cached releases, merging all releases.", "error", err)
+			timeline.APIGroups, cached = nil, 0
+		}
+	}
+
 	// merge all releases together
-	for _, release := range releases {
-		// data is copied into the overview, so it's okay to have the loop re-use the same variable
-		if err := mergeReleaseIntoOverview(timeline, release, now); err != nil {
-			return nil, fmt.Errorf("failed to process release %s: %w", release.Version(), err)
+	for i, release := range releases {
+		metadata, err := createReleaseMetadata(release, o.now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process release %s: failed to create metadata: %w", release.Version(), err)
+		}
+
+		timeline.Releases = append(timeline.Releases, metadata)
+
+		notes, err := release.ReleaseNotes()
+		if err != nil {
+			return nil, fmt.Errorf("failed to process release %s: failed to load release notes: %w", release.Version(), err)
+		}
+		releaseNotes[release.Version()] = notes
+
+		if i < cached {
+			o.logger.Debug("Using cached release…", "release", release.Version())
+		} else {
+			o.logger.Debug("Merging release…", "release", release.Version())
+
+			// data is copied into the overview, so it's okay to have the loop re-use the same variable
+			if err := mergeReleaseIntoOverview(ctx, timeline, release, o.overlays); err != nil {
+				return nil, fmt.Errorf("failed to process release %s: %w", release.Version(), err)
+			}
+
+			if cache != nil {
+				if err := cache.store(i, timeline.APIGroups); err != nil {
+					o.logger.Warn("Failed to cache release.", "release", release.Version(), "error", err)
+				}
+			}
+		}
+
+		if metadata.Released && metadata.EndOfLifeDate == nil && metadata.LatestVersion == "" {
+			o.logger.Warn("Released release has neither an EOL date nor a latest version.", "release", release.Version())
+		}
+
+		o.progress(i+1, len(releases), release.Version())
+	}
+
+	if len(timeline.APIGroups) == 0 {
+		o.logger.Warn("Timeline contains no API groups.")
+	}
+
+	// nothing was known about the upcoming release back then
+	if o.asOf {
+		for i, rel := range timeline.Releases {
+			if !rel.Released {
+				timeline.Releases[i].EndOfLifeDate = nil
+				timeline.Releases[i].LatestVersion = ""
+				timeline.Releases[i].PreReleaseStage = ""
+				timeline.Releases[i].Advisories = nil
+				timeline.Releases[i].setSupportDates(o.now)
+			} else if latest := rel.LatestPatchRelease(); latest != nil {
+				// latest.txt only knows today's patch release
+				timeline.Releases[i].LatestVersion = latest.Version
+			}
 		}
 	}
 
 	// mark old releases as archived
-	if err := calculateArchivalStatus(timeline); err != nil {
+	if err := calculateArchivalStatus(timeline, o); err != nil {
 		return nil, fmt.Errorf("failed to calculate archival status: %w", err)
 	}
 
+	// extrapolate future releases; this happens after the archival status has
+	// been determined, so projected releases do not shift the archive window
+	if err := addProjectedReleases(timeline, o.projectedReleases, o.now); err != nil {
+		return nil, fmt.Errorf("failed to project future releases: %w", err)
+	}
+
+	// determine which prerelease APIs are usable without enabling them first
+	if err := calculateDefaultEnablement(timeline); err != nil {
+		return nil, fmt.Errorf("failed to calculate default enablement: %w", err)
+	}
+
+	// complete the deprecation data, e.g. resources inherit the deprecation
+	// of their API version and removals are known from the data itself
+	if err := calculateDeprecations(timeline); err != nil {
+		return nil, fmt.Errorf("failed to calculate deprecations: %w", err)
+	}
+
+	// estimate when APIs without a known removal are going to be removed
+	if err := calculatePredictedRemovals(timeline); err != nil {
+		return nil, fmt.Errorf("failed to predict removals: %w", err)
+	}
+
+	// summarize when resources appeared, moved versions and disappeared;
+	// the timeline still contains all releases within the asOf cutoff, so
+	// the summary is not affected by the release range or archival
+	if err := calculateLifecycles(timeline); err != nil {
+		return nil, fmt.Errorf("failed to calculate resource lifecycles: %w", err)
+	}
+
+	// detect renamed short names and changed categories
+	calculateAliasChanges(timeline)
+
+	// link to the official API reference of each release
+	calculateReferenceDocs(timeline)
+
+	// detect when resources started to be persisted in another version
+	calculateStorageVersionChanges(timeline)
+
+	// link resources to the API versions that took them over, so consumers
+	// can show migration paths (e.g. from extensions to networking.k8s.io)
+	if err := calculateSuccessions(timeline); err != nil {
+		return nil, fmt.Errorf("failed to calculate resource successions: %w", err)
+	}
+
+	// detect resources that moved to another API group, even if that group
+	// offered them long before
+	if err := calculateMigrations(timeline); err != nil {
+		return nil, fmt.Errorf("failed to calculate resource migrations: %w", err)
+	}
+
+	// link renamed and split up API groups, so they can be shown as one
+	// logical API
+	calculateGroupLineage(timeline)
+
+	// detect entire API groups and versions disappearing, which is more
+	// severe than the removal of single resources
+	calculateDisappearances(timeline)
+
+	// record when each API group first reached alpha, beta and GA
+	if err := calculateMaturityProgressions(timeline); err != nil {
+		return nil, fmt.Errorf("failed to calculate maturity progressions: %w", err)
+	}
+
+	// record when each resource became GA, regardless of the version
+	if err := calculateFirstGAReleases(timeline); err != nil {
+		return nil, fmt.Errorf("failed to calculate first GA releases: %w", err)
+	}
+
 	// calculate "releases of interest":
 	//   a) an API resource disappears
 	//   b) a more mature version of an API group becomes available
-	if err := calculateReleasesOfInterest(timeline); err != nil {
-		return nil, fmt.Errorf("failed to calculate ROIs: %w", err)
+	//   c) an API resource changes its scope (Namespaced <-> Cluster)
+	if o.releasesOfInterest {
+		if err := calculateReleasesOfInterest(timeline); err != nil {
+			return nil, fmt.Errorf("failed to calculate ROIs: %w", err)
+		}
+
+		// explain the changes with the design documents behind them and
+		// the upstream changelog
+		applyKEPs(timeline, o.keps)
+		attachReleaseNotes(timeline, releaseNotes)
+	}
+
+	if !o.includeArchived {
+		removeArchived(timeline)
+	}
+
+	if o.supportedOnly {
+		removeUnsupported(timeline)
+	}
+
+	if !o.includeUnreleased {
+		removeUnreleased(timeline)
+	}
+
+	if err := removeOutOfRange(timeline, o); err != nil {
+		return nil, fmt.Errorf("failed to apply release range: %w", err)
+	}
+
+	applyAnnotations(timeline, o.annotations)
+
+	// sort API groups alphabetically, but featured groups first
+	featured := map[string]int{}
+	for i, name := range o.featuredGroups {
+		featured[name] = i
+	}
+
+	for i, apiGroup := range timeline.APIGroups {
+		_, timeline.APIGroups[i].Featured = featured[apiGroup.Name]
 	}
 
-	// sort API groups alphabetically
 	sort.Slice(timeline.APIGroups, func(i, j int) bool {
-		return timeline.APIGroups[i].Name < timeline.APIGroups[j].Name
+		a, b := timeline.APIGroups[i], timeline.APIGroups[j]
+
+		if a.Featured != b.Featured {
+			return a.Featured
+		}
+
+		if a.Featured {
+			return featured[a.Name] < featured[b.Name]
+		}
+
+		return a.Name < b.Name
 	})
 
 	// sort versions for each API group in descending order (latest first)
 	for idx, apiGroup := range timeline.APIGroups {
 		sort.Slice(apiGroup.APIVersions, func(i, j int) bool {
-			return version.CompareAPIVersions(apiGroup.APIVersions[j].Version, apiGroup.APIVersions[i].Version)
+			return version.CompareAPIVersions(apiGroup.APIVersions[j].Version, apiGroup.APIVersions[i].Version) < 0
 		})
 
 		timeline.APIGroups[idx] = apiGroup
@@ -69,18 +274,23 @@ func CreateTimeline(releases []*database.KubernetesRelease, now time.Time) (*Tim
 	return timeline, nil
 }
 
-func mergeReleaseIntoOverview(timeline *Timeline, release *database.KubernetesRelease, now time.Time) error {
-	api, err := release.API()
+func mergeReleaseIntoOverview(ctx context.Context, timeline *Timeline, release *database.KubernetesRelease, overlays []*database.ReleaseDatabase) error {
+	api, err := release.API(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to load API: %w", err)
 	}
 
-	metadata, err := createReleaseMetadata(release, now)
+	api, err = applyOverlays(ctx, api, release.Version(), overlays)
 	if err != nil {
-		return fmt.Errorf("failed to create metadata: %w", err)
+		return fmt.Errorf("failed to apply overlays: %w", err)
 	}
 
-	timeline.Releases = append(timeline.Releases, metadata)
+	relCtx, err := newReleaseContext(release)
+	if err != nil {
+		return err
+	}
+
+	relCtx.hasAliases = hasAliases(api)
 
 	// a cluster without any APIs
 	if len(api.APIGroups) == 0 {
@@ -112,7 +322,7 @@ func mergeReleaseIntoOverview(timeline *Timeline, release *database.KubernetesRe
 			existingGroup = &timeline.APIGroups[len(timeline.APIGroups)-1]
 		}
 
-		if err := mergeAPIGroupOverviews(existingGroup, &apiGroup, apiGroupName, release.Version()); err != nil {
+		if err := mergeAPIGroupOverviews(existingGroup, &apiGroup, apiGroupName, relCtx); err != nil {
 			return fmt.Errorf("failed to process API group %s: %w", apiGroupName, err)
 		}
 	}
@@ -120,7 +330,9 @@ func mergeReleaseIntoOverview(timeline *Timeline, release *database.KubernetesRe
 	return nil
 }
 
-func mergeAPIGroupOverviews(dest *APIGroup, groupinfo *types.APIGroup, groupName string, release string) error {
+func mergeAPIGroupOverviews(dest *APIGroup, groupinfo *types.APIGroup, groupName string, relCtx *releaseContext) error {
+	release := relCtx.version
+
 	// copy the name
 	dest.Name = groupName
 
@@ -130,6 +342,25 @@ func mergeAPIGroupOverviews(dest *APIGroup, groupinfo *types.APIGroup, groupName
 	}
 	dest.PreferredVersions[release] = groupinfo.PreferredVersion
 
+	// the storage version is the same for all versions of a kind
+	for _, apiVersion := range groupinfo.APIVersions {
+		for _, resource := range apiVersion.Resources {
+			if resource.StorageVersion == "" {
+				continue
+			}
+
+			if dest.StorageVersions == nil {
+				dest.StorageVersions = map[string]map[string]string{}
+			}
+
+			if dest.StorageVersions[release] == nil {
+				dest.StorageVersions[release] = map[string]string{}
+			}
+
+			dest.StorageVersions[release][resource.Kind] = resource.StorageVersion
+		}
+	}
+
 	// a group without any versions
 	if len(groupinfo.APIVersions) == 0 {
 		return nil
@@ -155,7 +386,7 @@ func mergeAPIGroupOverviews(dest *APIGroup, groupinfo *types.APIGroup, groupName
 			existingVersion = &dest.APIVersions[len(dest.APIVersions)-1]
 		}
 
-		if err := mergeAPIVersionOverviews(existingVersion, &apiVersion, release); err != nil {
+		if err := mergeAPIVersionOverviews(existingVersion, &apiVersion, groupinfo.Name, relCtx); err != nil {
 			return fmt.Errorf("failed to process API version %s: %w", apiVersion.Version, err)
 		}
 	}
@@ -163,11 +394,38 @@ func mergeAPIGroupOverviews(dest *APIGroup, groupinfo *types.APIGroup, groupName
 	return nil
 }
 
-func mergeAPIVersionOverviews(dest *APIVersion, versioninfo *types.APIVersion, release string) error {
+func mergeAPIVersionOverviews(dest *APIVersion, versioninfo *types.APIVersion, groupName string, relCtx *releaseContext) error {
+	release := relCtx.version
+
 	// copy the version
 	dest.Version = versioninfo.Version
 	dest.Releases = append(dest.Releases, release)
 
+	if versioninfo.DeprecatedIn != "" {
+		dest.DeprecatedIn = versioninfo.DeprecatedIn
+	}
+
+	if versioninfo.RemovedIn != "" {
+		dest.RemovedIn = versioninfo.RemovedIn
+	}
+
+	if versioninfo.FeatureGate != "" {
+		if dest.FeatureGates == nil {
+			dest.FeatureGates = map[string]string{}
+		}
+
+		dest.FeatureGates[release] = versioninfo.FeatureGate
+	}
+
+	// curated values; all other releases are filled in later
+	if versioninfo.DefaultEnabled != nil {
+		if dest.DefaultEnablement == nil {
+			dest.DefaultEnablement = map[string]bool{}
+		}
+
+		dest.DefaultEnablement[release] = *versioninfo.DefaultEnabled
+	}
+
 	// a version without any resources
 	if len(versioninfo.Resources) == 0 {
 		return nil
@@ -193,7 +451,7 @@ func mergeAPIVersionOverviews(dest *APIVersion, versioninfo *types.APIVersion, r
 			existingResource = &dest.Resources[len(dest.Resources)-1]
 		}
 
-		if err := mergeAPIResourceOverviews(existingResource, &resource, release); err != nil {
+		if err := mergeAPIResourceOverviews(existingResource, &resource, groupName, relCtx); err != nil {
 			return fmt.Errorf("failed to process API resource %s: %w", resource.Kind, err)
 		}
 	}
@@ -201,14 +459,55 @@ func mergeAPIVersionOverviews(dest *APIVersion, versioninfo *types.APIVersion, r
 	return nil
 }
 
-func mergeAPIResourceOverviews(dest *APIResource, resourceinfo *types.Resource, release string) error {
+func mergeAPIResourceOverviews(dest *APIResource, resourceinfo *types.Resource, groupName string, relCtx *releaseContext) error {
+	release := relCtx.version
+
 	// copy the version
 	dest.Kind = resourceinfo.Kind
 	dest.Plural = resourceinfo.Plural
 	dest.Singular = resourceinfo.Singular
-	dest.Description = resourceinfo.Description
 	dest.Releases = sets.List(sets.New(dest.Releases...).Insert(release))
 
+	switch {
+	case resourceinfo.DeprecatedIn != "":
+		dest.DeprecatedIn = resourceinfo.DeprecatedIn
+	case resourceinfo.Deprecated && dest.DeprecatedIn == "":
+		// releases are merged in order, so this is the first release that
+		// marked the resource as deprecated
+		dest.DeprecatedIn = release
+	}
+
+	if resourceinfo.RemovedIn != "" {
+		dest.RemovedIn = resourceinfo.RemovedIn
+	}
+
+	if resourceinfo.FeatureGate != "" {
+		if dest.FeatureGates == nil {
+			dest.FeatureGates = map[string]string{}
+		}
+
+		dest.FeatureGates[release] = resourceinfo.FeatureGate
+	}
+
+	if resourceinfo.DefaultEnabled != nil {
+		if dest.DefaultEnablement == nil {
+			dest.DefaultEnablement = map[string]bool{}
+		}
+
+		dest.DefaultEnablement[release] = *resourceinfo.DefaultEnabled
+	}
+
+	// releases are merged in order, so the newest description wins, but
+	// releases without one (e.g. dumped by kubectl) must not erase it
+	if resourceinfo.Description != "" {
+		if dest.Descriptions == nil {
+			dest.Descriptions = map[string]string{}
+		}
+
+		dest.Description = resourceinfo.Description
+		dest.Descriptions[release] = resourceinfo.Description
+	}
+
 	// remember the scope, which _could_ technically change between versions and/or releases
 	if dest.Scopes == nil {
 		dest.Scopes = map[string]string{}
@@ -220,12 +519,28 @@ func mergeAPIResourceOverviews(dest *APIResource, resourceinfo *types.Resource,
 		dest.Scopes[release] = "Cluster"
 	}
 
+	// only some data sources know these, so releases without them are not
+	// recorded to not mistake them for removals
+	if relCtx.hasAliases {
+		if dest.ShortNames == nil {
+			dest.ShortNames = map[string][]string{}
+			dest.Categories = map[string][]string{}
+		}
+
+		dest.ShortNames[release] = sets.List(sets.New(resourceinfo.ShortNames...))
+		dest.Categories[release] = sets.List(sets.New(resourceinfo.Categories...))
+	}
+
+	if relCtx.conformance.Has(conformanceKey(groupName, resourceinfo.Kind)) {
+		dest.ConformanceReleases = append(dest.ConformanceReleases, release)
+	}
+
 	return nil
 }
 
 func createReleaseMetadata(release *database.KubernetesRelease, now time.Time) (ReleaseMetadata, error) {
 	endOfLife, err := release.EndOfLifeDate()
-	if err != nil {
+	if err != nil && !errors.Is(err, ErrMissingEOL) {
 		return ReleaseMetadata{}, fmt.Errorf("failed to read EOL date: %w", err)
 	}
 
@@ -239,6 +554,41 @@ func createReleaseMetadata(release *database.KubernetesRelease, now time.Time) (
 		return ReleaseMetadata{}, err
 	}
 
+	patches, err := release.PatchReleases()
+	if err != nil {
+		return ReleaseMetadata{}, fmt.Errorf("failed to read patch releases: %w", err)
+	}
+
+	clients, err := release.ClientVersions()
+	if err != nil {
+		return ReleaseMetadata{}, fmt.Errorf("failed to read client versions: %w", err)
+	}
+
+	runtimes, err := release.RuntimeVersions()
+	if err != nil {
+		return ReleaseMetadata{}, fmt.Errorf("failed to read container runtime versions: %w", err)
+	}
+
+	specs, err := release.SpecVersions()
+	if err != nil {
+		return ReleaseMetadata{}, fmt.Errorf("failed to read spec versions: %w", err)
+	}
+
+	highlights, err := release.Highlights()
+	if err != nil {
+		return ReleaseMetadata{}, fmt.Errorf("failed to read highlights: %w", err)
+	}
+
+	advisories, err := release.Advisories()
+	if err != nil {
+		return ReleaseMetadata{}, fmt.Errorf("failed to read security advisories: %w", err)
+	}
+
+	providers, err := release.ProviderSupport()
+	if err != nil {
+		return ReleaseMetadata{}, fmt.Errorf("failed to read managed provider support: %w", err)
+	}
+
 	eol := endOfLife != nil && now.After(*endOfLife)
 
 	// "!before" is not the same as "after"; on the release
@@ -246,25 +596,50 @@ func createReleaseMetadata(release *database.KubernetesRelease, now time.Time) (
 	released := !now.Before(releaseDate)
 	supported := released && !eol
 
-	return ReleaseMetadata{
+	metadata := ReleaseMetadata{
 		Version:       release.Version(),
 		Released:      released,
 		Supported:     supported,
+		PreRelease:    !released,
 		ReleaseDate:   releaseDate,
 		EndOfLifeDate: endOfLife,
 		LatestVersion: latestVersion,
-	}, nil
+		PatchReleases: convertPatchReleases(patches, now),
+		Clients:       clients,
+		Runtimes:      runtimes,
+		Specs:         specs,
+		Highlights:    highlights,
+		Advisories:    advisories,
+		Providers:     convertProviderSupport(providers, now),
+	}
+
+	if metadata.PreRelease {
+		metadata.PreReleaseStage = getPreReleaseStage(latestVersion)
+	}
+
+	metadata.setSupportDates(now)
+
+	return metadata, nil
 }
 
 func calculateReleasesOfInterest(tl *Timeline) error {
 	for i, apiGroup := range tl.APIGroups {
 		groupSuperset := sets.Set[string]{}
 
+		graduations, err := getGraduationReleases(apiGroup, tl.Releases)
+		if err != nil {
+			return err
+		}
+
 		for j, apiVersion := range apiGroup.APIVersions {
 			versionSuperset := sets.Set[string]{}
 
 			for k, apiResource := range apiVersion.Resources {
 				notableReleases := getReleasesWithNotableChangesForResource(apiResource, tl.Releases)
+
+				if graduation, exists := graduations[apiVersion.Version][apiResource.Kind]; exists {
+					notableReleases = tl.sortedReleases(append(notableReleases, graduation))
+				}
 				if len(notableReleases) > 0 {
 					tl.APIGroups[i].APIVersions[j].Resources[k].ReleasesOfInterest = notableReleases
 					versionSuperset.Insert(notableReleases...)
@@ -273,14 +648,14 @@ func calculateReleasesOfInterest(tl *Timeline) error {
 			}
 
 			if versionSuperset.Len() > 0 {
-				tl.APIGroups[i].APIVersions[j].ReleasesOfInterest = sets.List(versionSuperset)
+				tl.APIGroups[i].APIVersions[j].ReleasesOfInterest = tl.sortedReleases(sets.List(versionSuperset))
 				groupSuperset = groupSuperset.Union(versionSuperset)
 				// fmt.Printf("%s.%s changes in %v\n", apiGroup.Name, apiVersion.Version, sets.List(versionSuperset))
 			}
 		}
 
 		if groupSuperset.Len() > 0 {
-			tl.APIGroups[i].ReleasesOfInterest = sets.List(groupSuperset)
+			tl.APIGroups[i].ReleasesOfInterest = tl.sortedReleases(sets.List(groupSuperset))
 			// fmt.Printf("%s changes in %v\n", apiGroup.Name, sets.List(groupSuperset))
 		}
 	}
@@ -292,37 +667,144 @@ func getReleasesWithNotableChangesForResource(res APIResource, releases []Releas
 	availableInReleases := sets.New(res.Releases...)
 	result := []string{}
 
-	var wasAvailable bool
+	var (
+		wasAvailable bool
+		lastScope    string
+	)
+
 	for i, release := range releases {
+		scope := res.Scopes[release.Version]
+
 		// for the first known release, we cannot determine if
 		// there are breaking changes; this makes the loop quite neat
 		if i > 0 {
 			isAvailable := availableInReleases.Has(release.Version)
 
-			if wasAvailable && !isAvailable {
+			switch {
+			case wasAvailable && !isAvailable:
+				result = append(result, release.Version)
+
+			// a resource that flips between Namespaced and Cluster breaks
+			// all manifests and clients that use it
+			case wasAvailable && isAvailable && scope != "" && lastScope != "" && scope != lastScope:
 				result = append(result, release.Version)
 			}
 		}
 
 		wasAvailable = availableInReleases.Has(release.Version)
+		if scope != "" {
+			lastScope = scope
+		}
 	}
 
 	return result
 }
 
-func calculateArchivalStatus(tl *Timeline) error {
-	totalReleases := len(tl.Releases)
-	archiveThresold := totalReleases - numRecentReleases
+// getGraduationReleases returns the release in which an API version made a
+// kind available in a more mature version than before (e.g. when apps/v1
+// Deployment appeared next to apps/v1beta2), per API version and kind. The
+// first version of a kind is not a graduation. Versions are ranked by their
+// stability first (see version.APIVersion.MoreMatureThan), so that an alpha
+// version of a new major version does not hide a later GA version.
+func getGraduationReleases(apiGroup APIGroup, releases []ReleaseMetadata) (map[string]map[string]string, error) {
+	parsed := map[string]*version.APIVersion{}
+	for _, apiVersion := range apiGroup.APIVersions {
+		p, err := version.ParseAPIVersion(apiVersion.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API version %s/%s: %w", apiGroup.Name, apiVersion.Version, err)
+		}
+
+		parsed[apiVersion.Version] = p
+	}
+
+	// kind => most mature version so far
+	mostMature := map[string]*version.APIVersion{}
+	result := map[string]map[string]string{}
+
+	for _, release := range releases {
+		// versions that appear in the same release must be compared to the
+		// versions of previous releases only
+		current := map[string]*version.APIVersion{}
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				if !apiResource.HasRelease(release.Version) {
+					continue
+				}
+
+				candidate := parsed[apiVersion.Version]
+				best, known := mostMature[apiResource.Kind]
+
+				if known && candidate.MoreMatureThan(best) {
+					if _, exists := result[apiVersion.Version][apiResource.Kind]; !exists {
+						if result[apiVersion.Version] == nil {
+							result[apiVersion.Version] = map[string]string{}
+						}
+
+						result[apiVersion.Version][apiResource.Kind] = release.Version
+					}
+				}
+
+				if previous, exists := current[apiResource.Kind]; !exists || candidate.MoreMatureThan(previous) {
+					current[apiResource.Kind] = candidate
+				}
+			}
+		}
+
+		for kind, candidate := range current {
+			if best, known := mostMature[kind]; !known || candidate.MoreMatureThan(best) {
+				mostMature[kind] = candidate
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// isArchived decides whether a release is archived, either because it is not
+// among the most recent releases or because its end of life is long gone.
+func isArchived(rel ReleaseMetadata, beyondRecent bool, o *options) bool {
+	switch {
+	case !o.archival:
+		return false
+	case o.archiveAfterEOL != nil:
+		return rel.EndOfLifeDate != nil && rel.EndOfLifeDate.Add(*o.archiveAfterEOL).Before(o.now)
+	default:
+		return beyondRecent
+	}
+}
+
+func calculateArchivalStatus(tl *Timeline, o *options) error {
+	outOfRange, err := outOfRangeReleases(tl, o)
+	if err != nil {
+		return err
+	}
+
+	// the recent releases are counted within the release range, as the
+	// releases outside of it are going to be removed anyway
+	totalReleases := len(tl.Releases) - outOfRange.Len()
+	archiveThresold := totalReleases - o.recentReleases
 
 	// mark releases as archived
 	archivedRelases := sets.Set[string]{}
+	position := 0
 	for i, rel := range tl.Releases {
-		if i < archiveThresold {
+		if outOfRange.Has(rel.Version) {
+			continue
+		}
+
+		if isArchived(rel, position < archiveThresold, o) {
 			tl.Releases[i].Archived = true
 			archivedRelases.Insert(rel.Version)
 		}
+
+		position++
 	}
 
+	// resources that only exist in archived releases and releases outside
+	// of the range disappear entirely once both are removed
+	archivedRelases = archivedRelases.Union(outOfRange)
+
 	// based on the list of archived releases, mark resources/versions/groups
 	// as archived if they only show up in archived releases
 	for i, apiGroup := range tl.APIGroups {
@@ -354,3 +836,200 @@ func calculateArchivalStatus(tl *Timeline) error {
 
 	return nil
 }
+
+// validateReleaseRange ensures that both limits of a release range (if
+// given) are valid release versions.
+func validateReleaseRange(min, max string) error {
+	for _, limit := range []string{min, max} {
+		if limit == "" {
+			continue
+		}
+
+		if _, err := releaseLessThan(limit, limit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// outOfRangeReleases returns the versions of all releases in the timeline
+// that are older than the minimum or newer than the maximum release.
+func outOfRangeReleases(tl *Timeline, o *options) (sets.Set[string], error) {
+	result := sets.New[string]()
+
+	for _, rel := range tl.Releases {
+		if o.minRelease != "" {
+			tooOld, err := releaseLessThan(rel.Version, o.minRelease)
+			if err != nil {
+				return nil, err
+			}
+
+			if tooOld {
+				result.Insert(rel.Version)
+				continue
+			}
+		}
+
+		if o.maxRelease != "" {
+			tooNew, err := releaseLessThan(o.maxRelease, rel.Version)
+			if err != nil {
+				return nil, err
+			}
+
+			if tooNew {
+				result.Insert(rel.Version)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// removeOutOfRange drops all releases outside of the configured release
+// range, plus all groups, versions and resources that only exist in them.
+func removeOutOfRange(tl *Timeline, o *options) error {
+	outOfRange, err := outOfRangeReleases(tl, o)
+	if err != nil {
+		return err
+	}
+
+	if outOfRange.Len() > 0 {
+		removeReleases(tl, func(rel ReleaseMetadata) bool {
+			return outOfRange.Has(rel.Version)
+		})
+	}
+
+	return nil
+}
+
+// filterPlannedReleases returns all sorted releases that were released at
+// the given time, plus the one that was being worked on back then.
+func filterPlannedReleases(releases []*database.KubernetesRelease, now time.Time) ([]*database.KubernetesRelease, error) {
+	result := []*database.KubernetesRelease{}
+	for _, release := range releases {
+		releaseDate, err := release.ReleaseDate()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read release date of %s: %w", release.Version(), err)
+		}
+
+		result = append(result, release)
+
+		if now.Before(releaseDate) {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// removeArchived drops all archived releases, groups, versions and resources.
+func removeArchived(tl *Timeline) {
+	releases := []ReleaseMetadata{}
+	for _, rel := range tl.Releases {
+		if !rel.Archived {
+			releases = append(releases, rel)
+		}
+	}
+	tl.Releases = releases
+
+	groups := []APIGroup{}
+	for _, apiGroup := range tl.APIGroups {
+		if apiGroup.Archived {
+			continue
+		}
+
+		versions := []APIVersion{}
+		for _, apiVersion := range apiGroup.APIVersions {
+			if apiVersion.Archived {
+				continue
+			}
+
+			resources := []APIResource{}
+			for _, apiResource := range apiVersion.Resources {
+				if !apiResource.Archived {
+					resources = append(resources, apiResource)
+				}
+			}
+
+			apiVersion.Resources = resources
+			versions = append(versions, apiVersion)
+		}
+
+		apiGroup.APIVersions = versions
+		groups = append(groups, apiGroup)
+	}
+	tl.APIGroups = groups
+}
+
+// removeUnsupported drops all releases that have reached their end of life,
+// plus all groups, versions and resources that only exist in them.
+func removeUnsupported(tl *Timeline) {
+	removeReleases(tl, func(rel ReleaseMetadata) bool {
+		return rel.Released && !rel.Supported
+	})
+}
+
+// getPreReleaseStage returns the kind of build a pre-release version like
+// "1.29.0-beta.1" is, i.e. "alpha", "beta" or "rc".
+func getPreReleaseStage(latestVersion string) string {
+	_, prerelease, found := strings.Cut(latestVersion, "-")
+	if !found {
+		return ""
+	}
+
+	stage, _, _ := strings.Cut(prerelease, ".")
+
+	switch stage {
+	case "alpha", "beta", "rc":
+		return stage
+	default:
+		return ""
+	}
+}
+
+// removeUnreleased drops the upcoming release (but not projected releases),
+// plus all groups, versions and resources that only exist in it.
+func removeUnreleased(tl *Timeline) {
+	removeReleases(tl, func(rel ReleaseMetadata) bool {
+		return !rel.Released && !rel.Projected
+	})
+}
+
+func removeReleases(tl *Timeline, remove func(ReleaseMetadata) bool) {
+	removed := sets.New[string]()
+	releases := []ReleaseMetadata{}
+	for _, rel := range tl.Releases {
+		if remove(rel) {
+			removed.Insert(rel.Version)
+			continue
+		}
+
+		releases = append(releases, rel)
+	}
+	tl.Releases = releases
+
+	groups := []APIGroup{}
+	for _, apiGroup := range tl.APIGroups {
+		versions := []APIVersion{}
+		for _, apiVersion := range apiGroup.APIVersions {
+			resources := []APIResource{}
+			for _, apiResource := range apiVersion.Resources {
+				if sets.New(apiResource.Releases...).Difference(removed).Len() > 0 {
+					resources = append(resources, apiResource)
+				}
+			}
+
+			if sets.New(apiVersion.Releases...).Difference(removed).Len() > 0 {
+				apiVersion.Resources = resources
+				versions = append(versions, apiVersion)
+			}
+		}
+
+		if len(versions) > 0 {
+			apiGroup.APIVersions = versions
+			groups = append(groups, apiGroup)
+		}
+	}
+	tl.APIGroups = groups
+}
@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+)
+
+func TestGetPreReleaseStage(t *testing.T) {
+	testcases := map[string]string{
+		"1.29.0-alpha.0": "alpha",
+		"1.29.0-beta.2":  "beta",
+		"1.29.0-rc.1":    "rc",
+		"1.29.0":         "",
+		"":               "",
+	}
+
+	for latestVersion, expected := range testcases {
+		if stage := getPreReleaseStage(latestVersion); stage != expected {
+			t.Errorf("Expected %q to be a %q build, got %q.", latestVersion, expected, stage)
+		}
+	}
+}
+
+func TestArchivalStatus(t *testing.T) {
+	eol := func(year int) *time.Time {
+		date := time.Date(year, time.June, 1, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name     string
+		opts     []Option
+		archived []string
+	}{
+		{
+			name:     "recent releases",
+			opts:     []Option{WithRecentReleases(2)},
+			archived: []string{"1.20", "1.21"},
+		},
+		{
+			name:     "archive a year after EOL",
+			opts:     []Option{WithArchiveAfterEOL(365 * 24 * time.Hour)},
+			archived: []string{"1.20"},
+		},
+		{
+			name:     "recent releases within the release range",
+			opts:     []Option{WithRecentReleases(2), WithReleaseRange("", "1.22")},
+			archived: []string{"1.20"},
+		},
+		{
+			name:     "never archive",
+			opts:     []Option{WithRecentReleases(2), WithArchival(false)},
+			archived: []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tl := &Timeline{
+				Releases: []ReleaseMetadata{
+					{Version: "1.20", EndOfLifeDate: eol(2022)},
+					{Version: "1.21", EndOfLifeDate: eol(2023)},
+					{Version: "1.22", EndOfLifeDate: eol(2024)},
+					{Version: "1.23"},
+				},
+			}
+
+			if err := calculateArchivalStatus(tl, newOptions(append(tc.opts, WithNow(now)))); err != nil {
+				t.Fatalf("Failed to calculate archival status: %v", err)
+			}
+
+			archived := []string{}
+			for _, rel := range tl.Releases {
+				if rel.Archived {
+					archived = append(archived, rel.Version)
+				}
+			}
+
+			if !reflect.DeepEqual(archived, tc.archived) {
+				t.Errorf("Expected %v to be archived, got %v.", tc.archived, archived)
+			}
+		})
+	}
+}
+
+func TestReleaseRange(t *testing.T) {
+	ctx := context.Background()
+
+	fsys := fstest.MapFS{}
+	for _, release := range []string{"1.21", "1.22", "1.23", "1.24", "1.25"} {
+		fsys["releases/"+release+"/released.txt"] = &fstest.MapFile{Data: []byte("2023-01-01")}
+		fsys["releases/"+release+"/latest.txt"] = &fstest.MapFile{Data: []byte("v" + release + ".0")}
+		fsys["releases/"+release+"/api.json"] = &fstest.MapFile{Data: []byte(fmt.Sprintf(`{
+			"release": %q,
+			"apiGroups": [{
+				"name": "batch",
+				"preferredVersion": "v1",
+				"apiVersions": [
+					{"version": "v1", "resources": [{"kind": "CronJob", "plural": "cronjobs", "namespaced": true}]},
+					{"version": "v1beta1", "resources": [{"kind": "CronJob", "plural": "cronjobs", "namespaced": true}]}
+				]
+			}]
+		}`, release))}
+	}
+
+	releases, err := database.NewReleaseDatabaseFromFS(fsys).LoadReleases(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load releases: %v", err)
+	}
+
+	tl, err := CreateTimeline(ctx, releases, WithReleaseRange("1.24", ""))
+	if err != nil {
+		t.Fatalf("Failed to create timeline: %v", err)
+	}
+
+	versions := []string{}
+	for _, rel := range tl.Releases {
+		versions = append(versions, rel.Version)
+	}
+
+	if expected := []string{"1.24", "1.25"}; !reflect.DeepEqual(versions, expected) {
+		t.Fatalf("Expected releases %v, got %v.", expected, versions)
+	}
+
+	// everything derived from the history must not depend on the range
+	for _, apiVersion := range tl.APIGroups[0].APIVersions {
+		resource := apiVersion.Resources[0]
+
+		if resource.FirstGARelease != "1.21" {
+			t.Errorf("Expected %s CronJob to be GA since 1.21, got %q.", apiVersion.Version, resource.FirstGARelease)
+		}
+
+		if resource.Lifecycle == nil || resource.Lifecycle.Introduced != "1.21" {
+			t.Errorf("Expected %s CronJob to be introduced in 1.21, got %+v.", apiVersion.Version, resource.Lifecycle)
+		}
+
+		if !apiVersion.DefaultEnabledIn("1.24") {
+			t.Errorf("Expected %s to be enabled by default in 1.24.", apiVersion.Version)
+		}
+	}
+
+	if _, err := CreateTimeline(ctx, releases, WithReleaseRange("1.24", "latest")); err == nil {
+		t.Error("Expected an invalid release range to be rejected.")
+	}
+}
+
+func TestReleasesWithNotableChangesForResource(t *testing.T) {
+	releases := []ReleaseMetadata{
+		{Version: "1.20"},
+		{Version: "1.21"},
+		{Version: "1.22"},
+		{Version: "1.23"},
+		{Version: "1.24"},
+	}
+
+	resource := APIResource{
+		Kind:     "Widget",
+		Releases: []string{"1.20", "1.21", "1.22", "1.23"},
+		Scopes: map[string]string{
+			"1.20": "Namespaced",
+			"1.21": "Namespaced",
+			// the scope in 1.22 is unknown, which is not a change
+			"1.23": "Cluster",
+		},
+	}
+
+	expected := []string{"1.23", "1.24"}
+	if notable := getReleasesWithNotableChangesForResource(resource, releases); !reflect.DeepEqual(notable, expected) {
+		t.Errorf("Expected notable changes in %v, got %v.", expected, notable)
+	}
+}
+
+func TestCalculateReleasesOfInterest(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.8"},
+			{Version: "1.9"},
+			{Version: "1.10"},
+			{Version: "1.11"},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "apps",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "Deployment", Releases: []string{"1.8", "1.9", "1.10"}},
+						},
+					},
+					{
+						Version: "v1beta2",
+						Resources: []APIResource{
+							// present from the start, so not a graduation
+							{Kind: "Deployment", Releases: []string{"1.8", "1.9", "1.10", "1.11"}},
+						},
+					},
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "Deployment", Releases: []string{"1.9", "1.10", "1.11"}},
+							// new kinds are not a graduation
+							{Kind: "ControllerRevision", Releases: []string{"1.10", "1.11"}},
+						},
+					},
+					{
+						Version: "v2alpha1",
+						Resources: []APIResource{
+							// alpha versions do not supersede stable ones
+							{Kind: "Deployment", Releases: []string{"1.11"}},
+						},
+					},
+				},
+			},
+			{
+				// CronJob was served as v1beta1 and v2alpha1 at the same time,
+				// before it graduated to v1
+				Name: "batch",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.10", "1.11"}},
+						},
+					},
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.8", "1.9", "1.10", "1.11"}},
+						},
+					},
+					{
+						Version: "v2alpha1",
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.8", "1.9", "1.10", "1.11"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := calculateReleasesOfInterest(tl); err != nil {
+		t.Fatalf("Failed to calculate releases of interest: %v", err)
+	}
+
+	group := tl.APIGroups[0]
+
+	testcases := []struct {
+		version  int
+		resource int
+		expected []string
+	}{
+		{version: 0, resource: 0, expected: []string{"1.11"}},
+		{version: 1, resource: 0, expected: nil},
+		{version: 2, resource: 0, expected: []string{"1.9"}},
+		{version: 2, resource: 1, expected: nil},
+		{version: 3, resource: 0, expected: nil},
+	}
+
+	for _, tc := range testcases {
+		resource := group.APIVersions[tc.version].Resources[tc.resource]
+		if !reflect.DeepEqual(resource.ReleasesOfInterest, tc.expected) {
+			t.Errorf("Expected %s %s to have notable changes in %v, got %v.", group.APIVersions[tc.version].Version, resource.Kind, tc.expected, resource.ReleasesOfInterest)
+		}
+	}
+
+	if expected := []string{"1.9", "1.11"}; !reflect.DeepEqual(group.ReleasesOfInterest, expected) {
+		t.Errorf("Expected the group to have notable changes in %v, got %v.", expected, group.ReleasesOfInterest)
+	}
+
+	batch := tl.APIGroups[1]
+
+	for i, expected := range [][]string{{"1.10"}, nil, nil} {
+		resource := batch.APIVersions[i].Resources[0]
+		if !reflect.DeepEqual(resource.ReleasesOfInterest, expected) {
+			t.Errorf("Expected batch/%s %s to have notable changes in %v, got %v.", batch.APIVersions[i].Version, resource.Kind, expected, resource.ReleasesOfInterest)
+		}
+	}
+}
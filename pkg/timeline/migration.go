@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+	"sort"
+
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+// migrationWindow is the number of releases after a resource disappeared
+// from an API group in which another group must offer it, so that it is
+// considered a migration.
+const migrationWindow = 3
+
+// ResourceMigration records that a kind moved from one API group to another,
+// e.g. NetworkPolicy from extensions/v1beta1 to networking.k8s.io/v1.
+type ResourceMigration struct {
+	Group   string
+	Version string
+	Kind    string
+	// Release is the first release in which the old group did not offer the
+	// resource anymore.
+	Release string
+	// Overlap lists the releases in which both groups offered the resource.
+	Overlap []string
+}
+
+// calculateMigrations detects kinds that disappeared from one API group while
+// (or shortly before) another group offered them and links all resources of
+// the old group to the most mature version of the new group (MigratedTo) and
+// vice versa (MigratedFrom). Unlike successions, this also detects migrations
+// where the new group existed long before the old group gave up the kind.
+func calculateMigrations(tl *Timeline) error {
+	known := []string{}
+	for _, release := range tl.Releases {
+		if !release.Projected {
+			known = append(known, release.Version)
+		}
+	}
+
+	if len(known) == 0 {
+		return nil
+	}
+
+	releaseIndex := map[string]int{}
+	for i, release := range known {
+		releaseIndex[release] = i
+	}
+
+	// kind => group => releases offering the kind in any version
+	served := map[string]map[string][]string{}
+	kinds := []string{}
+
+	for _, apiGroup := range tl.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				if _, exists := served[apiResource.Kind]; !exists {
+					served[apiResource.Kind] = map[string][]string{}
+					kinds = append(kinds, apiResource.Kind)
+				}
+
+				for _, release := range apiResource.Releases {
+					if _, exists := releaseIndex[release]; exists && !contains(served[apiResource.Kind][apiGroup.Name], release) {
+						served[apiResource.Kind][apiGroup.Name] = append(served[apiResource.Kind][apiGroup.Name], release)
+					}
+				}
+			}
+		}
+	}
+
+	for _, kind := range kinds {
+		groups := served[kind]
+		if len(groups) < 2 {
+			continue
+		}
+
+		names := []string{}
+		for name := range groups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, from := range names {
+			_, last := releaseRange(groups[from], releaseIndex)
+
+			// still offered by the group
+			if last < 0 || last == len(known)-1 {
+				continue
+			}
+
+			removedIn := last + 1
+			to := ""
+			toFirst := -1
+
+			for _, candidate := range names {
+				if candidate == from {
+					continue
+				}
+
+				first, candidateLast := releaseRange(groups[candidate], releaseIndex)
+				if candidateLast < removedIn || first > removedIn+migrationWindow-1 {
+					continue
+				}
+
+				if to == "" || first < toFirst {
+					to = candidate
+					toFirst = first
+				}
+			}
+
+			if to == "" {
+				continue
+			}
+
+			// point to the most mature version once the new group took over
+			takeover := known[max(removedIn, toFirst)]
+
+			toVersion, err := preferredVersionOf(tl, to, kind, takeover)
+			if err != nil {
+				return err
+			}
+
+			fromVersion, err := preferredVersionOf(tl, from, kind, known[last])
+			if err != nil {
+				return err
+			}
+
+			if toVersion == "" || fromVersion == "" {
+				continue
+			}
+
+			overlap := []string{}
+			for _, release := range known {
+				if contains(groups[from], release) && contains(groups[to], release) {
+					overlap = append(overlap, release)
+				}
+			}
+
+			migration := ResourceMigration{
+				Group:   to,
+				Version: toVersion,
+				Kind:    kind,
+				Release: known[removedIn],
+				Overlap: overlap,
+			}
+
+			for i, apiGroup := range tl.APIGroups {
+				for j, apiVersion := range apiGroup.APIVersions {
+					for k, apiResource := range apiVersion.Resources {
+						if apiResource.Kind != kind {
+							continue
+						}
+
+						resource := &tl.APIGroups[i].APIVersions[j].Resources[k]
+
+						if apiGroup.Name == from {
+							m := migration
+							resource.MigratedTo = &m
+						}
+
+						if apiGroup.Name == to && apiVersion.Version == toVersion {
+							resource.MigratedFrom = append(resource.MigratedFrom, ResourceMigration{
+								Group:   from,
+								Version: fromVersion,
+								Kind:    kind,
+								Release: migration.Release,
+								Overlap: overlap,
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// preferredVersionOf returns the most mature version of the API group that
+// offers the kind in the given release.
+func preferredVersionOf(tl *Timeline, group string, kind string, release string) (string, error) {
+	available := []string{}
+
+	for _, apiGroup := range tl.APIGroups {
+		if apiGroup.Name != group {
+			continue
+		}
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				if apiResource.Kind == kind && apiResource.HasRelease(release) {
+					available = append(available, apiVersion.Version)
+				}
+			}
+		}
+	}
+
+	if len(available) == 0 {
+		return "", nil
+	}
+
+	preferred, err := version.PreferredAPIVersion(available)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine preferred version of %s %s in %s: %w", group, kind, release, err)
+	}
+
+	return preferred.String(), nil
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateMigrations(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.14"},
+			{Version: "1.15"},
+			{Version: "1.16"},
+			{Version: "1.17"},
+			{Version: "1.18", Projected: true},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "extensions",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "NetworkPolicy", Releases: []string{"1.14", "1.15"}},
+							{Kind: "Widget", Releases: []string{"1.14", "1.15"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "networking.k8s.io",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "NetworkPolicy", Releases: []string{"1.14"}},
+						},
+					},
+					{
+						// offered long before extensions gave it up
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "NetworkPolicy", Releases: []string{"1.14", "1.15", "1.16", "1.17", "1.18"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "example.com",
+				APIVersions: []APIVersion{
+					{
+						// still served in the latest release, but appeared too late
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "Widget", Releases: []string{"1.18"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := calculateMigrations(tl); err != nil {
+		t.Fatalf("Failed to calculate migrations: %v", err)
+	}
+
+	expected := &ResourceMigration{
+		Group:   "networking.k8s.io",
+		Version: "v1",
+		Kind:    "NetworkPolicy",
+		Release: "1.16",
+		Overlap: []string{"1.14", "1.15"},
+	}
+
+	extensions := tl.APIGroups[0].APIVersions[0]
+	if migration := extensions.Resources[0].MigratedTo; !reflect.DeepEqual(migration, expected) {
+		t.Errorf("Expected NetworkPolicy to have moved to %+v, got %+v.", expected, migration)
+	}
+
+	// projected releases do not count
+	if migration := extensions.Resources[1].MigratedTo; migration != nil {
+		t.Errorf("Expected Widget not to have moved, got %+v.", migration)
+	}
+
+	networking := tl.APIGroups[1]
+	if migrations := networking.APIVersions[0].Resources[0].MigratedFrom; migrations != nil {
+		t.Errorf("Expected the reverse link only on the most mature version, got %+v.", migrations)
+	}
+
+	expectedFrom := []ResourceMigration{{
+		Group:   "extensions",
+		Version: "v1beta1",
+		Kind:    "NetworkPolicy",
+		Release: "1.16",
+		Overlap: []string{"1.14", "1.15"},
+	}}
+
+	if migrations := networking.APIVersions[1].Resources[0].MigratedFrom; !reflect.DeepEqual(migrations, expectedFrom) {
+		t.Errorf("Expected NetworkPolicy to have moved from %+v, got %+v.", expectedFrom, migrations)
+	}
+
+	// the groups are linked as well
+	calculateGroupLineage(tl)
+
+	if lineage := tl.GroupLineage("networking.k8s.io"); !reflect.DeepEqual(lineage, []string{"extensions", "networking.k8s.io"}) {
+		t.Errorf("Expected networking.k8s.io to be linked to extensions, got %v.", lineage)
+	}
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"io"
+	"log/slog"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+const (
+	// The number of most recent releases we consider to show by default,
+	// all older releases are "archived"; this is 11 because we want to
+	// show e.g. 1.19..1.29, just because I think it looks nice.
+	defaultRecentReleases = 11
+)
+
+// Option configures how a timeline is created.
+type Option func(*options)
+
+// ProgressFunc is called after each release has been merged into the timeline.
+type ProgressFunc func(done int, total int, release string)
+
+type options struct {
+	now                time.Time
+	asOf               bool
+	recentReleases     int
+	archiveAfterEOL    *time.Duration
+	archival           bool
+	minRelease         string
+	maxRelease         string
+	includeArchived    bool
+	supportedOnly      bool
+	includeUnreleased  bool
+	projectedReleases  int
+	overlays           []*database.ReleaseDatabase
+	annotations        []types.Annotation
+	keps               []types.KEP
+	featuredGroups     []string
+	releasesOfInterest bool
+	cacheDirectory     string
+	logger             *slog.Logger
+	progress           ProgressFunc
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		now:                time.Now().UTC(),
+		recentReleases:     defaultRecentReleases,
+		archival:           true,
+		includeArchived:    true,
+		includeUnreleased:  true,
+		releasesOfInterest: true,
+		logger:             slog.New(slog.NewTextHandler(io.Discard, nil)),
+		progress:           func(int, int, string) {},
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithNow overrides the current time, which is used to determine the
+// support status of each release.
+func WithNow(now time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// WithAsOf creates the timeline as it would have looked on the given date:
+// The date is used as the current time (like WithNow) and all releases that
+// were not even planned yet are removed (only the next upcoming release is
+// kept). Note that patch versions and advisories are not historic, as the
+// database only contains their latest state.
+func WithAsOf(date time.Time) Option {
+	return func(o *options) {
+		o.now = date
+		o.asOf = true
+	}
+}
+
+// WithRecentReleases sets the number of most recent releases that are
+// not archived (default 11).
+func WithRecentReleases(n int) Option {
+	return func(o *options) {
+		o.recentReleases = n
+	}
+}
+
+// WithArchiveAfterEOL archives releases once their end of life is longer ago
+// than the given duration, instead of keeping a fixed number of recent
+// releases (see WithRecentReleases). Releases without a known EOL date are
+// never archived.
+func WithArchiveAfterEOL(d time.Duration) Option {
+	return func(o *options) {
+		o.archiveAfterEOL = &d
+	}
+}
+
+// WithArchival controls whether old releases are archived at all (the
+// default). Self-hosted instances that want to treat all releases alike can
+// disable it.
+func WithArchival(enabled bool) Option {
+	return func(o *options) {
+		o.archival = enabled
+	}
+}
+
+// WithReleaseRange limits the timeline to releases between min and max
+// (both inclusive, like "1.20" and "1.29"). Empty strings disable the
+// respective limit. Data derived from the history, like the release in which
+// a resource became GA, still takes all releases into account.
+func WithReleaseRange(min, max string) Option {
+	return func(o *options) {
+		o.minRelease = min
+		o.maxRelease = max
+	}
+}
+
+// WithArchived controls whether archived releases (and the API groups,
+// versions and resources that only exist in them) are kept in the timeline
+// (the default) or removed from it.
+func WithArchived(include bool) Option {
+	return func(o *options) {
+		o.includeArchived = include
+	}
+}
+
+// WithSupportedOnly controls whether releases that have reached their end
+// of life (and the API groups, versions and resources that only exist in
+// them) are removed from the timeline. Unreleased releases are kept.
+func WithSupportedOnly(enabled bool) Option {
+	return func(o *options) {
+		o.supportedOnly = enabled
+	}
+}
+
+// WithUnreleased controls whether the upcoming release, whose data is based
+// on alpha, beta and RC builds, is kept in the timeline (the default).
+func WithUnreleased(include bool) Option {
+	return func(o *options) {
+		o.includeUnreleased = include
+	}
+}
+
+// WithProjectedReleases appends the given number of speculative future
+// releases. Their release dates are extrapolated from the recent release
+// cadence and superseded alpha/beta APIs are removed from them according to
+// the deprecation policy. Projected releases are marked as such.
+func WithProjectedReleases(n int) Option {
+	return func(o *options) {
+		o.projectedReleases = n
+	}
+}
+
+// WithOverlays adds the APIs of additional databases (e.g. internal CRDs)
+// to the releases of the same name. Overlays only need to contain api.json
+// files; releases that do not exist in the primary database are ignored.
+func WithOverlays(overlays ...*database.ReleaseDatabase) Option {
+	return func(o *options) {
+		o.overlays = append(o.overlays, overlays...)
+	}
+}
+
+// WithAnnotations attaches user-provided notes to matching API resources.
+// If multiple annotations match a resource, the most specific one (i.e. one
+// with a version) wins.
+func WithAnnotations(annotations []types.Annotation) Option {
+	return func(o *options) {
+		o.annotations = append(o.annotations, annotations...)
+	}
+}
+
+// WithKEPs links the given Kubernetes Enhancement Proposals to the releases
+// of interest of the resources they apply to.
+func WithKEPs(keps ...types.KEP) Option {
+	return func(o *options) {
+		o.keps = append(o.keps, keps...)
+	}
+}
+
+// WithFeaturedGroups marks the given API groups (like "apps" or "core") as
+// featured and sorts them to the top, in the given order. All other groups
+// are sorted alphabetically.
+func WithFeaturedGroups(groups ...string) Option {
+	return func(o *options) {
+		o.featuredGroups = append(o.featuredGroups, groups...)
+	}
+}
+
+// WithReleasesOfInterest controls whether releases with notable changes
+// are calculated (enabled by default).
+func WithReleasesOfInterest(enabled bool) Option {
+	return func(o *options) {
+		o.releasesOfInterest = enabled
+	}
+}
+
+// WithCache enables caching the merged API data of each release in the given
+// directory. When creating the timeline again, only releases whose data has
+// changed (and all releases after them) have to be merged again, which
+// speeds up local iteration on the newest release considerably. The
+// directory can be deleted at any time.
+func WithCache(directory string) Option {
+	return func(o *options) {
+		o.cacheDirectory = directory
+	}
+}
+
+// WithLogger sets the logger used to report warnings and details while
+// creating the timeline. By default, nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithProgress sets a callback that is invoked after each merged release.
+func WithProgress(progress ProgressFunc) Option {
+	return func(o *options) {
+		o.progress = progress
+	}
+}
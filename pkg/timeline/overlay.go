@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/database"
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+// applyAnnotations attaches the best matching annotation to each resource.
+func applyAnnotations(tl *Timeline, annotations []types.Annotation) {
+	if len(annotations) == 0 {
+		return
+	}
+
+	for i, apiGroup := range tl.APIGroups {
+		for j, apiVersion := range apiGroup.APIVersions {
+			for k, apiResource := range apiVersion.Resources {
+				var match *types.Annotation
+
+				for a, annotation := range annotations {
+					if !annotation.Matches(apiGroup.Name, apiVersion.Version, apiResource.Kind) {
+						continue
+					}
+
+					if match == nil || (match.Version == "" && annotation.Version != "") {
+						match = &annotations[a]
+					}
+				}
+
+				tl.APIGroups[i].APIVersions[j].Resources[k].Annotation = match
+			}
+		}
+	}
+}
+
+// applyOverlays returns a copy of api that additionally contains all API
+// groups and versions found in the overlays for the given release. Versions
+// that already exist in the base API are left untouched.
+func applyOverlays(ctx context.Context, api *types.KubernetesAPI, release string, overlays []*database.ReleaseDatabase) (*types.KubernetesAPI, error) {
+	if len(overlays) == 0 {
+		return api, nil
+	}
+
+	// the base API is shared (cached), so never modify it in-place
+	result := *api
+	result.APIGroups = append([]types.APIGroup{}, api.APIGroups...)
+
+	for _, overlay := range overlays {
+		overlayRelease, err := overlay.Release(release)
+		if err != nil {
+			if errors.Is(err, database.ErrUnknownRelease) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		overlayAPI, err := overlayRelease.API(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load overlay API: %w", err)
+		}
+
+		for _, overlayGroup := range overlayAPI.APIGroups {
+			idx := -1
+			for i, group := range result.APIGroups {
+				if group.Name == overlayGroup.Name {
+					idx = i
+					break
+				}
+			}
+
+			if idx < 0 {
+				result.APIGroups = append(result.APIGroups, overlayGroup)
+				continue
+			}
+
+			group := result.APIGroups[idx]
+			versions := append([]types.APIVersion{}, group.APIVersions...)
+
+		nextVersion:
+			for _, overlayVersion := range overlayGroup.APIVersions {
+				for _, v := range versions {
+					if v.Version == overlayVersion.Version {
+						continue nextVersion
+					}
+				}
+
+				versions = append(versions, overlayVersion)
+			}
+
+			group.APIVersions = versions
+			result.APIGroups[idx] = group
+		}
+	}
+
+	return &result, nil
+}
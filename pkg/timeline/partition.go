@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"path"
+)
+
+// PartitionIndex is the entry point of a timeline that has been split into
+// one document per API group. It is small enough to be loaded eagerly, while
+// the API groups can be fetched on demand.
+type PartitionIndex struct {
+	Releases  []ReleaseMetadata
+	APIGroups []PartitionedGroup
+}
+
+type PartitionedGroup struct {
+	Name     string
+	Archived bool
+	Featured bool
+	// Path is the location of the group's document (containing an APIGroup),
+	// relative to the index document.
+	Path string
+}
+
+// PartitionPath returns the path of an API group's document, relative to the
+// index document.
+func PartitionPath(group string) string {
+	return path.Join("groups", group+".json")
+}
+
+// Partition returns the index for the timeline. The API groups themselves
+// are meant to be stored individually at their PartitionPath.
+func (o *Timeline) Partition() *PartitionIndex {
+	index := &PartitionIndex{
+		Releases:  o.Releases,
+		APIGroups: []PartitionedGroup{},
+	}
+
+	for _, apiGroup := range o.APIGroups {
+		index.APIGroups = append(index.APIGroups, PartitionedGroup{
+			Name:     apiGroup.Name,
+			Archived: apiGroup.Archived,
+			Featured: apiGroup.Featured,
+			Path:     PartitionPath(apiGroup.Name),
+		})
+	}
+
+	return index
+}
+
+// Assemble recreates the timeline from the index and the given API groups,
+// e.g. after a client has loaded the groups it is interested in. Groups are
+// sorted like in the index; groups that are not part of the index are
+// ignored.
+func (o *PartitionIndex) Assemble(groups []APIGroup) *Timeline {
+	byName := map[string]APIGroup{}
+	for _, group := range groups {
+		byName[group.Name] = group
+	}
+
+	tl := &Timeline{
+		Releases:  o.Releases,
+		APIGroups: []APIGroup{},
+	}
+
+	for _, entry := range o.APIGroups {
+		if group, ok := byName[entry.Name]; ok {
+			tl.APIGroups = append(tl.APIGroups, group)
+		}
+	}
+
+	return tl
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPartition(t *testing.T) {
+	tl := testTimeline()
+	tl.APIGroups = append([]APIGroup{{Name: "apps", Featured: true}}, tl.APIGroups...)
+
+	index := tl.Partition()
+
+	if len(index.Releases) != len(tl.Releases) {
+		t.Errorf("Expected the index to contain all %d releases, got %d.", len(tl.Releases), len(index.Releases))
+	}
+
+	expected := []PartitionedGroup{
+		{Name: "apps", Featured: true, Path: "groups/apps.json"},
+		{Name: "batch", Path: "groups/batch.json"},
+	}
+	if !reflect.DeepEqual(index.APIGroups, expected) {
+		t.Fatalf("Expected groups %+v, got %+v.", expected, index.APIGroups)
+	}
+
+	// groups can be loaded in any order and subset
+	if assembled := index.Assemble([]APIGroup{tl.APIGroups[1], tl.APIGroups[0]}); !reflect.DeepEqual(assembled, tl) {
+		t.Errorf("Expected the assembled timeline to equal the original one.")
+	}
+
+	if assembled := index.Assemble([]APIGroup{tl.APIGroups[1]}); len(assembled.APIGroups) != 1 || assembled.APIGroups[0].Name != "batch" {
+		t.Errorf("Expected only the batch group, got %+v.", assembled.APIGroups)
+	}
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+type PatchRelease struct {
+	Version string
+	Date    time.Time
+}
+
+// PatchCadence returns the average time between two patch releases, or 0 if
+// fewer than two patch releases are known.
+func (r *ReleaseMetadata) PatchCadence() time.Duration {
+	if len(r.PatchReleases) < 2 {
+		return 0
+	}
+
+	first := r.PatchReleases[0].Date
+	last := r.PatchReleases[len(r.PatchReleases)-1].Date
+
+	return last.Sub(first) / time.Duration(len(r.PatchReleases)-1)
+}
+
+// LatestPatchRelease returns the most recent known patch release, if any.
+func (r *ReleaseMetadata) LatestPatchRelease() *PatchRelease {
+	if len(r.PatchReleases) == 0 {
+		return nil
+	}
+
+	return &r.PatchReleases[len(r.PatchReleases)-1]
+}
+
+// convertPatchReleases turns the patch releases from the database into
+// their timeline representation, leaving out those that happened after now.
+func convertPatchReleases(patches []types.PatchRelease, now time.Time) []PatchRelease {
+	var result []PatchRelease
+
+	for _, patch := range patches {
+		// dates have already been validated by the database
+		date, _ := time.ParseInLocation("2006-01-02", patch.Date, time.UTC)
+		if date.After(now) {
+			continue
+		}
+
+		result = append(result, PatchRelease{
+			Version: patch.Version,
+			Date:    date,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"testing"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+func TestPatchReleases(t *testing.T) {
+	patches := []types.PatchRelease{
+		{Version: "1.28.0", Date: "2023-08-15"},
+		{Version: "1.28.1", Date: "2023-08-24"},
+		{Version: "1.28.2", Date: "2023-09-13"},
+		{Version: "1.28.3", Date: "2023-10-18"},
+	}
+
+	now := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	release := ReleaseMetadata{
+		Version:       "1.28",
+		PatchReleases: convertPatchReleases(patches, now),
+	}
+
+	if len(release.PatchReleases) != 3 {
+		t.Fatalf("Expected patch releases after %s to be left out, got %+v.", now.Format("2006-01-02"), release.PatchReleases)
+	}
+
+	if latest := release.LatestPatchRelease(); latest == nil || latest.Version != "1.28.2" {
+		t.Errorf("Expected 1.28.2 to be the latest patch release, got %+v.", latest)
+	}
+
+	// 29 days between 1.28.0 and 1.28.2
+	expected := 29 * 24 * time.Hour / 2
+	if cadence := release.PatchCadence(); cadence != expected {
+		t.Errorf("Expected a cadence of %v, got %v.", expected, cadence)
+	}
+
+	if cadence := (&ReleaseMetadata{}).PatchCadence(); cadence != 0 {
+		t.Errorf("Expected no cadence without patch releases, got %v.", cadence)
+	}
+}
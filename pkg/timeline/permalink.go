@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Permalink is a stable path (like "api/networking.k8s.io/v1/Ingress") that
+// points to the history of an API resource. The target resource can differ
+// from the one in the path, e.g. when a resource moved to another API group.
+type Permalink struct {
+	Path    string
+	Group   string
+	Version string
+	Kind    string
+}
+
+// PermalinkPath returns the path of the permalink for an API resource; the
+// version can be empty to link to a resource regardless of its version.
+func PermalinkPath(group, version, kind string) string {
+	if version == "" {
+		return fmt.Sprintf("api/%s/%s", group, kind)
+	}
+
+	return fmt.Sprintf("api/%s/%s/%s", group, version, kind)
+}
+
+// Permalinks returns one permalink per API resource and API version, plus
+// one per API resource without a version, which points to the version that
+// was most recently preferred. If a resource is not served anymore, but moved
+// to another API group (like extensions' Deployment to apps), its
+// version-less permalink points to the new group instead, so that links using
+// the old group name keep pointing to the current API. Permalinks are sorted
+// by path.
+func (o *Timeline) Permalinks() []Permalink {
+	result := []Permalink{}
+	kinds := map[string][]string{}
+
+	for _, apiGroup := range o.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				result = append(result, Permalink{
+					Path:    PermalinkPath(apiGroup.Name, apiVersion.Version, apiResource.Kind),
+					Group:   apiGroup.Name,
+					Version: apiVersion.Version,
+					Kind:    apiResource.Kind,
+				})
+
+				if !contains(kinds[apiGroup.Name], apiResource.Kind) {
+					kinds[apiGroup.Name] = append(kinds[apiGroup.Name], apiResource.Kind)
+				}
+			}
+		}
+	}
+
+	for _, apiGroup := range o.APIGroups {
+		for _, kind := range kinds[apiGroup.Name] {
+			target := o.resolvePermalink(apiGroup.Name, kind, map[string]bool{})
+			if target == nil {
+				continue
+			}
+
+			target.Path = PermalinkPath(apiGroup.Name, "", kind)
+			result = append(result, *target)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+
+	return result
+}
+
+// resolvePermalink finds the API version of the most recent release that
+// serves the resource in the given group, preferring the group's preferred
+// version, and follows resources that moved into other groups.
+func (o *Timeline) resolvePermalink(group, kind string, visited map[string]bool) *Permalink {
+	key := group + "/" + kind
+	if visited[key] {
+		return nil
+	}
+	visited[key] = true
+
+	var apiGroup *APIGroup
+	for i := range o.APIGroups {
+		if o.APIGroups[i].Name == group {
+			apiGroup = &o.APIGroups[i]
+			break
+		}
+	}
+
+	if apiGroup == nil {
+		return nil
+	}
+
+	latest := ""
+	for i := len(o.Releases) - 1; i >= 0; i-- {
+		if !o.Releases[i].Projected {
+			latest = o.Releases[i].Version
+			break
+		}
+	}
+
+	for i := len(o.Releases) - 1; i >= 0; i-- {
+		release := o.Releases[i]
+		if release.Projected {
+			continue
+		}
+
+		var found *APIResource
+		version := ""
+
+		for j, apiVersion := range apiGroup.APIVersions {
+			for k, apiResource := range apiVersion.Resources {
+				if apiResource.Kind != kind || !apiResource.HasRelease(release.Version) {
+					continue
+				}
+
+				if found == nil || apiVersion.Version == apiGroup.PreferredVersion(release.Version) {
+					found = &apiGroup.APIVersions[j].Resources[k]
+					version = apiVersion.Version
+				}
+			}
+		}
+
+		if found == nil {
+			continue
+		}
+
+		// the resource is gone, follow it to its new group, if possible
+		if release.Version != latest {
+			if migration := found.MigratedTo; migration != nil {
+				if target := o.resolvePermalink(migration.Group, migration.Kind, visited); target != nil {
+					return target
+				}
+			}
+
+			if succession := found.SucceededBy; succession != nil && succession.Type == Replacement {
+				if target := o.resolvePermalink(succession.Group, kind, visited); target != nil {
+					return target
+				}
+			}
+		}
+
+		return &Permalink{Group: group, Version: version, Kind: kind}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPermalinks(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.15"},
+			{Version: "1.16"},
+			{Version: "1.17"},
+			{Version: "1.18", Projected: true},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name:              "apps",
+				PreferredVersions: map[string]string{"1.15": "v1", "1.16": "v1", "1.17": "v1"},
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta2",
+						Resources: []APIResource{
+							{Kind: "Deployment", Releases: []string{"1.15", "1.16", "1.17"}},
+						},
+					},
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "Deployment", Releases: []string{"1.15", "1.16", "1.17"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "extensions",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "Deployment", Releases: []string{"1.15"}, MigratedTo: &ResourceMigration{Group: "apps", Version: "v1", Kind: "Deployment"}},
+							// gone, but not moved anywhere
+							{Kind: "ReplicationControllerDummy", Releases: []string{"1.15"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []Permalink{
+		{Path: "api/apps/Deployment", Group: "apps", Version: "v1", Kind: "Deployment"},
+		{Path: "api/apps/v1/Deployment", Group: "apps", Version: "v1", Kind: "Deployment"},
+		{Path: "api/apps/v1beta2/Deployment", Group: "apps", Version: "v1beta2", Kind: "Deployment"},
+		{Path: "api/extensions/Deployment", Group: "apps", Version: "v1", Kind: "Deployment"},
+		{Path: "api/extensions/ReplicationControllerDummy", Group: "extensions", Version: "v1beta1", Kind: "ReplicationControllerDummy"},
+		{Path: "api/extensions/v1beta1/Deployment", Group: "extensions", Version: "v1beta1", Kind: "Deployment"},
+		{Path: "api/extensions/v1beta1/ReplicationControllerDummy", Group: "extensions", Version: "v1beta1", Kind: "ReplicationControllerDummy"},
+	}
+
+	if permalinks := tl.Permalinks(); !reflect.DeepEqual(permalinks, expected) {
+		t.Errorf("Expected %+v, got %+v.", expected, permalinks)
+	}
+}
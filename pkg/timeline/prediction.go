@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+// calculatePredictedRemovals applies the Kubernetes deprecation policy to
+// prerelease APIs that are still served, but whose removal is not known yet:
+// alpha APIs can vanish in any release, beta APIs must be kept for 3 releases
+// (which is always longer than the required 9 months) after they have been
+// deprecated or superseded by a newer version, and GA APIs are never removed.
+// Predictions are only stored in PredictedRemovalIn, never in RemovedIn.
+func calculatePredictedRemovals(tl *Timeline) error {
+	latest := ""
+	releaseIndex := map[string]int{}
+
+	for i, release := range tl.Releases {
+		releaseIndex[release.Version] = i
+
+		if !release.Projected {
+			latest = release.Version
+		}
+	}
+
+	if latest == "" {
+		return nil
+	}
+
+	for i, apiGroup := range tl.APIGroups {
+		for j, apiVersion := range apiGroup.APIVersions {
+			if !apiVersion.HasRelease(latest) || apiVersion.RemovedIn != "" {
+				continue
+			}
+
+			parsed, err := version.ParseAPIVersion(apiVersion.Version)
+			if err != nil {
+				return fmt.Errorf("invalid API version %s/%s: %w", apiGroup.Name, apiVersion.Version, err)
+			}
+
+			if parsed.IsGA() {
+				continue
+			}
+
+			// an API version lives as long as its longest-living resource
+			versionPrediction := ""
+			kept := false
+			if len(apiVersion.Resources) == 0 {
+				versionPrediction, err = predictRemoval(tl, parsed, apiVersion.DeprecatedIn, -1, latest)
+				if err != nil {
+					return err
+				}
+			}
+
+			for k, apiResource := range apiVersion.Resources {
+				if !apiResource.HasRelease(latest) || apiResource.RemovedIn != "" {
+					continue
+				}
+
+				prediction, err := predictRemoval(tl, parsed, apiResource.DeprecatedIn, supersedingRelease(&apiGroup, parsed, apiResource.Kind, releaseIndex), latest)
+				if err != nil {
+					return fmt.Errorf("failed to predict removal of %s/%s %s: %w", apiGroup.Name, apiVersion.Version, apiResource.Kind, err)
+				}
+
+				tl.APIGroups[i].APIVersions[j].Resources[k].PredictedRemovalIn = prediction
+
+				// a resource that is kept indefinitely keeps its version alive
+				if prediction == "" {
+					kept = true
+				} else if versionPrediction == "" {
+					versionPrediction = prediction
+				} else if earlier, err := releaseLessThan(versionPrediction, prediction); err != nil {
+					return err
+				} else if earlier {
+					versionPrediction = prediction
+				}
+			}
+
+			if !kept {
+				tl.APIGroups[i].APIVersions[j].PredictedRemovalIn = versionPrediction
+			}
+		}
+	}
+
+	return nil
+}
+
+// predictRemoval returns the earliest release in which an API version can be
+// removed, or an empty string if the deprecation policy does not allow to
+// remove it (yet). supersededAt is the index of the release in which a newer
+// version took over, or -1.
+func predictRemoval(tl *Timeline, apiVersion *version.APIVersion, deprecatedIn string, supersededAt int, latest string) (string, error) {
+	if apiVersion.IsAlpha() {
+		return nextMinorRelease(latest)
+	}
+
+	start := deprecatedIn
+	if supersededAt >= 0 {
+		superseded := tl.Releases[supersededAt].Version
+
+		if start == "" {
+			start = superseded
+		} else if earlier, err := releaseLessThan(superseded, start); err != nil {
+			return "", err
+		} else if earlier {
+			start = superseded
+		}
+	}
+
+	// beta APIs that are neither deprecated nor superseded are kept
+	if start == "" {
+		return "", nil
+	}
+
+	prediction := start
+	for i := 0; i < betaDeprecationReleases; i++ {
+		next, err := nextMinorRelease(prediction)
+		if err != nil {
+			return "", err
+		}
+
+		prediction = next
+	}
+
+	// the grace period has passed already, so it could be removed any time
+	if overdue, err := releaseLessThan(latest, prediction); err != nil {
+		return "", err
+	} else if !overdue {
+		return nextMinorRelease(latest)
+	}
+
+	return prediction, nil
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"testing"
+)
+
+func TestCalculatePredictedRemovals(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.24"},
+			{Version: "1.25"},
+			{Version: "1.26"},
+			{Version: "1.27", Projected: true},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "flowcontrol.apiserver.k8s.io",
+				APIVersions: []APIVersion{
+					{
+						Version:  "v1alpha1",
+						Releases: []string{"1.24", "1.25", "1.26"},
+						Resources: []APIResource{
+							{Kind: "Experiment", Releases: []string{"1.24", "1.25", "1.26"}},
+						},
+					},
+					{
+						Version:  "v1beta1",
+						Releases: []string{"1.24", "1.25", "1.26"},
+						Resources: []APIResource{
+							{Kind: "Legacy", Releases: []string{"1.24", "1.25", "1.26"}, DeprecatedIn: "1.22"},
+						},
+					},
+					{
+						Version:  "v1beta2",
+						Releases: []string{"1.24", "1.25", "1.26"},
+						Resources: []APIResource{
+							{Kind: "FlowSchema", Releases: []string{"1.24", "1.25", "1.26"}},
+							{Kind: "PriorityLevelConfiguration", Releases: []string{"1.24", "1.25", "1.26"}},
+						},
+					},
+					{
+						Version:  "v1beta3",
+						Releases: []string{"1.25", "1.26"},
+						Resources: []APIResource{
+							{Kind: "PriorityLevelConfiguration", Releases: []string{"1.25", "1.26"}, DeprecatedIn: "1.26"},
+						},
+					},
+					{
+						Version:  "v1beta4",
+						Releases: []string{"1.26"},
+						Resources: []APIResource{
+							{Kind: "FlowSchema", Releases: []string{"1.26"}},
+						},
+					},
+					{
+						Version:  "v1",
+						Releases: []string{"1.26"},
+						Resources: []APIResource{
+							{Kind: "PriorityLevelConfiguration", Releases: []string{"1.26"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "batch",
+				APIVersions: []APIVersion{
+					{
+						Version:   "v1beta1",
+						Releases:  []string{"1.24"},
+						RemovedIn: "1.25",
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.24"}, RemovedIn: "1.25"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := calculatePredictedRemovals(tl); err != nil {
+		t.Fatalf("Failed to predict removals: %v", err)
+	}
+
+	group := tl.APIGroups[0]
+
+	testcases := []struct {
+		version  int
+		resource int
+		expected string
+	}{
+		// alpha APIs can be removed in the next release (the projected
+		// release does not count as known)
+		{version: 0, resource: 0, expected: "1.27"},
+		// deprecated in 1.22, so the grace period has passed already
+		{version: 1, resource: 0, expected: "1.27"},
+		// superseded in 1.26 by v1beta4, so it must be kept until 1.28
+		{version: 2, resource: 0, expected: "1.29"},
+		// superseded in 1.25 by v1beta3
+		{version: 2, resource: 1, expected: "1.28"},
+		// deprecated and superseded by v1 in 1.26
+		{version: 3, resource: 0, expected: "1.29"},
+		// the newest beta is kept
+		{version: 4, resource: 0, expected: ""},
+		// GA is never removed
+		{version: 5, resource: 0, expected: ""},
+	}
+
+	for _, tc := range testcases {
+		resource := group.APIVersions[tc.version].Resources[tc.resource]
+		if resource.PredictedRemovalIn != tc.expected {
+			t.Errorf("Expected %s %s to be removed in %q, got %q.", group.APIVersions[tc.version].Version, resource.Kind, tc.expected, resource.PredictedRemovalIn)
+		}
+	}
+
+	// versions live as long as their longest-living resource
+	for i, expected := range []string{"1.27", "1.27", "1.29", "1.29", "", ""} {
+		if actual := group.APIVersions[i].PredictedRemovalIn; actual != expected {
+			t.Errorf("Expected version %s to be removed in %q, got %q.", group.APIVersions[i].Version, expected, actual)
+		}
+	}
+
+	// known removals are not predicted
+	if removed := tl.APIGroups[1].APIVersions[0]; removed.PredictedRemovalIn != "" || removed.Resources[0].PredictedRemovalIn != "" {
+		t.Errorf("Expected no prediction for removed APIs, got %+v.", removed)
+	}
+}
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+const (
+	// Per the Kubernetes deprecation policy, beta APIs must be kept for 3
+	// releases after they have been deprecated (i.e. superseded by a newer
+	// version), while alpha APIs can be removed without notice.
+	betaDeprecationReleases  = 3
+	alphaDeprecationReleases = 1
+
+	// the number of past releases used to determine the release cadence
+	cadenceSampleSize = 4
+)
+
+// addProjectedReleases appends speculative future releases to the timeline,
+// based on the recent release cadence and the deprecation policy. Only
+// removals of superseded prerelease APIs are projected, no new APIs.
+func addProjectedReleases(tl *Timeline, count int, now time.Time) error {
+	if count <= 0 || len(tl.Releases) == 0 {
+		return nil
+	}
+
+	last := tl.Releases[len(tl.Releases)-1]
+	releaseIndex := map[string]int{}
+	for i, rel := range tl.Releases {
+		releaseIndex[rel.Version] = i
+	}
+
+	cadence := releaseCadence(tl.Releases)
+	lastIndex := len(tl.Releases) - 1
+
+	projected := []string{}
+	current := last.Version
+	for i := 1; i <= count; i++ {
+		next, err := nextMinorRelease(current)
+		if err != nil {
+			return err
+		}
+
+		projectedRelease := ReleaseMetadata{
+			Version:     next,
+			Projected:   true,
+			ReleaseDate: last.ReleaseDate.Add(time.Duration(i) * cadence),
+		}
+		projectedRelease.setSupportDates(now)
+
+		tl.Releases = append(tl.Releases, projectedRelease)
+
+		projected = append(projected, next)
+		current = next
+	}
+
+	for i, apiGroup := range tl.APIGroups {
+		remaining := []string{}
+
+		for j, apiVersion := range apiGroup.APIVersions {
+			if !apiVersion.HasRelease(last.Version) {
+				continue
+			}
+
+			parsed, err := version.ParseAPIVersion(apiVersion.Version)
+			if err != nil {
+				return fmt.Errorf("invalid API version %s/%s: %w", apiGroup.Name, apiVersion.Version, err)
+			}
+
+			// by default, an API version lives as long as its longest-living resource
+			lifetime := 0
+			if len(apiVersion.Resources) == 0 {
+				lifetime = count
+			}
+
+			for k, apiResource := range apiVersion.Resources {
+				if !apiResource.HasRelease(last.Version) {
+					continue
+				}
+
+				resourceLifetime := count
+				if !parsed.IsGA() {
+					if supersededAt := supersedingRelease(&apiGroup, parsed, apiResource.Kind, releaseIndex); supersededAt >= 0 {
+						grace := betaDeprecationReleases
+						if parsed.IsAlpha() {
+							grace = alphaDeprecationReleases
+						}
+
+						// number of projected releases that still contain the resource
+						resourceLifetime = min(max(supersededAt+grace-lastIndex-1, 0), count)
+					}
+				}
+
+				tl.APIGroups[i].APIVersions[j].Resources[k].Releases = append(tl.APIGroups[i].APIVersions[j].Resources[k].Releases, projected[:resourceLifetime]...)
+				lifetime = max(lifetime, resourceLifetime)
+			}
+
+			tl.APIGroups[i].APIVersions[j].Releases = append(tl.APIGroups[i].APIVersions[j].Releases, projected[:lifetime]...)
+			if lifetime > 0 {
+				remaining = append(remaining, apiVersion.Version)
+			}
+		}
+
+		// the previously preferred version might not be available anymore
+		for p, release := range projected {
+			available := []string{}
+			for _, apiVersion := range tl.APIGroups[i].APIVersions {
+				if apiVersion.HasRelease(release) {
+					available = append(available, apiVersion.Version)
+				}
+			}
+
+			if len(available) == 0 {
+				continue
+			}
+
+			preferred, err := version.PreferredAPIVersion(available)
+			if err != nil {
+				return fmt.Errorf("failed to determine preferred version of %s in %s: %w", apiGroup.Name, projected[p], err)
+			}
+
+			tl.APIGroups[i].PreferredVersions[release] = preferred.String()
+		}
+	}
+
+	return nil
+}
+
+// supersedingRelease returns the index of the first release in which a newer
+// version of the given API group contained the same kind, or -1.
+func supersedingRelease(apiGroup *APIGroup, apiVersion *version.APIVersion, kind string, releaseIndex map[string]int) int {
+	result := -1
+
+	for _, other := range apiGroup.APIVersions {
+		otherVersion, err := version.ParseAPIVersion(other.Version)
+		if err != nil || !apiVersion.LessThan(otherVersion) {
+			continue
+		}
+
+		for _, res := range other.Resources {
+			if res.Kind != kind {
+				continue
+			}
+
+			for _, release := range res.Releases {
+				idx, exists := releaseIndex[release]
+				if exists && (result < 0 || idx < result) {
+					result = idx
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// releaseCadence returns the average time between the most recent releases.
+func releaseCadence(releases []ReleaseMetadata) time.Duration {
+	// Kubernetes aims for 3 releases per year
+	const fallback = 4 * 30 * 24 * time.Hour
+
+	if len(releases) < 2 {
+		return fallback
+	}
+
+	first := max(len(releases)-cadenceSampleSize-1, 0)
+	last := len(releases) - 1
+
+	return releases[last].ReleaseDate.Sub(releases[first].ReleaseDate) / time.Duration(last-first)
+}
+
+func nextMinorRelease(release string) (string, error) {
+	major, minor, found := strings.Cut(release, ".")
+	if !found {
+		return "", fmt.Errorf("%w: invalid release %q", ErrMalformedVersion, release)
+	}
+
+	minorNumber, err := strconv.Atoi(minor)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid release %q: %v", ErrMalformedVersion, release, err)
+	}
+
+	return fmt.Sprintf("%s.%d", major, minorNumber+1), nil
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddProjectedReleases(t *testing.T) {
+	day := 24 * time.Hour
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.24", ReleaseDate: base},
+			{Version: "1.25", ReleaseDate: base.Add(100 * day)},
+			{Version: "1.26", ReleaseDate: base.Add(200 * day)},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name:              "flowcontrol.apiserver.k8s.io",
+				PreferredVersions: map[string]string{"1.24": "v1beta2", "1.25": "v1beta3", "1.26": "v1beta3"},
+				APIVersions: []APIVersion{
+					{
+						Version:  "v1beta2",
+						Releases: []string{"1.24", "1.25", "1.26"},
+						Resources: []APIResource{
+							{Kind: "FlowSchema", Releases: []string{"1.24", "1.25", "1.26"}},
+						},
+					},
+					{
+						Version:  "v1beta3",
+						Releases: []string{"1.25", "1.26"},
+						Resources: []APIResource{
+							{Kind: "FlowSchema", Releases: []string{"1.25", "1.26"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := addProjectedReleases(tl, 3, base.Add(250*day)); err != nil {
+		t.Fatalf("Failed to project releases: %v", err)
+	}
+
+	if len(tl.Releases) != 6 {
+		t.Fatalf("Expected 6 releases, got %d.", len(tl.Releases))
+	}
+
+	projected := tl.Releases[3]
+	if !projected.Projected || projected.Version != "1.27" || !projected.ReleaseDate.Equal(base.Add(300*day)) {
+		t.Errorf("Unexpected first projected release: %+v", projected)
+	}
+
+	if projected.SupportPhase != SupportPhaseUpcoming || projected.DaysSinceRelease != -50 {
+		t.Errorf("Expected the projected release to be upcoming in 50 days, got %q and %d.", projected.SupportPhase, projected.DaysSinceRelease)
+	}
+
+	// v1beta2 was superseded in 1.25 and must be kept for 3 releases (until 1.27)
+	group := tl.APIGroups[0]
+	if expected, actual := "1.24 1.25 1.26 1.27", strings.Join(group.APIVersions[0].Releases, " "); expected != actual {
+		t.Errorf("Expected v1beta2 in %q, got %q.", expected, actual)
+	}
+
+	if expected, actual := "1.25 1.26 1.27 1.28 1.29", strings.Join(group.APIVersions[1].Releases, " "); expected != actual {
+		t.Errorf("Expected v1beta3 in %q, got %q.", expected, actual)
+	}
+
+	if preferred := group.PreferredVersion("1.28"); preferred != "v1beta3" {
+		t.Errorf("Expected v1beta3 to be preferred in 1.28, got %q.", preferred)
+	}
+}
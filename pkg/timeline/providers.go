@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+// ProviderSupport describes the availability of a release on a managed
+// Kubernetes offering like EKS.
+type ProviderSupport struct {
+	Provider             string
+	Available            time.Time
+	SupportedUntil       *time.Time // end of standard support, nil if not announced yet
+	ExtendedSupportUntil *time.Time // end of extended support, nil if not offered
+	Supported            bool       // true if the provider supports the release (including extended support)
+}
+
+// Provider returns the support information for the given provider (e.g.
+// "EKS"), or nil if the release is not known to be available there.
+func (r *ReleaseMetadata) Provider(name string) *ProviderSupport {
+	for i, provider := range r.Providers {
+		if provider.Provider == name {
+			return &r.Providers[i]
+		}
+	}
+
+	return nil
+}
+
+// convertProviderSupport turns the provider data from the database into its
+// timeline representation, leaving out providers that did not offer the
+// release yet at the given time.
+func convertProviderSupport(providers []types.ProviderSupport, now time.Time) []ProviderSupport {
+	var result []ProviderSupport
+
+	for _, provider := range providers {
+		// dates have already been validated by the database
+		available := parseDate(provider.Available)
+		if available == nil || now.Before(*available) {
+			continue
+		}
+
+		support := ProviderSupport{
+			Provider:             provider.Provider,
+			Available:            *available,
+			SupportedUntil:       parseDate(provider.SupportedUntil),
+			ExtendedSupportUntil: parseDate(provider.ExtendedSupportUntil),
+		}
+
+		end := support.SupportedUntil
+		if support.ExtendedSupportUntil != nil {
+			end = support.ExtendedSupportUntil
+		}
+
+		support.Supported = end == nil || !now.After(*end)
+
+		result = append(result, support)
+	}
+
+	return result
+}
+
+func parseDate(s string) *time.Time {
+	date, err := time.ParseInLocation("2006-01-02", s, time.UTC)
+	if err != nil {
+		return nil
+	}
+
+	return &date
+}
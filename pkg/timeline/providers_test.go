@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"testing"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+func TestProviderSupport(t *testing.T) {
+	providers := []types.ProviderSupport{
+		{Provider: "AKS", Available: "2023-10-01"},
+		{Provider: "EKS", Available: "2023-09-26", SupportedUntil: "2024-11-26", ExtendedSupportUntil: "2025-11-26"},
+		{Provider: "GKE", Available: "2023-08-30", SupportedUntil: "2024-10-31"},
+	}
+
+	testcases := []struct {
+		now       time.Time
+		providers []string
+		supported []bool
+	}{
+		{
+			now:       time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC),
+			providers: []string{"GKE"},
+			supported: []bool{true},
+		},
+		{
+			now:       time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+			providers: []string{"AKS", "EKS", "GKE"},
+			supported: []bool{true, true, false},
+		},
+		{
+			now:       time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+			providers: []string{"AKS", "EKS", "GKE"},
+			supported: []bool{true, false, false},
+		},
+	}
+
+	for _, tc := range testcases {
+		release := ReleaseMetadata{Providers: convertProviderSupport(providers, tc.now)}
+
+		if len(release.Providers) != len(tc.providers) {
+			t.Errorf("Expected %v on %s, got %+v.", tc.providers, tc.now.Format("2006-01-02"), release.Providers)
+			continue
+		}
+
+		for i, name := range tc.providers {
+			provider := release.Provider(name)
+			if provider == nil {
+				t.Errorf("Expected %s to be available on %s.", name, tc.now.Format("2006-01-02"))
+				continue
+			}
+
+			if provider.Supported != tc.supported[i] {
+				t.Errorf("Expected %s support on %s to be %v, got %v.", name, tc.now.Format("2006-01-02"), tc.supported[i], provider.Supported)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReferenceDocsURL returns the URL of a resource in the official API
+// reference on kubernetes.io for the given release, like
+// "https://v1-28.docs.kubernetes.io/docs/reference/generated/kubernetes-api/v1.28/#ingress-v1-networking-k8s-io".
+// The versioned docs are used, so that links keep pointing to the same
+// release after newer ones have been published.
+func ReferenceDocsURL(group, version, kind, release string) string {
+	anchor := fmt.Sprintf("%s-%s-%s", strings.ToLower(kind), version, strings.ReplaceAll(group, ".", "-"))
+
+	return fmt.Sprintf("https://v%s.docs.kubernetes.io/docs/reference/generated/kubernetes-api/v%s/#%s", strings.ReplaceAll(release, ".", "-"), release, anchor)
+}
+
+// LatestReferenceDocs returns the URL of the official API reference of the
+// most recent release serving the resource, if any.
+func (o *APIResource) LatestReferenceDocs() string {
+	for i := len(o.Releases) - 1; i >= 0; i-- {
+		if url, ok := o.ReferenceDocs[o.Releases[i]]; ok {
+			return url
+		}
+	}
+
+	return ""
+}
+
+// calculateReferenceDocs links every resource to the official API reference
+// of each release serving it. Projected releases and prereleases have no
+// published reference yet.
+func calculateReferenceDocs(tl *Timeline) {
+	published := map[string]bool{}
+	for _, release := range tl.Releases {
+		published[release.Version] = !release.Projected && !release.PreRelease
+	}
+
+	for i, apiGroup := range tl.APIGroups {
+		for j, apiVersion := range apiGroup.APIVersions {
+			for k, apiResource := range apiVersion.Resources {
+				docs := map[string]string{}
+
+				for _, release := range apiResource.Releases {
+					if published[release] {
+						docs[release] = ReferenceDocsURL(apiGroup.Name, apiVersion.Version, apiResource.Kind, release)
+					}
+				}
+
+				tl.APIGroups[i].APIVersions[j].Resources[k].ReferenceDocs = docs
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReferenceDocsURL(t *testing.T) {
+	testcases := []struct {
+		group    string
+		version  string
+		kind     string
+		release  string
+		expected string
+	}{
+		{
+			group:    "core",
+			version:  "v1",
+			kind:     "Pod",
+			release:  "1.28",
+			expected: "https://v1-28.docs.kubernetes.io/docs/reference/generated/kubernetes-api/v1.28/#pod-v1-core",
+		},
+		{
+			group:    "networking.k8s.io",
+			version:  "v1beta1",
+			kind:     "IngressClass",
+			release:  "1.18",
+			expected: "https://v1-18.docs.kubernetes.io/docs/reference/generated/kubernetes-api/v1.18/#ingressclass-v1beta1-networking-k8s-io",
+		},
+	}
+
+	for _, tc := range testcases {
+		if url := ReferenceDocsURL(tc.group, tc.version, tc.kind, tc.release); url != tc.expected {
+			t.Errorf("Expected %q, got %q.", tc.expected, url)
+		}
+	}
+}
+
+func TestCalculateReferenceDocs(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.27"},
+			{Version: "1.28"},
+			{Version: "1.29", PreRelease: true},
+			{Version: "1.30", Projected: true},
+		},
+		APIGroups: []APIGroup{{
+			Name: "apps",
+			APIVersions: []APIVersion{{
+				Version: "v1",
+				Resources: []APIResource{{
+					Kind:     "Deployment",
+					Releases: []string{"1.27", "1.28", "1.29", "1.30"},
+				}},
+			}},
+		}},
+	}
+
+	calculateReferenceDocs(tl)
+
+	resource := tl.APIGroups[0].APIVersions[0].Resources[0]
+	expected := map[string]string{
+		"1.27": ReferenceDocsURL("apps", "v1", "Deployment", "1.27"),
+		"1.28": ReferenceDocsURL("apps", "v1", "Deployment", "1.28"),
+	}
+
+	if !reflect.DeepEqual(resource.ReferenceDocs, expected) {
+		t.Errorf("Expected\n%v\nbut got\n%v", expected, resource.ReferenceDocs)
+	}
+
+	if url := resource.LatestReferenceDocs(); url != expected["1.28"] {
+		t.Errorf("Expected latest reference docs to be %q, got %q.", expected["1.28"], url)
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"regexp"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ReleaseNotesIn returns the upstream changelog entries that explain the
+// changes of the resource in the given release of interest.
+func (o *APIResource) ReleaseNotesIn(release string) []types.ReleaseNote {
+	return o.ReleaseNotes[release]
+}
+
+// attachReleaseNotes links the upstream changelog entries of a release to
+// the resources for which the release is a release of interest. Entries are
+// matched by mentioning the resource's kind; if the kind exists in multiple
+// API groups (like Ingress), the group must be mentioned as well.
+func attachReleaseNotes(tl *Timeline, notes map[string][]types.ReleaseNote) {
+	if len(notes) == 0 {
+		return
+	}
+
+	kindGroups := map[string]sets.Set[string]{}
+	for _, apiGroup := range tl.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				if kindGroups[apiResource.Kind] == nil {
+					kindGroups[apiResource.Kind] = sets.New[string]()
+				}
+
+				kindGroups[apiResource.Kind].Insert(apiGroup.Name)
+			}
+		}
+	}
+
+	for i, apiGroup := range tl.APIGroups {
+		// the core group is never mentioned by name
+		var groupPattern *regexp.Regexp
+		if apiGroup.Name != "core" {
+			groupPattern = wordPattern(regexp.QuoteMeta(apiGroup.Name))
+		}
+
+		for j, apiVersion := range apiGroup.APIVersions {
+			for k, apiResource := range apiVersion.Resources {
+				if len(apiResource.ReleasesOfInterest) == 0 {
+					continue
+				}
+
+				// allow plurals like "CronJobs"
+				kindPattern := wordPattern(regexp.QuoteMeta(apiResource.Kind) + "s?")
+				ambiguous := kindGroups[apiResource.Kind].Len() > 1
+
+				resourceNotes := map[string][]types.ReleaseNote{}
+
+				for _, release := range apiResource.ReleasesOfInterest {
+					for _, note := range notes[release] {
+						if !kindPattern.MatchString(note.Text) {
+							continue
+						}
+
+						if ambiguous && (groupPattern == nil || !groupPattern.MatchString(note.Text)) {
+							continue
+						}
+
+						resourceNotes[release] = append(resourceNotes[release], note)
+					}
+				}
+
+				if len(resourceNotes) > 0 {
+					tl.APIGroups[i].APIVersions[j].Resources[k].ReleaseNotes = resourceNotes
+				}
+			}
+		}
+	}
+}
+
+// wordPattern matches the expression only as a whole word; unlike with \b,
+// dashes (as in "batch-job") do not count as word boundaries.
+func wordPattern(expr string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|[^\w-])` + expr + `($|[^\w-])`)
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+func TestAttachReleaseNotes(t *testing.T) {
+	tl := &Timeline{
+		APIGroups: []APIGroup{
+			{
+				Name: "batch",
+				APIVersions: []APIVersion{{
+					Version: "v1",
+					Resources: []APIResource{
+						{Kind: "CronJob", ReleasesOfInterest: []string{"1.21"}},
+						{Kind: "Job", ReleasesOfInterest: []string{"1.21"}},
+					},
+				}},
+			},
+			{
+				Name: "extensions",
+				APIVersions: []APIVersion{{
+					Version: "v1beta1",
+					Resources: []APIResource{
+						{Kind: "Ingress", ReleasesOfInterest: []string{"1.22"}},
+					},
+				}},
+			},
+			{
+				Name: "networking.k8s.io",
+				APIVersions: []APIVersion{{
+					Version: "v1",
+					Resources: []APIResource{
+						{Kind: "Ingress", ReleasesOfInterest: []string{"1.22"}},
+					},
+				}},
+			},
+		},
+	}
+
+	cronJobs := types.ReleaseNote{Kind: "API Change", Text: "CronJobs are now generally available."}
+	jobs := types.ReleaseNote{Kind: "API Change", Text: "Added the `suspend` field to Job."}
+	ingress := types.ReleaseNote{Kind: "Deprecation", Text: "The extensions/v1beta1 Ingress API is no longer served."}
+	unrelated := types.ReleaseNote{Kind: "API Change", Text: "Ingress controllers should use a JobSet."}
+
+	notes := map[string][]types.ReleaseNote{
+		"1.21": {cronJobs, jobs, unrelated},
+		"1.22": {ingress, unrelated},
+	}
+
+	attachReleaseNotes(tl, notes)
+
+	testcases := []struct {
+		group    int
+		resource int
+		release  string
+		expected []types.ReleaseNote
+	}{
+		{group: 0, resource: 0, release: "1.21", expected: []types.ReleaseNote{cronJobs}},
+		// "JobSet" must not match the Job kind
+		{group: 0, resource: 1, release: "1.21", expected: []types.ReleaseNote{jobs}},
+		// Ingress exists in two groups, so the group must be mentioned
+		{group: 1, resource: 0, release: "1.22", expected: []types.ReleaseNote{ingress}},
+		{group: 2, resource: 0, release: "1.22", expected: nil},
+		// notes are only attached to releases of interest
+		{group: 0, resource: 0, release: "1.22", expected: nil},
+	}
+
+	for _, tc := range testcases {
+		apiGroup := tl.APIGroups[tc.group]
+		resource := apiGroup.APIVersions[0].Resources[tc.resource]
+
+		if attached := resource.ReleaseNotesIn(tc.release); !reflect.DeepEqual(attached, tc.expected) {
+			t.Errorf("Expected release notes of %s %s in %s to be %+v, got %+v.", apiGroup.Name, resource.Kind, tc.release, tc.expected, attached)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown renders the diff as a Markdown document with one section per
+// API group, suitable for upgrade runbooks and pull request descriptions.
+func (d *ReleaseDiff) WriteMarkdown(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# Kubernetes API changes from %s to %s\n\n", d.From, d.To)
+
+	if d.Empty() {
+		fmt.Fprintf(bw, "Kubernetes %s and %s offer the same APIs.\n", d.From, d.To)
+		return bw.Flush()
+	}
+
+	groups := d.ByGroup()
+	added, removed, changed := d.Count(DiffAdded), d.Count(DiffRemoved), d.Count(DiffChanged)
+
+	fmt.Fprintf(bw, "%d changes in %d API groups (%d added, %d removed, %d changed).\n", added+removed+changed, len(groups), added, removed, changed)
+
+	for _, group := range groups {
+		fmt.Fprintf(bw, "\n## %s\n\n", group.Name)
+		fmt.Fprintln(bw, "| Change | API | Details |")
+		fmt.Fprintln(bw, "| ------ | --- | ------- |")
+
+		for _, entry := range group.Entries {
+			fmt.Fprintf(bw, "| %s | %s | %s |\n", entry.Type, markdownAPIName(entry), markdownEscape(strings.Join(entry.Details, ", ")))
+		}
+	}
+
+	return bw.Flush()
+}
+
+func markdownAPIName(entry DiffEntry) string {
+	switch {
+	case entry.Version == "":
+		return fmt.Sprintf("`%s`", entry.Group)
+	case entry.Kind == "":
+		return fmt.Sprintf("`%s`", entry.APIVersion())
+	default:
+		return fmt.Sprintf("`%s` %s", entry.APIVersion(), entry.Kind)
+	}
+}
+
+func markdownEscape(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
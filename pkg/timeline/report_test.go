@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	tl := testTimeline()
+	tl.APIGroups[0].APIVersions[1].Resources[0].DeprecatedIn = "1.26"
+
+	diff, err := tl.Diff("1.24", "1.26")
+	if err != nil {
+		t.Fatalf("Failed to diff releases: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := diff.WriteMarkdown(&buf); err != nil {
+		t.Fatalf("Failed to write Markdown: %v", err)
+	}
+
+	expected := `# Kubernetes API changes from 1.24 to 1.26
+
+4 changes in 1 API groups (0 added, 3 removed, 1 changed).
+
+## batch
+
+| Change | API | Details |
+| ------ | --- | ------- |
+| removed | ` + "`batch/v1beta1`" + ` |  |
+| removed | ` + "`batch/v1beta1`" + ` CronJob |  |
+| changed | ` + "`batch/v1`" + ` CronJob | deprecated |
+| removed | ` + "`batch/v1`" + ` Job |  |
+`
+
+	if buf.String() != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	same, err := tl.Diff("1.25", "1.25")
+	if err != nil {
+		t.Fatalf("Failed to diff releases: %v", err)
+	}
+
+	buf.Reset()
+	if err := same.WriteMarkdown(&buf); err != nil {
+		t.Fatalf("Failed to write Markdown: %v", err)
+	}
+
+	expected = "# Kubernetes API changes from 1.25 to 1.25\n\nKubernetes 1.25 and 1.25 offer the same APIs.\n"
+	if buf.String() != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// the subset of SARIF 2.1.0 needed to report audit findings
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	HelpURI              string             `json:"helpUri"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// RuleID identifies the kind of finding, independent of the affected object,
+// e.g. "batch/v1beta1/CronJob/removed-1.25".
+func (f AuditFinding) RuleID() string {
+	id := fmt.Sprintf("%s/%s/%s/%s", f.Object.Group(), f.Object.Version(), f.Object.Kind, f.Status)
+	if f.Release != "" {
+		id += "-" + f.Release
+	}
+
+	return id
+}
+
+// WriteSARIF writes the findings as a SARIF 2.1.0 log, as understood by
+// GitHub code scanning and other static analysis tooling. Findings that do
+// not fail the audit are reported as warnings, unless strict is true.
+func (r *AuditReport) WriteSARIF(w io.Writer, toolVersion string, strict bool) error {
+	driver := sarifDriver{
+		Name:           "apininja",
+		Version:        toolVersion,
+		InformationURI: "https://kube-api.ninja/",
+		Rules:          []sarifRule{},
+	}
+
+	results := []sarifResult{}
+	ruleIndexes := map[string]int{}
+
+	for _, finding := range r.Findings {
+		level := "warning"
+		if strict || finding.Status == AuditRemoved || finding.Status == AuditUnavailable {
+			level = "error"
+		}
+
+		ruleID := finding.RuleID()
+
+		ruleIndex, exists := ruleIndexes[ruleID]
+		if !exists {
+			ruleIndex = len(driver.Rules)
+			ruleIndexes[ruleID] = ruleIndex
+
+			// the release notes list the removed and deprecated APIs
+			helpURI := driver.InformationURI
+			if finding.Release != "" {
+				helpURI += fmt.Sprintf("releases/%s.md", finding.Release)
+			}
+
+			driver.Rules = append(driver.Rules, sarifRule{
+				ID:                   ruleID,
+				ShortDescription:     sarifMessage{Text: fmt.Sprintf("%s %s: %s", finding.Object.APIVersion, finding.Object.Kind, finding)},
+				HelpURI:              helpURI,
+				DefaultConfiguration: sarifConfiguration{Level: level},
+			})
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    ruleID,
+			RuleIndex: ruleIndex,
+			Level:     level,
+			Message:   sarifMessage{Text: fmt.Sprintf("%s is not ready for Kubernetes %s: %s", finding.Object, r.Target, finding)},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(finding.Object.Source)},
+				},
+			}},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: driver},
+			Results: results,
+		}},
+	})
+}
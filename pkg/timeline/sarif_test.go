@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/manifest"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	cronJob := manifest.Object{Source: "deploy/cronjobs.yaml", APIVersion: "batch/v1beta1", Kind: "CronJob", Name: "backup"}
+	otherCronJob := cronJob
+	otherCronJob.Name = "cleanup"
+
+	report := &AuditReport{
+		Target: "1.26",
+		Findings: []AuditFinding{
+			{Object: cronJob, Status: AuditRemoved, Release: "1.25", Alternative: "v1"},
+			{Object: otherCronJob, Status: AuditRemoved, Release: "1.25", Alternative: "v1"},
+			{Object: manifest.Object{Source: "deploy/flow.yaml", APIVersion: "flowcontrol.apiserver.k8s.io/v1beta2", Kind: "FlowSchema"}, Status: AuditDeprecated, Release: "1.29"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf, "v1.0.0", false); err != nil {
+		t.Fatalf("Failed to write SARIF: %v", err)
+	}
+
+	log := sarifLog{}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("Failed to decode SARIF: %v", err)
+	}
+
+	run := log.Runs[0]
+
+	// both CronJobs share the same rule
+	if len(run.Tool.Driver.Rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %+v.", run.Tool.Driver.Rules)
+	}
+
+	if id := run.Tool.Driver.Rules[0].ID; id != "batch/v1beta1/CronJob/removed-1.25" {
+		t.Errorf("Unexpected rule ID %q.", id)
+	}
+
+	if len(run.Results) != 3 {
+		t.Fatalf("Expected 3 results, got %d.", len(run.Results))
+	}
+
+	expectedLevels := []string{"error", "error", "warning"}
+	for i, result := range run.Results {
+		if result.Level != expectedLevels[i] {
+			t.Errorf("Expected result %d to be a %s, got %s.", i, expectedLevels[i], result.Level)
+		}
+	}
+
+	if run.Results[1].RuleIndex != 0 || run.Results[2].RuleIndex != 1 {
+		t.Errorf("Results reference the wrong rules: %+v", run.Results)
+	}
+
+	if uri := run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "deploy/flow.yaml" {
+		t.Errorf("Unexpected location %q.", uri)
+	}
+}
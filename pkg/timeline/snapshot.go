@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+// SnapshotReport is the result of comparing a cluster snapshot (as created
+// by the clusterdumper) against a target release.
+type SnapshotReport struct {
+	// Release is the cluster's release according to the snapshot.
+	Release string
+	Target  string
+
+	// Removals lists all API resources served by the cluster that are not
+	// served anymore by the target release.
+	Removals []SnapshotRemoval
+
+	// Unknown lists all API resources that are not part of Kubernetes itself,
+	// like CRDs or aggregated APIs, which cannot be checked.
+	Unknown []string
+}
+
+// SnapshotRemoval is a removed resource, plus the API version in the same
+// API group that replaces it in the target release (if any).
+type SnapshotRemoval struct {
+	RemovedResource
+	Alternative string
+}
+
+// CompareSnapshot checks which of the API resources served by a cluster
+// are not available anymore in the target release.
+func (o *Timeline) CompareSnapshot(snapshot *types.KubernetesAPI, target string) (*SnapshotReport, error) {
+	targetIdx := o.releaseIndex(target)
+	if targetIdx < 0 {
+		return nil, fmt.Errorf("%w %q", ErrUnknownRelease, target)
+	}
+
+	// snapshots of releases newer than the database are still useful
+	clusterIdx := o.releaseIndex(snapshot.Release)
+	if clusterIdx > targetIdx {
+		return nil, fmt.Errorf("cannot compare %s snapshot against older release %s", snapshot.Release, target)
+	}
+
+	report := &SnapshotReport{
+		Release:  snapshot.Release,
+		Target:   target,
+		Removals: []SnapshotRemoval{},
+		Unknown:  []string{},
+	}
+
+	for _, snapGroup := range snapshot.APIGroups {
+		groupName := snapGroup.Name
+		if groupName == "" {
+			groupName = "core"
+		}
+
+		for _, snapVersion := range snapGroup.APIVersions {
+			resources := snapVersion.Resources
+
+			// `kubectl api-versions` does not list resources, so assume that
+			// all known resources of the version are served
+			if len(resources) == 0 {
+				resources = o.knownResources(groupName, snapVersion.Version, snapshot.Release)
+			}
+
+			for _, snapResource := range resources {
+				apiGroup, apiResource := o.findResource(groupName, snapVersion.Version, snapResource.Kind)
+				if apiResource == nil {
+					report.Unknown = append(report.Unknown, fmt.Sprintf("%s/%s %s", groupName, snapVersion.Version, snapResource.Kind))
+					continue
+				}
+
+				if apiResource.HasRelease(target) {
+					continue
+				}
+
+				removedIn := target
+				for i := clusterIdx + 1; i < targetIdx; i++ {
+					if !apiResource.HasRelease(o.Releases[i].Version) {
+						removedIn = o.Releases[i].Version
+						break
+					}
+				}
+
+				report.Removals = append(report.Removals, SnapshotRemoval{
+					RemovedResource: RemovedResource{
+						Group:     groupName,
+						Version:   snapVersion.Version,
+						Kind:      snapResource.Kind,
+						RemovedIn: removedIn,
+					},
+					Alternative: alternativeVersion(apiGroup, snapResource.Kind, target),
+				})
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// knownResources returns all resources of an API version that are served in
+// the given release (or in any release, if the release is unknown).
+func (o *Timeline) knownResources(group, apiVersion, release string) []types.Resource {
+	result := []types.Resource{}
+
+	for _, apiGroup := range o.APIGroups {
+		if apiGroup.Name != group {
+			continue
+		}
+
+		for _, ver := range apiGroup.APIVersions {
+			if ver.Version != apiVersion {
+				continue
+			}
+
+			for _, res := range ver.Resources {
+				if release == "" || !o.HasRelease(release) || res.HasRelease(release) {
+					result = append(result, types.Resource{Kind: res.Kind})
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func (o *Timeline) findResource(group, apiVersion, kind string) (*APIGroup, *APIResource) {
+	for i, apiGroup := range o.APIGroups {
+		if apiGroup.Name != group {
+			continue
+		}
+
+		for j, ver := range apiGroup.APIVersions {
+			if ver.Version != apiVersion {
+				continue
+			}
+
+			for k, res := range ver.Resources {
+				if res.Kind == kind {
+					return &o.APIGroups[i], &o.APIGroups[i].APIVersions[j].Resources[k]
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// alternativeVersion returns the most mature API version in the group that
+// serves the kind in the given release.
+func alternativeVersion(apiGroup *APIGroup, kind string, release string) string {
+	var best *version.APIVersion
+
+	for _, apiVersion := range apiGroup.APIVersions {
+		for _, apiResource := range apiVersion.Resources {
+			if apiResource.Kind != kind || !apiResource.HasRelease(release) {
+				continue
+			}
+
+			parsed, err := version.ParseAPIVersion(apiVersion.Version)
+			if err != nil {
+				continue
+			}
+
+			if best == nil || best.LessThan(parsed) {
+				best = parsed
+			}
+		}
+	}
+
+	if best == nil {
+		return ""
+	}
+
+	return best.String()
+}
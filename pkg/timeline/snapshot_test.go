@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"errors"
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+func TestCompareSnapshot(t *testing.T) {
+	tl := testTimeline()
+
+	snapshot := &types.KubernetesAPI{
+		Version: "1.24.3",
+		Release: "1.24",
+		APIGroups: []types.APIGroup{
+			{
+				Name: "batch",
+				APIVersions: []types.APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []types.Resource{
+							{Kind: "CronJob"},
+						},
+					},
+					{
+						Version: "v1",
+						Resources: []types.Resource{
+							{Kind: "CronJob"},
+							{Kind: "Job"},
+						},
+					},
+				},
+			},
+			{
+				Name: "example.com",
+				APIVersions: []types.APIVersion{
+					{
+						Version: "v1",
+						Resources: []types.Resource{
+							{Kind: "Widget"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	report, err := tl.CompareSnapshot(snapshot, "1.26")
+	if err != nil {
+		t.Fatalf("Failed to compare snapshot: %v", err)
+	}
+
+	if len(report.Removals) != 2 {
+		t.Fatalf("Expected 2 removals, got %v", report.Removals)
+	}
+
+	cronjob := report.Removals[0]
+	if cronjob.String() != "batch/v1beta1 CronJob" || cronjob.RemovedIn != "1.25" || cronjob.Alternative != "v1" {
+		t.Errorf("Expected batch/v1beta1 CronJob to be removed in 1.25 with v1 alternative, got %+v", cronjob)
+	}
+
+	job := report.Removals[1]
+	if job.String() != "batch/v1 Job" || job.RemovedIn != "1.26" || job.Alternative != "" {
+		t.Errorf("Expected batch/v1 Job to be removed in 1.26 without alternative, got %+v", job)
+	}
+
+	if len(report.Unknown) != 1 || report.Unknown[0] != "example.com/v1 Widget" {
+		t.Errorf("Expected example.com/v1 Widget to be unknown, got %v", report.Unknown)
+	}
+}
+
+func TestCompareSnapshotWithoutResources(t *testing.T) {
+	tl := testTimeline()
+
+	snapshot := &types.KubernetesAPI{
+		Release: "1.24",
+		APIGroups: []types.APIGroup{{
+			Name:        "batch",
+			APIVersions: []types.APIVersion{{Version: "v1beta1"}},
+		}},
+	}
+
+	report, err := tl.CompareSnapshot(snapshot, "1.25")
+	if err != nil {
+		t.Fatalf("Failed to compare snapshot: %v", err)
+	}
+
+	if len(report.Removals) != 1 || report.Removals[0].String() != "batch/v1beta1 CronJob" {
+		t.Fatalf("Expected batch/v1beta1 CronJob to be removed, got %v", report.Removals)
+	}
+}
+
+func TestCompareSnapshotUnknownTarget(t *testing.T) {
+	tl := testTimeline()
+
+	_, err := tl.CompareSnapshot(&types.KubernetesAPI{Release: "1.24"}, "1.99")
+	if !errors.Is(err, ErrUnknownRelease) {
+		t.Fatalf("Expected ErrUnknownRelease, got %v", err)
+	}
+}
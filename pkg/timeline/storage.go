@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"sort"
+)
+
+// StorageVersionChange describes a release in which the API server started
+// to persist a kind in another API version, which usually requires a
+// storage migration before upgrading further.
+type StorageVersionChange struct {
+	Release string
+	Kind    string
+	From    string
+	To      string
+}
+
+// StorageVersion returns the API version a kind is persisted as in the
+// given release, or an empty string if unknown.
+func (o *APIGroup) StorageVersion(release string, kind string) string {
+	return o.StorageVersions[release][kind]
+}
+
+func calculateStorageVersionChanges(tl *Timeline) {
+	for i, apiGroup := range tl.APIGroups {
+		tl.APIGroups[i].StorageVersionChanges = storageVersionChanges(&apiGroup, tl.Releases)
+	}
+}
+
+func storageVersionChanges(apiGroup *APIGroup, releases []ReleaseMetadata) []StorageVersionChange {
+	var changes []StorageVersionChange
+
+	// kind => storage version in the last release that knew it
+	last := map[string]string{}
+
+	for _, release := range releases {
+		versions, ok := apiGroup.StorageVersions[release.Version]
+		if !ok {
+			// releases without data (e.g. dumped from Swagger specs) are skipped
+			continue
+		}
+
+		kinds := []string{}
+		for kind := range versions {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+
+		for _, kind := range kinds {
+			previous, known := last[kind]
+			current := versions[kind]
+
+			if known && previous != current {
+				changes = append(changes, StorageVersionChange{
+					Release: release.Version,
+					Kind:    kind,
+					From:    previous,
+					To:      current,
+				})
+			}
+
+			last[kind] = current
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStorageVersionChanges(t *testing.T) {
+	releases := []ReleaseMetadata{
+		{Version: "1.20"},
+		{Version: "1.21"},
+		{Version: "1.22"},
+		{Version: "1.23"},
+	}
+
+	apiGroup := &APIGroup{
+		Name: "batch",
+		// 1.21 was dumped from a Swagger spec and has no storage versions
+		StorageVersions: map[string]map[string]string{
+			"1.20": {"Job": "batch/v1", "CronJob": "batch/v1beta1"},
+			"1.22": {"Job": "batch/v1", "CronJob": "batch/v1beta1"},
+			"1.23": {"Job": "batch/v1", "CronJob": "batch/v1"},
+		},
+	}
+
+	expected := []StorageVersionChange{
+		{Release: "1.23", Kind: "CronJob", From: "batch/v1beta1", To: "batch/v1"},
+	}
+
+	if changes := storageVersionChanges(apiGroup, releases); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected %+v, but got %+v.", expected, changes)
+	}
+
+	if v := apiGroup.StorageVersion("1.20", "CronJob"); v != "batch/v1beta1" {
+		t.Errorf("Expected CronJob to be stored as batch/v1beta1 in 1.20, but got %q.", v)
+	}
+
+	if v := apiGroup.StorageVersion("1.21", "CronJob"); v != "" {
+		t.Errorf("Expected unknown storage version in 1.21, but got %q.", v)
+	}
+}
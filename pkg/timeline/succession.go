@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+// SuccessionType describes how an API version took over a resource from
+// another API version.
+type SuccessionType string
+
+const (
+	// Graduation means a newer version in the same API group took over.
+	Graduation SuccessionType = "graduation"
+	// Replacement means a version in another API group took over.
+	Replacement SuccessionType = "replacement"
+)
+
+// ResourceSuccession points to a resource in another API version, e.g. from
+// extensions/v1beta1 Ingress to networking.k8s.io/v1beta1 Ingress.
+type ResourceSuccession struct {
+	Group   string
+	Version string
+	Kind    string
+	Type    SuccessionType
+}
+
+type resourceOccurrence struct {
+	group    int
+	version  int
+	resource int
+	parsed   *version.APIVersion
+	first    int
+	last     int
+}
+
+// calculateSuccessions links every resource to the API version that took it
+// over (SucceededBy) and, in reverse, to the API versions it took over from
+// (Replaces).
+func calculateSuccessions(tl *Timeline) error {
+	releaseIndex := map[string]int{}
+	for i, rel := range tl.Releases {
+		releaseIndex[rel.Version] = i
+	}
+
+	// kind => all resources of that kind
+	occurrences := map[string][]resourceOccurrence{}
+	kinds := []string{}
+
+	for i, apiGroup := range tl.APIGroups {
+		for j, apiVersion := range apiGroup.APIVersions {
+			parsed, err := version.ParseAPIVersion(apiVersion.Version)
+			if err != nil {
+				return fmt.Errorf("invalid API version %s/%s: %w", apiGroup.Name, apiVersion.Version, err)
+			}
+
+			for k, apiResource := range apiVersion.Resources {
+				first, last := releaseRange(apiResource.Releases, releaseIndex)
+				if first < 0 {
+					continue
+				}
+
+				if _, exists := occurrences[apiResource.Kind]; !exists {
+					kinds = append(kinds, apiResource.Kind)
+				}
+
+				occurrences[apiResource.Kind] = append(occurrences[apiResource.Kind], resourceOccurrence{
+					group:    i,
+					version:  j,
+					resource: k,
+					parsed:   parsed,
+					first:    first,
+					last:     last,
+				})
+			}
+		}
+	}
+
+	// iterate in a stable order, so that Replaces is sorted deterministically
+	for _, kind := range kinds {
+		occs := occurrences[kind]
+
+		for _, occ := range occs {
+			successor, successionType := findSuccessor(occ, occs, len(tl.Releases)-1)
+			if successor == nil {
+				continue
+			}
+
+			resource := &tl.APIGroups[occ.group].APIVersions[occ.version].Resources[occ.resource]
+			resource.SucceededBy = &ResourceSuccession{
+				Group:   tl.APIGroups[successor.group].Name,
+				Version: tl.APIGroups[successor.group].APIVersions[successor.version].Version,
+				Kind:    kind,
+				Type:    successionType,
+			}
+
+			successorResource := &tl.APIGroups[successor.group].APIVersions[successor.version].Resources[successor.resource]
+			successorResource.Replaces = append(successorResource.Replaces, ResourceSuccession{
+				Group:   tl.APIGroups[occ.group].Name,
+				Version: tl.APIGroups[occ.group].APIVersions[occ.version].Version,
+				Kind:    kind,
+				Type:    successionType,
+			})
+		}
+	}
+
+	return nil
+}
+
+// findSuccessor returns the API version that took over the resource: the
+// next newer version in the same API group or, if there is none and the
+// resource was removed, the earliest other API group offering the same kind.
+func findSuccessor(occ resourceOccurrence, candidates []resourceOccurrence, lastRelease int) (*resourceOccurrence, SuccessionType) {
+	var best *resourceOccurrence
+
+	for i, candidate := range candidates {
+		if candidate.group != occ.group || !occ.parsed.LessThan(candidate.parsed) {
+			continue
+		}
+
+		// a new alpha version (like batch/v2alpha1) does not supersede a
+		// stable or beta one
+		if !candidate.parsed.MoreMatureThan(occ.parsed) {
+			continue
+		}
+
+		if best == nil || candidate.parsed.LessThan(best.parsed) {
+			best = &candidates[i]
+		}
+	}
+
+	if best != nil {
+		return best, Graduation
+	}
+
+	// stable versions or resources that are still around are not replaced
+	if occ.parsed.IsGA() || occ.last == lastRelease {
+		return nil, ""
+	}
+
+	for i, candidate := range candidates {
+		if candidate.group == occ.group {
+			continue
+		}
+
+		// must have appeared after the resource and before it was gone
+		if candidate.first <= occ.first || candidate.first > occ.last+1 {
+			continue
+		}
+
+		if best == nil || candidate.first < best.first || (candidate.first == best.first && candidate.parsed.LessThan(best.parsed)) {
+			best = &candidates[i]
+		}
+	}
+
+	if best != nil {
+		return best, Replacement
+	}
+
+	return nil, ""
+}
+
+func releaseRange(releases []string, releaseIndex map[string]int) (int, int) {
+	first, last := -1, -1
+
+	for _, release := range releases {
+		idx, exists := releaseIndex[release]
+		if !exists {
+			continue
+		}
+
+		if first < 0 || idx < first {
+			first = idx
+		}
+
+		if idx > last {
+			last = idx
+		}
+	}
+
+	return first, last
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateSuccessions(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.18"},
+			{Version: "1.19"},
+			{Version: "1.20"},
+			{Version: "1.21"},
+			{Version: "1.22"},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "extensions",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "Ingress", Releases: []string{"1.18", "1.19", "1.20", "1.21"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "networking.k8s.io",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "Ingress", Releases: []string{"1.19", "1.20", "1.21"}},
+						},
+					},
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "Ingress", Releases: []string{"1.19", "1.20", "1.21", "1.22"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "batch",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "Job", Releases: []string{"1.18", "1.19", "1.20", "1.21", "1.22"}},
+						},
+					},
+					{
+						Version: "v2alpha1",
+						Resources: []APIResource{
+							{Kind: "Job", Releases: []string{"1.20"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := calculateSuccessions(tl); err != nil {
+		t.Fatalf("Failed to calculate successions: %v", err)
+	}
+
+	testcases := []struct {
+		group       int
+		version     int
+		succeededBy *ResourceSuccession
+		replaces    []ResourceSuccession
+	}{
+		{
+			group:       0,
+			version:     0,
+			succeededBy: &ResourceSuccession{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Ingress", Type: Replacement},
+		},
+		{
+			group:       1,
+			version:     0,
+			succeededBy: &ResourceSuccession{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress", Type: Graduation},
+			replaces: []ResourceSuccession{
+				{Group: "extensions", Version: "v1beta1", Kind: "Ingress", Type: Replacement},
+			},
+		},
+		{
+			group:   1,
+			version: 1,
+			replaces: []ResourceSuccession{
+				{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Ingress", Type: Graduation},
+			},
+		},
+		// a new alpha version does not supersede a stable one
+		{
+			group:   2,
+			version: 0,
+		},
+	}
+
+	for _, tc := range testcases {
+		apiGroup := tl.APIGroups[tc.group]
+		resource := apiGroup.APIVersions[tc.version].Resources[0]
+		name := apiGroup.Name + "/" + apiGroup.APIVersions[tc.version].Version
+
+		if !reflect.DeepEqual(resource.SucceededBy, tc.succeededBy) {
+			t.Errorf("Expected %s to be succeeded by %+v, but got %+v.", name, tc.succeededBy, resource.SucceededBy)
+		}
+
+		if !reflect.DeepEqual(resource.Replaces, tc.replaces) {
+			t.Errorf("Expected %s to replace %+v, but got %+v.", name, tc.replaces, resource.Replaces)
+		}
+	}
+}
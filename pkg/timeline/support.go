@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"time"
+)
+
+type SupportPhase string
+
+const (
+	// SupportPhaseUpcoming means the release has not been released yet.
+	SupportPhaseUpcoming SupportPhase = "upcoming"
+	// SupportPhaseActive means the release receives regular patch releases.
+	SupportPhaseActive SupportPhase = "active"
+	// SupportPhaseMaintenance means the release is close to its end of life
+	// and only receives fixes for critical bugs and security issues.
+	SupportPhaseMaintenance SupportPhase = "maintenance"
+	// SupportPhaseEOL means the release is not supported anymore.
+	SupportPhaseEOL SupportPhase = "eol"
+)
+
+// maintenanceModeMonths is how long before the EOL date a release enters
+// maintenance mode, as per the Kubernetes patch release policy.
+const maintenanceModeMonths = 2
+
+// setSupportDates fills in the fields derived from the release and EOL date,
+// so that templates and API consumers do not have to compare them to "now".
+func (r *ReleaseMetadata) setSupportDates(now time.Time) {
+	r.DaysSinceRelease = daysBetween(r.ReleaseDate, now)
+	r.DaysUntilEOL = nil
+	r.MaintenanceModeDate = nil
+
+	if r.EndOfLifeDate != nil {
+		days := daysBetween(now, *r.EndOfLifeDate)
+		maintenance := r.EndOfLifeDate.AddDate(0, -maintenanceModeMonths, 0)
+
+		r.DaysUntilEOL = &days
+		r.MaintenanceModeDate = &maintenance
+	}
+
+	switch {
+	case !r.Released:
+		r.SupportPhase = SupportPhaseUpcoming
+	case !r.Supported:
+		r.SupportPhase = SupportPhaseEOL
+	case r.MaintenanceModeDate != nil && !now.Before(*r.MaintenanceModeDate):
+		r.SupportPhase = SupportPhaseMaintenance
+	default:
+		r.SupportPhase = SupportPhaseActive
+	}
+}
+
+// daysBetween returns the number of calendar days (in UTC) from one date to
+// another, which is negative if "to" is before "from".
+func daysBetween(from time.Time, to time.Time) int {
+	from = from.UTC().Truncate(24 * time.Hour)
+	to = to.UTC().Truncate(24 * time.Hour)
+
+	return int(to.Sub(from).Hours() / 24)
+}
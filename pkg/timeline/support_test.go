@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetSupportDates(t *testing.T) {
+	date := func(s string) time.Time {
+		parsed, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return parsed
+	}
+
+	releaseDate := date("2023-12-13")
+	eol := date("2025-02-28")
+
+	testcases := []struct {
+		now           string
+		released      bool
+		supported     bool
+		eol           *time.Time
+		expectedPhase SupportPhase
+		expectedDays  *int
+	}{
+		{now: "2023-12-01", eol: &eol, expectedPhase: SupportPhaseUpcoming, expectedDays: ptr(455)},
+		{now: "2024-06-01", released: true, supported: true, expectedPhase: SupportPhaseActive},
+		{now: "2024-06-01", released: true, supported: true, eol: &eol, expectedPhase: SupportPhaseActive, expectedDays: ptr(272)},
+		{now: "2024-12-28", released: true, supported: true, eol: &eol, expectedPhase: SupportPhaseMaintenance, expectedDays: ptr(62)},
+		{now: "2025-03-10", released: true, eol: &eol, expectedPhase: SupportPhaseEOL, expectedDays: ptr(-10)},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.now, func(t *testing.T) {
+			now := date(tc.now).Add(12 * time.Hour)
+
+			release := ReleaseMetadata{
+				Released:      tc.released,
+				Supported:     tc.supported,
+				ReleaseDate:   releaseDate,
+				EndOfLifeDate: tc.eol,
+			}
+			release.setSupportDates(now)
+
+			if release.SupportPhase != tc.expectedPhase {
+				t.Errorf("Expected phase %q, got %q.", tc.expectedPhase, release.SupportPhase)
+			}
+
+			if (tc.expectedDays == nil) != (release.DaysUntilEOL == nil) || (tc.expectedDays != nil && *tc.expectedDays != *release.DaysUntilEOL) {
+				t.Errorf("Expected %v days until EOL, got %v.", deref(tc.expectedDays), deref(release.DaysUntilEOL))
+			}
+
+			if expected := daysBetween(releaseDate, now); release.DaysSinceRelease != expected {
+				t.Errorf("Expected %d days since release, got %d.", expected, release.DaysSinceRelease)
+			}
+		})
+	}
+}
+
+func ptr(i int) *int {
+	return &i
+}
+
+func deref(i *int) any {
+	if i == nil {
+		return nil
+	}
+
+	return *i
+}
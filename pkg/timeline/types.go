@@ -3,8 +3,13 @@
 
 package timeline
 
-import "time"
+import (
+	"time"
 
+	"go.xrstf.de/kube-api.ninja/pkg/types"
+)
+
+// Timeline is the merged view of all API groups across all releases.
 type Timeline struct {
 	APIGroups []APIGroup
 	Releases  []ReleaseMetadata
@@ -15,9 +20,32 @@ type ReleaseMetadata struct {
 	Released      bool
 	Supported     bool
 	Archived      bool
+	Projected     bool // true for speculative future releases, see WithProjectedReleases
+	PreRelease    bool // true for the upcoming release, whose data is based on alpha/beta/RC builds, see WithUnreleased
 	ReleaseDate   time.Time
 	EndOfLifeDate *time.Time
 	LatestVersion string
+	PatchReleases []PatchRelease // known patch releases, oldest first
+	Clients       *types.ClientVersions
+	Runtimes      *types.RuntimeVersions
+	Specs         *types.SpecVersions
+	Highlights    []string
+	Advisories    []types.Advisory
+	Providers     []ProviderSupport // availability on managed Kubernetes offerings
+	// PreReleaseStage is the kind of build ("alpha", "beta" or "rc") the data
+	// of a pre-release is based on, if known.
+	PreReleaseStage string
+	// SupportPhase summarizes Released and Supported, but also knows when
+	// a release is in maintenance mode.
+	SupportPhase SupportPhase
+	// MaintenanceModeDate is when the release enters maintenance mode, nil if
+	// the EOL date is not known yet.
+	MaintenanceModeDate *time.Time
+	// DaysUntilEOL is negative once the release is EOL, nil if the EOL date is
+	// not known yet.
+	DaysUntilEOL *int
+	// DaysSinceRelease is negative for upcoming releases.
+	DaysSinceRelease int
 }
 
 func (o *Timeline) ReleaseMetadata(release string) ReleaseMetadata {
@@ -37,9 +65,23 @@ func (o *Timeline) HasRelease(release string) bool {
 type APIGroup struct {
 	Name               string
 	Archived           bool
+	Featured           bool              // see WithFeaturedGroups
 	PreferredVersions  map[string]string // lists the prefered version per release
 	ReleasesOfInterest []string          // releases which have notable changes for this API group
 	APIVersions        []APIVersion
+	// StorageVersions lists the API version each kind is persisted as per
+	// release (release => kind => API version), if known
+	StorageVersions map[string]map[string]string
+	// releases in which the storage version of a kind changed
+	StorageVersionChanges []StorageVersionChange
+	// groups that took over resources of this group (e.g. "extensions" was
+	// succeeded by "apps") and vice versa, see GroupLineage
+	SucceededBy []GroupLink
+	Replaces    []GroupLink
+	// releases in which the entire group stopped being served
+	Disappearances []string
+	// releases in which the group first reached alpha, beta and GA
+	Maturity MaturityProgression
 }
 
 // helper functions for templating :grin:
@@ -53,7 +95,16 @@ type APIVersion struct {
 	Archived           bool
 	Releases           []string // releases which have this API version
 	ReleasesOfInterest []string // releases which have notable changes for this API version
-	Resources          []APIResource
+	DeprecatedIn       string   // release in which this version was deprecated, if known
+	RemovedIn          string   // release in which this version is (or will be) removed; derived from Releases if not known beforehand
+	PredictedRemovalIn string   // earliest release in which this version can be removed per the deprecation policy, if no removal is known yet
+	Disappearances     []string // releases in which the entire version stopped being served
+	// feature gates that must be enabled to serve this version, per release
+	FeatureGates map[string]string
+	// whether the API server serves this version without being configured,
+	// per release
+	DefaultEnablement map[string]bool
+	Resources         []APIResource
 }
 
 func (o *APIVersion) HasRelease(release string) bool {
@@ -74,7 +125,48 @@ type APIResource struct {
 	Scopes             map[string]string
 	Releases           []string // releases which have this resource
 	ReleasesOfInterest []string // releases which have notable changes for this resource
-	Description        string
+	Description        string   // description in the most recent release providing one
+	DeprecatedIn       string
+	RemovedIn          string
+	// earliest release in which this resource can be removed per the
+	// deprecation policy, if no removal is known yet
+	PredictedRemovalIn string
+	// releases in which this resource is exercised by the conformance test suite
+	ConformanceReleases []string
+	// earliest release in which a GA version of the group served this kind,
+	// if any; the same for all versions of the kind
+	FirstGARelease string
+	// user-provided annotation, see WithAnnotations
+	Annotation *types.Annotation
+	// history of the resource across all versions of its API group; shared
+	// by all versions that offer the same kind
+	Lifecycle *ResourceLifecycle
+	// the API version that took over this resource, if any
+	SucceededBy *ResourceSuccession
+	// the API versions this resource took over from
+	Replaces []ResourceSuccession
+	// the API group this resource moved to, if any, and the groups it moved
+	// from (only recorded on the most mature version of the new group)
+	MigratedTo   *ResourceMigration
+	MigratedFrom []ResourceMigration
+	// short names (like "cm") and categories (like "all") per release, if known
+	ShortNames map[string][]string
+	Categories map[string][]string
+	// releases in which the short names or categories changed
+	AliasChanges []AliasChange
+	// feature gates that must be enabled to serve this resource, per release
+	FeatureGates map[string]string
+	// whether the API server serves this resource without being configured,
+	// per release
+	DefaultEnablement map[string]bool
+	// descriptions per release, if known; see DescriptionIn
+	Descriptions map[string]string
+	// URLs of the official API reference per release, see ReferenceDocsURL
+	ReferenceDocs map[string]string
+	// KEPs behind the changes in a release of interest, see WithKEPs
+	KEPs map[string][]KEPLink
+	// upstream changelog entries explaining a release of interest
+	ReleaseNotes map[string][]types.ReleaseNote
 }
 
 func (o *APIResource) HasRelease(release string) bool {
@@ -86,3 +178,13 @@ func (o *APIResource) HasRelease(release string) bool {
 
 	return false
 }
+
+func (o *APIResource) ConformanceCovered(release string) bool {
+	for _, r := range o.ConformanceReleases {
+		if r == release {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UpgradePlan is the result of validating a multi-hop upgrade path,
+// like 1.24 → 1.25 → 1.26.
+type UpgradePlan struct {
+	Hops []UpgradeHop
+}
+
+// Valid returns true if none of the hops violates the version skew policy.
+func (p *UpgradePlan) Valid() bool {
+	for _, hop := range p.Hops {
+		if len(hop.Problems) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Removals returns the API resources removed across the entire plan.
+func (p *UpgradePlan) Removals() []RemovedResource {
+	result := []RemovedResource{}
+	for _, hop := range p.Hops {
+		result = append(result, hop.Removals...)
+	}
+
+	return result
+}
+
+// UpgradeHop is a single step in an upgrade path.
+type UpgradeHop struct {
+	From string
+	To   string
+
+	// Problems lists violations of the version skew policy.
+	Problems []string
+
+	// Removals lists all API resources that are served in From, but are gone
+	// by the time To is reached (including removals in skipped releases).
+	Removals []RemovedResource
+}
+
+// RemovedResource identifies an API resource that stopped being served
+// in a specific release.
+type RemovedResource struct {
+	Group     string
+	Version   string
+	Kind      string
+	RemovedIn string
+}
+
+func (r RemovedResource) String() string {
+	return fmt.Sprintf("%s/%s %s", r.Group, r.Version, r.Kind)
+}
+
+// ValidateUpgradePath checks that each hop in the given list of releases only
+// upgrades by a single minor version (control planes cannot skip minor
+// releases) and collects the API removals crossed by each hop.
+func (o *Timeline) ValidateUpgradePath(releases ...string) (*UpgradePlan, error) {
+	if len(releases) < 2 {
+		return nil, errors.New("an upgrade path needs at least two releases")
+	}
+
+	for _, release := range releases {
+		if !o.HasRelease(release) {
+			return nil, fmt.Errorf("%w %q", ErrUnknownRelease, release)
+		}
+	}
+
+	plan := &UpgradePlan{}
+
+	for i := 1; i < len(releases); i++ {
+		from := releases[i-1]
+		to := releases[i]
+
+		hop := UpgradeHop{
+			From:     from,
+			To:       to,
+			Problems: []string{},
+			Removals: []RemovedResource{},
+		}
+
+		fromIdx := o.releaseIndex(from)
+		toIdx := o.releaseIndex(to)
+
+		switch {
+		case toIdx < fromIdx:
+			hop.Problems = append(hop.Problems, "downgrades are not supported")
+		case toIdx == fromIdx:
+			hop.Problems = append(hop.Problems, "hop does not change the release")
+		case toIdx > fromIdx+1:
+			hop.Problems = append(hop.Problems, fmt.Sprintf("cannot skip minor releases, upgrade via %s first", o.Releases[fromIdx+1].Version))
+		}
+
+		// collect removals for every single step covered by this hop
+		for step := fromIdx + 1; step <= toIdx; step++ {
+			hop.Removals = append(hop.Removals, o.removalsIn(o.Releases[step-1].Version, o.Releases[step].Version)...)
+		}
+
+		plan.Hops = append(plan.Hops, hop)
+	}
+
+	return plan, nil
+}
+
+func (o *Timeline) releaseIndex(release string) int {
+	for i, r := range o.Releases {
+		if r.Version == release {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// removalsIn returns all resources that are served in prev, but not in next.
+func (o *Timeline) removalsIn(prev, next string) []RemovedResource {
+	result := []RemovedResource{}
+
+	for _, apiGroup := range o.APIGroups {
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				if apiResource.HasRelease(prev) && !apiResource.HasRelease(next) {
+					result = append(result, RemovedResource{
+						Group:     apiGroup.Name,
+						Version:   apiVersion.Version,
+						Kind:      apiResource.Kind,
+						RemovedIn: next,
+					})
+				}
+			}
+		}
+	}
+
+	return result
+}
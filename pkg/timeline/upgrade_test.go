@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"errors"
+	"testing"
+)
+
+func testTimeline() *Timeline {
+	return &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.24"},
+			{Version: "1.25"},
+			{Version: "1.26"},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "batch",
+				APIVersions: []APIVersion{
+					{
+						Version:  "v1beta1",
+						Releases: []string{"1.24"},
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.24"}},
+						},
+					},
+					{
+						Version:  "v1",
+						Releases: []string{"1.24", "1.25", "1.26"},
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.24", "1.25", "1.26"}},
+							{Kind: "Job", Releases: []string{"1.24", "1.25"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateUpgradePath(t *testing.T) {
+	tl := testTimeline()
+
+	plan, err := tl.ValidateUpgradePath("1.24", "1.25", "1.26")
+	if err != nil {
+		t.Fatalf("Failed to validate path: %v", err)
+	}
+
+	if !plan.Valid() {
+		t.Fatalf("Expected plan to be valid, but got %+v", plan)
+	}
+
+	if len(plan.Hops) != 2 {
+		t.Fatalf("Expected 2 hops, got %d", len(plan.Hops))
+	}
+
+	if removals := plan.Hops[0].Removals; len(removals) != 1 || removals[0].String() != "batch/v1beta1 CronJob" {
+		t.Fatalf("Expected batch/v1beta1 CronJob to be removed in first hop, got %v", removals)
+	}
+
+	if removals := plan.Hops[1].Removals; len(removals) != 1 || removals[0].Kind != "Job" {
+		t.Fatalf("Expected Job to be removed in second hop, got %v", removals)
+	}
+}
+
+func TestValidateUpgradePathSkippingReleases(t *testing.T) {
+	tl := testTimeline()
+
+	plan, err := tl.ValidateUpgradePath("1.24", "1.26")
+	if err != nil {
+		t.Fatalf("Failed to validate path: %v", err)
+	}
+
+	if plan.Valid() {
+		t.Fatal("Expected skipping a minor release to be invalid.")
+	}
+
+	if removals := plan.Removals(); len(removals) != 2 {
+		t.Fatalf("Expected removals of skipped releases to be included, got %v", removals)
+	}
+}
+
+func TestValidateUpgradePathUnknownRelease(t *testing.T) {
+	tl := testTimeline()
+
+	if _, err := tl.ValidateUpgradePath("1.24", "1.99"); !errors.Is(err, ErrUnknownRelease) {
+		t.Fatalf("Expected ErrUnknownRelease, got %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"fmt"
+)
+
+// WhatsNew summarizes the API changes of a release compared to the release
+// before it.
+type WhatsNew struct {
+	Release string
+	// Previous is empty for the oldest release in the timeline.
+	Previous string
+
+	// Added lists resources that are served in new API versions, but which
+	// are not more mature than before (like a brand new alpha API).
+	Added []APIChange
+	// Graduated lists resources that are served in a more mature API version
+	// than in the previous release, like v1beta1 to v1.
+	Graduated []APIChange
+	// Deprecated lists resources that are deprecated starting with this
+	// release.
+	Deprecated []APIChange
+	// Removed lists resources that were served in the previous release, but
+	// are not anymore.
+	Removed []APIChange
+}
+
+// Empty returns true if the release did not change any APIs.
+func (w *WhatsNew) Empty() bool {
+	return len(w.Added)+len(w.Graduated)+len(w.Deprecated)+len(w.Removed) == 0
+}
+
+// APIChange is a single resource in an API version that changed in a release.
+type APIChange struct {
+	Group   string
+	Version string
+	Kind    string
+	// Details explain the change, e.g. "from v1beta1" for graduations or
+	// "use apps/v1 instead" for removals.
+	Details string
+}
+
+// APIVersion returns the apiVersion as it is written in manifests (i.e.
+// without the group for the core API group).
+func (c APIChange) APIVersion() string {
+	return groupVersion(c.Group, c.Version)
+}
+
+// WhatsNew compares the APIs of a release to its predecessor in the
+// timeline. Projected releases are not supported, as their APIs are guesses.
+func (o *Timeline) WhatsNew(release string) (*WhatsNew, error) {
+	idx := o.releaseIndex(release)
+	if idx < 0 {
+		return nil, fmt.Errorf("%w %q", ErrUnknownRelease, release)
+	}
+
+	if o.Releases[idx].Projected {
+		return nil, fmt.Errorf("release %s is only projected", release)
+	}
+
+	result := &WhatsNew{
+		Release:    release,
+		Added:      []APIChange{},
+		Graduated:  []APIChange{},
+		Deprecated: []APIChange{},
+		Removed:    []APIChange{},
+	}
+
+	if idx == 0 {
+		return result, nil
+	}
+
+	previous := o.Releases[idx-1].Version
+	result.Previous = previous
+
+	for i := range o.APIGroups {
+		apiGroup := &o.APIGroups[i]
+
+		graduations, err := getGraduationReleases(*apiGroup, o.Releases)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, apiVersion := range apiGroup.APIVersions {
+			for _, apiResource := range apiVersion.Resources {
+				change := APIChange{
+					Group:   apiGroup.Name,
+					Version: apiVersion.Version,
+					Kind:    apiResource.Kind,
+				}
+
+				inPrevious, inRelease := apiResource.HasRelease(previous), apiResource.HasRelease(release)
+
+				switch {
+				case !inPrevious && inRelease:
+					if graduations[apiVersion.Version][apiResource.Kind] == release {
+						if from := alternativeVersion(apiGroup, apiResource.Kind, previous); from != "" {
+							change.Details = "from " + from
+						}
+
+						result.Graduated = append(result.Graduated, change)
+					} else {
+						result.Added = append(result.Added, change)
+					}
+
+				case inPrevious && !inRelease:
+					if alternative := alternativeVersion(apiGroup, apiResource.Kind, release); alternative != "" {
+						change.Details = fmt.Sprintf("use %s instead", groupVersion(apiGroup.Name, alternative))
+					} else if migration := apiResource.MigratedTo; migration != nil {
+						change.Details = fmt.Sprintf("use %s instead", groupVersion(migration.Group, migration.Version))
+					}
+
+					result.Removed = append(result.Removed, change)
+
+				case inPrevious && inRelease && apiResource.IsDeprecatedIn(release) && !apiResource.IsDeprecatedIn(previous):
+					switch {
+					case apiResource.RemovedIn != "":
+						change.Details = "removed in " + apiResource.RemovedIn
+					case apiResource.PredictedRemovalIn != "":
+						change.Details = "removal predicted for " + apiResource.PredictedRemovalIn
+					}
+
+					result.Deprecated = append(result.Deprecated, change)
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
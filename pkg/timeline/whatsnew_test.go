@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWhatsNew(t *testing.T) {
+	tl := &Timeline{
+		Releases: []ReleaseMetadata{
+			{Version: "1.20"},
+			{Version: "1.21"},
+			{Version: "1.22", Projected: true},
+		},
+		APIGroups: []APIGroup{
+			{
+				Name: "batch",
+				APIVersions: []APIVersion{
+					{
+						Version: "v1beta1",
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.20", "1.21"}, DeprecatedIn: "1.21", RemovedIn: "1.25"},
+						},
+					},
+					{
+						Version: "v1",
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.21", "1.22"}},
+							{Kind: "Job", Releases: []string{"1.20", "1.21", "1.22"}},
+						},
+					},
+					{
+						Version: "v1alpha1",
+						Resources: []APIResource{
+							{Kind: "CronJob", Releases: []string{"1.20"}},
+							{Kind: "Schedule", Releases: []string{"1.21"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	whatsNew, err := tl.WhatsNew("1.21")
+	if err != nil {
+		t.Fatalf("Failed to compare releases: %v", err)
+	}
+
+	expected := &WhatsNew{
+		Release:    "1.21",
+		Previous:   "1.20",
+		Added:      []APIChange{{Group: "batch", Version: "v1alpha1", Kind: "Schedule"}},
+		Graduated:  []APIChange{{Group: "batch", Version: "v1", Kind: "CronJob", Details: "from v1beta1"}},
+		Deprecated: []APIChange{{Group: "batch", Version: "v1beta1", Kind: "CronJob", Details: "removed in 1.25"}},
+		Removed:    []APIChange{{Group: "batch", Version: "v1alpha1", Kind: "CronJob", Details: "use batch/v1 instead"}},
+	}
+
+	if !reflect.DeepEqual(whatsNew, expected) {
+		t.Errorf("Expected %+v, got %+v.", expected, whatsNew)
+	}
+
+	first, err := tl.WhatsNew("1.20")
+	if err != nil {
+		t.Fatalf("Failed to compare releases: %v", err)
+	}
+
+	if first.Previous != "" || !first.Empty() {
+		t.Errorf("Expected no changes for the oldest release, got %+v.", first)
+	}
+
+	if _, err := tl.WhatsNew("1.22"); err == nil {
+		t.Error("Expected an error for a projected release.")
+	}
+
+	if _, err := tl.WhatsNew("1.99"); !errors.Is(err, ErrUnknownRelease) {
+		t.Errorf("Expected ErrUnknownRelease, got %v.", err)
+	}
+}
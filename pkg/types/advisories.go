@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+import "fmt"
+
+// Advisory is a security advisory (usually a CVE) affecting a Kubernetes
+// minor release, together with the patch release that fixed it.
+type Advisory struct {
+	ID       string `json:"id"` // e.g. "CVE-2023-3676"
+	Summary  string `json:"summary"`
+	Severity string `json:"severity"`          // e.g. "High"
+	FixedIn  string `json:"fixedIn,omitempty"` // patch release, e.g. "1.28.1"; empty if unfixed
+	Link     string `json:"url,omitempty"`
+}
+
+// URL returns the advisory's link, falling back to the CVE record.
+func (a *Advisory) URL() string {
+	if a.Link != "" {
+		return a.Link
+	}
+
+	return fmt.Sprintf("https://www.cve.org/CVERecord?id=%s", a.ID)
+}
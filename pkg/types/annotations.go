@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+// Annotation is a user-provided note about an API resource, used by
+// platform teams to publish their own migration status.
+type Annotation struct {
+	Group   string `json:"group"`             // e.g. "apps", "core" or "" for the core group
+	Version string `json:"version,omitempty"` // e.g. "v1beta1"; empty to match all versions
+	Kind    string `json:"kind"`              // e.g. "Deployment"
+	Note    string `json:"note,omitempty"`
+	Owner   string `json:"owner,omitempty"`  // e.g. "team-platform"
+	Ticket  string `json:"ticket,omitempty"` // link to a migration ticket
+}
+
+// Matches returns true if the annotation applies to the given resource.
+func (a *Annotation) Matches(group, version, kind string) bool {
+	annotatedGroup := a.Group
+	if annotatedGroup == "" {
+		annotatedGroup = "core"
+	}
+
+	if group == "" {
+		group = "core"
+	}
+
+	return annotatedGroup == group && a.Kind == kind && (a.Version == "" || a.Version == version)
+}
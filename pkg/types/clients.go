@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+// ClientVersions describes which Go client libraries correspond to
+// a Kubernetes minor release.
+type ClientVersions struct {
+	ClientGo          string `json:"clientGo"`                    // e.g. "v0.29"
+	ControllerRuntime string `json:"controllerRuntime,omitempty"` // e.g. "v0.17"
+}
@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+import "sort"
+
+// ConformanceCoverage lists the API resources that are exercised by the
+// conformance test suite of a single Kubernetes release.
+type ConformanceCoverage struct {
+	Release   string                `json:"release"`
+	Resources []ConformanceResource `json:"resources"`
+}
+
+func (c *ConformanceCoverage) Sort() {
+	sort.Slice(c.Resources, func(i, j int) bool {
+		if c.Resources[i].Group != c.Resources[j].Group {
+			return c.Resources[i].Group < c.Resources[j].Group
+		}
+
+		return c.Resources[i].Kind < c.Resources[j].Kind
+	})
+}
+
+type ConformanceResource struct {
+	Group string `json:"group"` // empty for the core group
+	Kind  string `json:"kind"`
+	Tests int    `json:"tests"` // number of conformance tests mentioning this resource
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+// Deprecation is a curated deprecation notice for an API version or a single
+// resource, as announced in the Kubernetes deprecation guide.
+type Deprecation struct {
+	// Group is the API group, "core" for the core group.
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	// Kind limits the notice to a single resource; if empty, it applies to
+	// the entire API version.
+	Kind         string `json:"kind,omitempty"`
+	DeprecatedIn string `json:"deprecatedIn,omitempty"`
+	RemovedIn    string `json:"removedIn,omitempty"`
+}
+
+// ApplyDeprecations fills in the deprecation fields of all matching API
+// versions and resources. Values that are already set are kept.
+func (r *KubernetesAPI) ApplyDeprecations(deprecations []Deprecation) {
+	for i, group := range r.APIGroups {
+		groupName := group.Name
+		if groupName == "" {
+			groupName = "core"
+		}
+
+		for j, version := range group.APIVersions {
+			for _, dep := range deprecations {
+				if dep.Group != groupName || dep.Version != version.Version {
+					continue
+				}
+
+				if dep.Kind == "" {
+					v := &r.APIGroups[i].APIVersions[j]
+					v.DeprecatedIn = firstNonEmpty(v.DeprecatedIn, dep.DeprecatedIn)
+					v.RemovedIn = firstNonEmpty(v.RemovedIn, dep.RemovedIn)
+					continue
+				}
+
+				for k, resource := range version.Resources {
+					if resource.Kind == dep.Kind {
+						res := &r.APIGroups[i].APIVersions[j].Resources[k]
+						res.DeprecatedIn = firstNonEmpty(res.DeprecatedIn, dep.DeprecatedIn)
+						res.RemovedIn = firstNonEmpty(res.RemovedIn, dep.RemovedIn)
+					}
+				}
+			}
+		}
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// DefaultEnablement is a curated exception to the rules that decide whether
+// an API version or a single resource is served without configuring the API
+// server, like beta APIs that were still enabled by default after KEP-3136.
+type DefaultEnablement struct {
+	// Group is the API group, "core" for the core group.
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	// Kind limits the exception to a single resource; if empty, it applies
+	// to the entire API version.
+	Kind        string `json:"kind,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	FromVersion string `json:"fromVersion"`
+	// ToVersion is empty if the exception still applies.
+	ToVersion string `json:"toVersion,omitempty"`
+}
+
+// AppliesTo returns true if the exception covers the given release (like
+// "1.28").
+func (e *DefaultEnablement) AppliesTo(release string) bool {
+	rel, err := version.ParseGeneric(release)
+	if err != nil {
+		return false
+	}
+
+	from, err := version.ParseGeneric(e.FromVersion)
+	if err != nil || rel.LessThan(from) {
+		return false
+	}
+
+	if e.ToVersion != "" {
+		to, err := version.ParseGeneric(e.ToVersion)
+		if err != nil || to.LessThan(rel) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ApplyDefaultEnablements records the curated default enablement on all
+// matching API versions and resources of the given release. Exceptions for an
+// entire API version also apply to all of its resources. Values that are
+// already set are kept.
+func (r *KubernetesAPI) ApplyDefaultEnablements(release string, enablements []DefaultEnablement) {
+	for _, enablement := range enablements {
+		if !enablement.AppliesTo(release) {
+			continue
+		}
+
+		enabled := enablement.Enabled
+
+		for i, group := range r.APIGroups {
+			groupName := group.Name
+			if groupName == "" {
+				groupName = "core"
+			}
+
+			if enablement.Group != groupName {
+				continue
+			}
+
+			for j, apiVersion := range group.APIVersions {
+				if enablement.Version != apiVersion.Version {
+					continue
+				}
+
+				v := &r.APIGroups[i].APIVersions[j]
+				if enablement.Kind == "" && v.DefaultEnabled == nil {
+					v.DefaultEnabled = &enabled
+				}
+
+				for k, resource := range v.Resources {
+					if (enablement.Kind == "" || enablement.Kind == resource.Kind) && resource.DefaultEnabled == nil {
+						v.Resources[k].DefaultEnabled = &enabled
+					}
+				}
+			}
+		}
+	}
+}
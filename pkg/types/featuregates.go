@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// FeatureGate is a Kubernetes feature gate, using the same stage format as
+// the upstream feature gate reference
+// (https://kubernetes.io/docs/reference/command-line-tools-reference/feature-gates/),
+// together with the APIs that are only served if it is enabled.
+type FeatureGate struct {
+	Name   string             `json:"name"`
+	Stages []FeatureGateStage `json:"stages"`
+	// APIs are curated, as the upstream list does not name them.
+	APIs []FeatureGateAPI `json:"apis,omitempty"`
+}
+
+type FeatureGateStage struct {
+	Stage        string `json:"stage"` // "alpha", "beta", "stable" or "deprecated"
+	DefaultValue bool   `json:"defaultValue"`
+	FromVersion  string `json:"fromVersion"`
+	// ToVersion is empty for the current stage.
+	ToVersion string `json:"toVersion,omitempty"`
+}
+
+// FeatureGateAPI is an API version or, if a kind is given, a single resource
+// guarded by a feature gate.
+type FeatureGateAPI struct {
+	// Group is the API group, "core" for the core group.
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind,omitempty"`
+}
+
+// Stage returns the stage the gate was in during the given release (like
+// "1.28"), or nil if the gate did not exist.
+func (g *FeatureGate) Stage(release string) *FeatureGateStage {
+	rel, err := version.ParseGeneric(release)
+	if err != nil {
+		return nil
+	}
+
+	for i, stage := range g.Stages {
+		from, err := version.ParseGeneric(stage.FromVersion)
+		if err != nil || rel.LessThan(from) {
+			continue
+		}
+
+		if stage.ToVersion != "" {
+			to, err := version.ParseGeneric(stage.ToVersion)
+			if err != nil || to.LessThan(rel) {
+				continue
+			}
+		}
+
+		return &g.Stages[i]
+	}
+
+	return nil
+}
+
+// RequiredIn returns true if the gate must be enabled explicitly to use its
+// APIs in the given release.
+func (g *FeatureGate) RequiredIn(release string) bool {
+	stage := g.Stage(release)
+	return stage != nil && !stage.DefaultValue
+}
+
+// ApplyFeatureGates records the feature gate that has to be enabled in the
+// given release on all matching API versions and resources. Gates for an
+// entire API version also apply to all of its resources.
+func (r *KubernetesAPI) ApplyFeatureGates(release string, gates []FeatureGate) {
+	for _, gate := range gates {
+		if !gate.RequiredIn(release) {
+			continue
+		}
+
+		for _, api := range gate.APIs {
+			for i, group := range r.APIGroups {
+				groupName := group.Name
+				if groupName == "" {
+					groupName = "core"
+				}
+
+				if api.Group != groupName {
+					continue
+				}
+
+				for j, apiVersion := range group.APIVersions {
+					if api.Version != apiVersion.Version {
+						continue
+					}
+
+					v := &r.APIGroups[i].APIVersions[j]
+					if api.Kind == "" {
+						v.FeatureGate = firstNonEmpty(v.FeatureGate, gate.Name)
+					}
+
+					for k, resource := range v.Resources {
+						if api.Kind == "" || api.Kind == resource.Kind {
+							v.Resources[k].FeatureGate = firstNonEmpty(resource.FeatureGate, gate.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+}
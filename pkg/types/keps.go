@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"fmt"
+)
+
+// KEP is a Kubernetes Enhancement Proposal
+// (https://github.com/kubernetes/enhancements), curated together with the
+// APIs it introduced or changed.
+type KEP struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	// URL defaults to the tracking issue of the KEP, which links to the
+	// design document and lists the releases it was worked on in.
+	URL  string   `json:"url,omitempty"`
+	APIs []KEPAPI `json:"apis"`
+}
+
+// KEPAPI selects the resources a KEP applies to. Without a version, all
+// versions of the group match, without a kind all resources of the version.
+type KEPAPI struct {
+	Group   string `json:"group"` // e.g. "apps" or "core"
+	Version string `json:"version,omitempty"`
+	Kind    string `json:"kind,omitempty"`
+}
+
+// Link returns the URL of the KEP.
+func (k *KEP) Link() string {
+	if k.URL != "" {
+		return k.URL
+	}
+
+	return fmt.Sprintf("https://github.com/kubernetes/enhancements/issues/%d", k.Number)
+}
+
+// Matches returns true if the KEP applies to the given resource.
+func (k *KEP) Matches(group, version, kind string) bool {
+	if group == "" {
+		group = "core"
+	}
+
+	for _, api := range k.APIs {
+		apiGroup := api.Group
+		if apiGroup == "" {
+			apiGroup = "core"
+		}
+
+		if apiGroup == group && (api.Version == "" || api.Version == version) && (api.Kind == "" || api.Kind == kind) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,10 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+// PatchRelease is a single patch release of a Kubernetes minor release.
+type PatchRelease struct {
+	Version string `json:"version"` // e.g. "1.28.1"
+	Date    string `json:"date"`    // e.g. "2023-08-23"
+}
@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+// ProviderSupport describes when a managed Kubernetes offering (like EKS,
+// GKE or AKS) made a release available and how long it supports it. All
+// dates are formatted like "2023-09-26".
+type ProviderSupport struct {
+	Provider  string `json:"provider"`  // e.g. "EKS"
+	Available string `json:"available"` // general availability on the provider
+	// SupportedUntil is the end of standard support; empty if not announced yet.
+	SupportedUntil string `json:"supportedUntil,omitempty"`
+	// ExtendedSupportUntil is the end of paid extended support, if offered.
+	ExtendedSupportUntil string `json:"extendedSupportUntil,omitempty"`
+}
@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+// ReleaseNote is an entry of the upstream changelog (CHANGELOG-1.x.md) of a
+// release, imported via `apininja data release-notes`.
+type ReleaseNote struct {
+	// Kind is the changelog section, like "API Change" or "Deprecation".
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+	// PullRequest is the URL of the pull request that made the change.
+	PullRequest string `json:"pullRequest,omitempty"`
+}
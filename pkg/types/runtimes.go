@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+import "fmt"
+
+// RuntimeVersions describes which container runtimes can be used with
+// a given Kubernetes minor release.
+type RuntimeVersions struct {
+	// Dockershim is true if the kubelet still ships the built-in Docker
+	// integration (it was removed in Kubernetes 1.24).
+	Dockershim bool          `json:"dockershim"`
+	Containerd *VersionRange `json:"containerd,omitempty"`
+	CRIO       *VersionRange `json:"crio,omitempty"`
+}
+
+// VersionRange is an inclusive range of minor versions, like "1.6" to "1.7".
+type VersionRange struct {
+	Min string `json:"min"`
+	Max string `json:"max,omitempty"`
+}
+
+func (r *VersionRange) String() string {
+	if r.Max == "" || r.Max == r.Min {
+		return r.Min
+	}
+
+	return fmt.Sprintf("%s – %s", r.Min, r.Max)
+}
@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package types
+
+// SpecVersions describes which versions of the pluggable interface specs
+// (storage, networking, ingress/routing) a Kubernetes release works with.
+type SpecVersions struct {
+	CSI        *VersionRange `json:"csi,omitempty"`
+	CNI        *VersionRange `json:"cni,omitempty"`
+	GatewayAPI *VersionRange `json:"gatewayAPI,omitempty"`
+}
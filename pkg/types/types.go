@@ -43,6 +43,16 @@ func (g *APIGroup) Sort() {
 type APIVersion struct {
 	Version   string     `json:"version"` // e.g. "v1beta1"
 	Resources []Resource `json:"resources"`
+	// DeprecatedIn and RemovedIn are the releases (like "1.25") in which
+	// the version was deprecated and is (or will be) removed, if known.
+	DeprecatedIn string `json:"deprecatedIn,omitempty"`
+	RemovedIn    string `json:"removedIn,omitempty"`
+	// FeatureGate names the feature gate that must be enabled to serve this
+	// version in this release, see data/featuregates.yaml.
+	FeatureGate string `json:"featureGate,omitempty"`
+	// DefaultEnabled overrides whether this version is served without
+	// configuring the API server in this release, see data/enablement.yaml.
+	DefaultEnabled *bool `json:"defaultEnabled,omitempty"`
 }
 
 func (v *APIVersion) Sort() {
@@ -57,6 +67,27 @@ type Resource struct {
 	Singular    string `json:"singular"`
 	Plural      string `json:"plural"`
 	Description string `json:"description"`
+	// Deprecated is true if the API documentation marks the resource as
+	// deprecated (only known for data from OpenAPI v3 documents).
+	Deprecated bool `json:"deprecated,omitempty"`
+	// DeprecatedIn and RemovedIn override the values of the API version for
+	// this resource.
+	DeprecatedIn string `json:"deprecatedIn,omitempty"`
+	RemovedIn    string `json:"removedIn,omitempty"`
+	// ShortNames (like "cm") and Categories (like "all") are only known for
+	// data from the discovery API or kubectl.
+	ShortNames []string `json:"shortNames,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+	// StorageVersion is the API version (like "apps/v1") the API server
+	// persists the resource as in etcd; only known for data from the
+	// discovery API.
+	StorageVersion string `json:"storageVersion,omitempty"`
+	// FeatureGate names the feature gate that must be enabled to serve this
+	// resource in this release.
+	FeatureGate string `json:"featureGate,omitempty"`
+	// DefaultEnabled overrides the value of the API version for this
+	// resource.
+	DefaultEnabled *bool `json:"defaultEnabled,omitempty"`
 }
 
 type APIOverview struct {
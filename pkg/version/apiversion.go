@@ -5,13 +5,25 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
-var apiVersionRegex = regexp.MustCompile(`^(v[0-9]+)([a-z]+[0-9]+)?$`)
+var apiVersionRegex = regexp.MustCompile(`^v([0-9]+)(([a-z]+)([0-9]+))?([a-z]+[0-9]+)?$`)
 
+// APIVersion is a parsed Kubernetes API version like "v1" or "v2beta3".
 type APIVersion struct {
-	version string
-	suffix  string
+	// Major is the major version, e.g. 2 for "v2beta3".
+	Major int
+	// Prerelease is the maturity of a prerelease version, e.g. "beta" for
+	// "v2beta3", and empty for GA versions.
+	Prerelease string
+	// PrereleaseVersion is the number after the maturity, e.g. 3 for
+	// "v2beta3", and 0 for GA versions.
+	PrereleaseVersion int
+	// Suffix is a vendor-specific addition to a prerelease version, e.g.
+	// "p1" for "v1beta1p1". Versions with a suffix sort after the same
+	// version without one.
+	Suffix string
 }
 
 func ParseAPIVersion(s string) (*APIVersion, error) {
@@ -20,97 +32,147 @@ func ParseAPIVersion(s string) (*APIVersion, error) {
 		return nil, errors.New("not a valid API version")
 	}
 
-	return &APIVersion{
-		version: match[1],
-		suffix:  match[2],
-	}, nil
-}
+	major, _ := strconv.Atoi(match[1])
+	parsed := &APIVersion{Major: major}
 
-func (s *APIVersion) String() string {
-	return fmt.Sprintf("%s%s", s.version, s.suffix)
-}
+	if match[2] != "" {
+		parsed.Prerelease = match[3]
+		parsed.PrereleaseVersion, _ = strconv.Atoi(match[4])
+		parsed.Suffix = match[5]
+	}
 
-func (s *APIVersion) Stable() bool {
-	return s.suffix == ""
+	return parsed, nil
 }
 
-func (s *APIVersion) Maturity() string {
-	if s.suffix == "" {
-		return ""
+func (s *APIVersion) String() string {
+	if s.IsGA() {
+		return fmt.Sprintf("v%d", s.Major)
 	}
 
-	match := suffixRegex.FindStringSubmatch(s.suffix)
-	if match == nil {
-		panic(fmt.Sprintf("%q is not a valid APIVersion suffix", s.suffix))
-	}
+	return fmt.Sprintf("v%d%s%d%s", s.Major, s.Prerelease, s.PrereleaseVersion, s.Suffix)
+}
 
-	return match[1]
+func (s *APIVersion) IsGA() bool {
+	return s.Prerelease == ""
 }
 
-func (s *APIVersion) Prerelease() bool {
-	return !s.Stable()
+func (s *APIVersion) IsBeta() bool {
+	return s.Prerelease == "beta"
 }
 
-func (s *APIVersion) LessThan(other *APIVersion) bool {
-	// comparing v1beta1 vs v2 => ignore suffix
-	if s.version != other.version {
-		return comparePrefixedNumbers(s.version, other.version)
-	}
+func (s *APIVersion) IsAlpha() bool {
+	return s.Prerelease == "alpha"
+}
 
-	if s.suffix != other.suffix {
-		if other.suffix == "" { // comparing v1beta1 vs v1
-			return true
-		}
+// Compare returns -1 if s is older than other, 0 if both are the same version
+// and 1 if s is newer. The major version is compared first, so v2alpha1 is
+// newer than v1.
+func (s *APIVersion) Compare(other *APIVersion) int {
+	// comparing v1beta1 vs v2 => ignore prerelease
+	if s.Major != other.Major {
+		return compareInts(s.Major, other.Major)
+	}
 
-		if s.suffix == "" { // comparing v1 vs v1beta1
-			return false
+	switch {
+	case s.Prerelease == other.Prerelease:
+		if s.PrereleaseVersion != other.PrereleaseVersion {
+			return compareInts(s.PrereleaseVersion, other.PrereleaseVersion)
 		}
 
-		return comparePrefixedNumbers(s.suffix, other.suffix)
+		return compareSuffixes(s.Suffix, other.Suffix)
+	case other.IsGA(): // comparing v1beta1 vs v1
+		return -1
+	case s.IsGA(): // comparing v1 vs v1beta1
+		return 1
+	default:
+		// compare beta vs. alpha (this relies on "alpha" being lexic. smaller than "beta")
+		return strings.Compare(s.Prerelease, other.Prerelease)
 	}
+}
 
-	return false // versions are identical
+func (s *APIVersion) LessThan(other *APIVersion) bool {
+	return s.Compare(other) < 0
 }
 
-var suffixRegex = regexp.MustCompile(`^([a-z]+)([0-9]+)$`)
+func (s *APIVersion) Equal(other *APIVersion) bool {
+	return s.Compare(other) == 0
+}
 
-// comparePrefixedNumbers returns true if i < j (e.g. "beta3" < "beta10" or "v2" < "v4")
-func comparePrefixedNumbers(i, j string) bool {
-	imatch := suffixRegex.FindStringSubmatch(i)
-	if imatch == nil {
-		panic(fmt.Sprintf("%q is not a valid prefixed number", i))
+// MoreMatureThan compares the stability first (GA > beta > alpha > other
+// prereleases) and only then the version order, so a prerelease of a new
+// major version is never more mature than a more stable older version (e.g.
+// batch/v2alpha1 is less mature than batch/v1beta1).
+func (s *APIVersion) MoreMatureThan(other *APIVersion) bool {
+	if s.stability() != other.stability() {
+		return s.stability() > other.stability()
 	}
 
-	jmatch := suffixRegex.FindStringSubmatch(j)
-	if jmatch == nil {
-		panic(fmt.Sprintf("%q is not a valid prefixed number", j))
-	}
+	return other.LessThan(s)
+}
 
-	// same prefix level (could be "v2" vs. "v3")
-	if imatch[1] == jmatch[1] {
-		irelease, _ := strconv.Atoi(imatch[2])
-		jrelease, _ := strconv.Atoi(jmatch[2])
+func (s *APIVersion) stability() int {
+	switch {
+	case s.IsGA():
+		return 3
+	case s.IsBeta():
+		return 2
+	case s.IsAlpha():
+		return 1
+	default:
+		return 0
+	}
+}
 
-		return irelease < jrelease
+// CompareAPIVersions compares two API versions like APIVersion.Compare, which
+// makes it usable with slices.SortFunc(). Strings that are not valid API
+// versions sort after all valid versions, in lexical order.
+func CompareAPIVersions(i, j string) int {
+	iVersion, iErr := ParseAPIVersion(i)
+	jVersion, jErr := ParseAPIVersion(j)
+
+	switch {
+	case iErr != nil && jErr != nil:
+		return strings.Compare(i, j)
+	case iErr != nil:
+		return 1
+	case jErr != nil:
+		return -1
+	default:
+		return iVersion.Compare(jVersion)
 	}
+}
 
-	// compare beta vs. alpha
-	return imatch[1] < jmatch[1]
+func compareInts(i, j int) int {
+	switch {
+	case i < j:
+		return -1
+	case i > j:
+		return 1
+	default:
+		return 0
+	}
 }
 
-// CompareAPIVersions is a helper to make slice.Sort() functions easier.
-func CompareAPIVersions(i, j string) bool {
-	iVersion, err := ParseAPIVersion(i)
-	if err != nil {
-		panic(fmt.Sprintf("%q is not a valid API version: %v", i, err))
+var suffixRegex = regexp.MustCompile(`^([a-z]+)([0-9]+)$`)
+
+// compareSuffixes compares vendor suffixes like "p2" and "p10" by their
+// prefix first and then numerically; no suffix sorts first.
+func compareSuffixes(i, j string) int {
+	if i == "" || j == "" {
+		return strings.Compare(i, j)
 	}
 
-	jVersion, err := ParseAPIVersion(j)
-	if err != nil {
-		panic(fmt.Sprintf("%q is not a valid API version: %v", j, err))
+	imatch := suffixRegex.FindStringSubmatch(i)
+	jmatch := suffixRegex.FindStringSubmatch(j)
+
+	if imatch[1] != jmatch[1] {
+		return strings.Compare(imatch[1], jmatch[1])
 	}
 
-	return iVersion.LessThan(jVersion)
+	inumber, _ := strconv.Atoi(imatch[2])
+	jnumber, _ := strconv.Atoi(jmatch[2])
+
+	return compareInts(inumber, jnumber)
 }
 
 // PreferredAPIVersion tries to mimic Kubernetes' own mechanism to determine the
@@ -130,12 +192,8 @@ func PreferredAPIVersion(versions []string) (*APIVersion, error) {
 		}
 
 		// same major version (e.g. "v1beta1" vs. "v1alpha3")
-		if apiVersion.version == preferred.version {
-			if apiVersion.Stable() { // e.g. "v1beta1" vs "v1"
-				preferred = apiVersion
-			} else if preferred.Stable() { // e.g. "v1" vs "v1beta1"
-				// NOP
-			} else if comparePrefixedNumbers(preferred.suffix, apiVersion.suffix) { // e.g. "v1alpha7" vs "v1beta1"
+		if apiVersion.Major == preferred.Major {
+			if preferred.LessThan(apiVersion) { // e.g. "v1alpha7" vs "v1beta1" or "v1beta1" vs "v1"
 				preferred = apiVersion
 			}
 
@@ -145,9 +203,9 @@ func PreferredAPIVersion(versions []string) (*APIVersion, error) {
 		// different major versions
 
 		// ... but same suffix (e.g. "v3" vs. "v6" or "v3beta1" vs. "v6beta1")
-		if apiVersion.suffix == preferred.suffix {
+		if apiVersion.Prerelease == preferred.Prerelease && apiVersion.PrereleaseVersion == preferred.PrereleaseVersion {
 			// highest major version wins
-			if comparePrefixedNumbers(preferred.version, apiVersion.version) {
+			if preferred.Major < apiVersion.Major {
 				preferred = apiVersion
 			}
 
@@ -158,18 +216,18 @@ func PreferredAPIVersion(versions []string) (*APIVersion, error) {
 
 		// In this somewhat unrealistic case, the highest maturity
 		// wins, regardless of major version (e.g. "v1beta1" beats "v2alpha7").
-		if apiVersion.Stable() {
+		if apiVersion.IsGA() {
 			preferred = apiVersion
 			continue
 		}
 
-		if preferred.Stable() {
+		if preferred.IsGA() {
 			// NOP
 			continue
 		}
 
 		// last resort, compare maturities (this relies on "alpha" being lexic. smaller than "beta")
-		if apiVersion.Maturity() > preferred.Maturity() {
+		if apiVersion.Prerelease > preferred.Prerelease {
 			preferred = apiVersion
 			continue
 		}
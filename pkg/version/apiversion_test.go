@@ -22,6 +22,36 @@ func TestParseValidAPIVersions(t *testing.T) {
 	}
 }
 
+func TestParseAPIVersionFields(t *testing.T) {
+	testcases := []struct {
+		input    string
+		expected APIVersion
+	}{
+		{"v1", APIVersion{Major: 1}},
+		{"v10", APIVersion{Major: 10}},
+		{"v1beta1", APIVersion{Major: 1, Prerelease: "beta", PrereleaseVersion: 1}},
+		{"v2alpha13", APIVersion{Major: 2, Prerelease: "alpha", PrereleaseVersion: 13}},
+		{"v1beta1p1", APIVersion{Major: 1, Prerelease: "beta", PrereleaseVersion: 1, Suffix: "p1"}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.input, func(t *testing.T) {
+			v, err := ParseAPIVersion(testcase.input)
+			if err != nil {
+				t.Fatalf("Should have parsed successfully, but did not: %v", err)
+			}
+
+			if *v != testcase.expected {
+				t.Errorf("Expected %+v, got %+v.", testcase.expected, *v)
+			}
+
+			if v.String() != testcase.input {
+				t.Errorf("Expected %q, got %q.", testcase.input, v.String())
+			}
+		})
+	}
+}
+
 func TestParseInvalidAPIVersions(t *testing.T) {
 	inputs := []string{
 		"",
@@ -54,6 +84,12 @@ func TestComparingAPIVersions(t *testing.T) {
 		{"v1alpha1", "v1"},
 		{"v1alpha1", "v1beta1"},
 		{"v1beta3", "v1beta10"},
+		{"v1alpha2", "v1alpha10"},
+		{"v1beta2", "v2"},
+		{"v1beta1", "v1beta1p1"},
+		{"v1beta1p2", "v1beta1p10"},
+		{"v1beta1p1", "v1beta2"},
+		{"v1beta1p1", "v1"},
 		{"v1", "v2"},
 		{"v1", "v2alpha1"},
 		{"v1", "v2"},
@@ -79,6 +115,69 @@ func TestComparingAPIVersions(t *testing.T) {
 	}
 }
 
+func TestCompareAPIVersions(t *testing.T) {
+	testcases := []struct {
+		left     string
+		right    string
+		expected int
+	}{
+		{"v1", "v1", 0},
+		{"v1beta1p1", "v1beta1p1", 0},
+		{"v1alpha10", "v1alpha2", 1},
+		{"v1alpha2", "v1alpha10", -1},
+		{"v2", "v1beta2", 1},
+		{"v1beta2", "v2", -1},
+		{"v1beta1p1", "v1beta1", 1},
+		// invalid versions sort last
+		{"v1", "foo", -1},
+		{"foo", "v1", 1},
+		{"bar", "foo", -1},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(fmt.Sprintf("%v", testcase), func(t *testing.T) {
+			if result := CompareAPIVersions(testcase.left, testcase.right); result != testcase.expected {
+				t.Errorf("Expected CompareAPIVersions(%s, %s) to be %d, got %d.", testcase.left, testcase.right, testcase.expected, result)
+			}
+		})
+	}
+}
+
+func TestMoreMatureAPIVersions(t *testing.T) {
+	testcases := []struct {
+		version  string
+		other    string
+		expected bool
+	}{
+		{"v1", "v1beta1", true},
+		{"v1beta2", "v1beta1", true},
+		{"v1beta1", "v1alpha1", true},
+		{"v2", "v1", true},
+		{"v2beta1", "v1beta1", true},
+		{"v1beta1", "v1", false},
+		{"v1", "v1", false},
+		{"v2alpha1", "v1", false},
+		{"v2alpha1", "v1beta1", false},
+		{"v1beta1", "v2alpha1", true},
+		{"v3alpha1", "v1beta2", false},
+		{"v1", "v2beta1", true},
+		{"v2beta1", "v1", false},
+		{"v2beta2", "v1beta1", true},
+		{"v1alpha1", "v1gamma1", true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(fmt.Sprintf("%v", testcase), func(t *testing.T) {
+			v, _ := ParseAPIVersion(testcase.version)
+			other, _ := ParseAPIVersion(testcase.other)
+
+			if result := v.MoreMatureThan(other); result != testcase.expected {
+				t.Errorf("Expected %s.MoreMatureThan(%s) to be %v, got %v.", testcase.version, testcase.other, testcase.expected, result)
+			}
+		})
+	}
+}
+
 func TestPreferredAPIVersion(t *testing.T) {
 	type comparison struct {
 		versions  []string
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package view implements personalized views of a timeline (pinned and
+// filtered API groups), which can be encoded into a single URL parameter
+// to make them shareable.
+package view
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+	"go.xrstf.de/kube-api.ninja/pkg/version"
+)
+
+// QueryParameter is the URL query parameter holding the encoded state.
+const QueryParameter = "view"
+
+// State describes a personalized view. Field names are kept short to keep
+// the encoded URLs short.
+type State struct {
+	// Pinned API groups are shown at the top, in the given order.
+	Pinned []string `json:"p,omitempty"`
+	// Groups, if not empty, limits the view to these (and the pinned) groups.
+	Groups []string `json:"g,omitempty"`
+	// StableOnly hides all alpha and beta API versions.
+	StableOnly bool `json:"s,omitempty"`
+}
+
+// Decode parses an encoded state.
+func Decode(encoded string) (*State, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid view: %w", err)
+	}
+
+	state := &State{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("invalid view: %w", err)
+	}
+
+	return state, nil
+}
+
+// Encode returns the URL-safe representation of the state.
+func (s *State) Encode() string {
+	// marshalling a struct of strings and bools cannot fail
+	data, _ := json.Marshal(s)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Empty returns true if the state does not change the timeline.
+func (s *State) Empty() bool {
+	return len(s.Pinned) == 0 && len(s.Groups) == 0 && !s.StableOnly
+}
+
+// IsPinned returns true if the given API group is pinned.
+func (s *State) IsPinned(group string) bool {
+	for _, pinned := range s.Pinned {
+		if strings.EqualFold(pinned, group) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TogglePin returns the encoded state with the given group (un)pinned.
+func (s *State) TogglePin(group string) string {
+	toggled := *s
+	toggled.Pinned = []string{}
+
+	for _, pinned := range s.Pinned {
+		if !strings.EqualFold(pinned, group) {
+			toggled.Pinned = append(toggled.Pinned, pinned)
+		}
+	}
+
+	if !s.IsPinned(group) {
+		toggled.Pinned = append(toggled.Pinned, group)
+	}
+
+	return toggled.Encode()
+}
+
+func (s *State) isIncluded(group string) bool {
+	if len(s.Groups) == 0 || s.IsPinned(group) {
+		return true
+	}
+
+	for _, g := range s.Groups {
+		if strings.EqualFold(g, group) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply returns a copy of the timeline with the view applied. The original
+// timeline is not modified.
+func (s *State) Apply(tl *timeline.Timeline) *timeline.Timeline {
+	result := &timeline.Timeline{
+		Releases:  tl.Releases,
+		APIGroups: []timeline.APIGroup{},
+	}
+
+	pinned := make([]*timeline.APIGroup, len(s.Pinned))
+	others := []timeline.APIGroup{}
+
+	for _, apiGroup := range tl.APIGroups {
+		if !s.isIncluded(apiGroup.Name) {
+			continue
+		}
+
+		if s.StableOnly {
+			versions := []timeline.APIVersion{}
+			for _, apiVersion := range apiGroup.APIVersions {
+				if parsed, err := version.ParseAPIVersion(apiVersion.Version); err == nil && parsed.IsGA() {
+					versions = append(versions, apiVersion)
+				}
+			}
+
+			if len(versions) == 0 {
+				continue
+			}
+
+			apiGroup.APIVersions = versions
+		}
+
+		isPinned := false
+		for i, name := range s.Pinned {
+			if strings.EqualFold(name, apiGroup.Name) {
+				group := apiGroup
+				pinned[i] = &group
+				isPinned = true
+				break
+			}
+		}
+
+		if !isPinned {
+			others = append(others, apiGroup)
+		}
+	}
+
+	for _, apiGroup := range pinned {
+		if apiGroup != nil {
+			result.APIGroups = append(result.APIGroups, *apiGroup)
+		}
+	}
+
+	result.APIGroups = append(result.APIGroups, others...)
+
+	return result
+}
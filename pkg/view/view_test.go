@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package view
+
+import (
+	"testing"
+
+	"go.xrstf.de/kube-api.ninja/pkg/timeline"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	state := &State{
+		Pinned:     []string{"apps", "batch"},
+		Groups:     []string{"core"},
+		StableOnly: true,
+	}
+
+	decoded, err := Decode(state.Encode())
+	if err != nil {
+		t.Fatalf("Failed to decode state: %v", err)
+	}
+
+	if decoded.Encode() != state.Encode() {
+		t.Errorf("Expected %+v, got %+v.", state, decoded)
+	}
+
+	if _, err := Decode("not-valid!"); err == nil {
+		t.Error("Expected an error when decoding invalid state.")
+	}
+}
+
+func TestApply(t *testing.T) {
+	tl := &timeline.Timeline{
+		APIGroups: []timeline.APIGroup{
+			{Name: "apps", APIVersions: []timeline.APIVersion{{Version: "v1"}}},
+			{Name: "batch", APIVersions: []timeline.APIVersion{{Version: "v1"}, {Version: "v1beta1"}}},
+			{Name: "core", APIVersions: []timeline.APIVersion{{Version: "v1"}}},
+			{Name: "resource.k8s.io", APIVersions: []timeline.APIVersion{{Version: "v1alpha2"}}},
+		},
+	}
+
+	state := &State{
+		Pinned:     []string{"core", "batch"},
+		StableOnly: true,
+	}
+
+	result := state.Apply(tl)
+
+	names := []string{}
+	for _, g := range result.APIGroups {
+		names = append(names, g.Name)
+	}
+
+	if expected := []string{"core", "batch", "apps"}; len(names) != len(expected) || names[0] != expected[0] || names[1] != expected[1] || names[2] != expected[2] {
+		t.Fatalf("Expected groups %v, got %v.", expected, names)
+	}
+
+	if len(result.APIGroups[1].APIVersions) != 1 {
+		t.Errorf("Expected prerelease versions to be removed, got %v.", result.APIGroups[1].APIVersions)
+	}
+
+	if len(tl.APIGroups) != 4 || len(tl.APIGroups[1].APIVersions) != 2 {
+		t.Error("Original timeline must not be modified.")
+	}
+
+	state = &State{Groups: []string{"apps"}, Pinned: []string{"core"}}
+	if result := state.Apply(tl); len(result.APIGroups) != 2 {
+		t.Errorf("Expected 2 groups, got %d.", len(result.APIGroups))
+	}
+}
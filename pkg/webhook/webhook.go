@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package webhook notifies downstream systems (like documentation builds or
+// chat bots) whenever the data of a rendered site has changed.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/changelog"
+)
+
+const (
+	// SignatureHeader contains the hex-encoded HMAC-SHA256 of the request
+	// body (prefixed with "sha256="), if the webhook has a secret.
+	SignatureHeader = "X-Kube-API-Ninja-Signature"
+
+	// a slow receiver must not block the site generation for long
+	timeout = 10 * time.Second
+)
+
+// Config is a single webhook receiver.
+type Config struct {
+	URL string `json:"url"`
+	// Secret is used to sign the payload, so receivers can verify that the
+	// request is genuine.
+	Secret string `json:"secret,omitempty"`
+	// Profiles limits the webhook to changes of these profiles; if empty,
+	// changes of all profiles are sent.
+	Profiles []string `json:"profiles,omitempty"`
+}
+
+// Wants returns true if the webhook is interested in the given profile.
+func (c *Config) Wants(profile string) bool {
+	if len(c.Profiles) == 0 {
+		return true
+	}
+
+	for _, p := range c.Profiles {
+		if p == profile {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Payload is the JSON document POSTed to the webhooks.
+type Payload struct {
+	Profile string    `json:"profile"`
+	URL     string    `json:"url"`
+	Date    time.Time `json:"date"`
+	// Changes can be empty if the site was built for the first time.
+	Changes []changelog.Change `json:"changes"`
+}
+
+// Send POSTs the payload to all webhooks that want it. All webhooks are
+// tried, even if some of them fail; the returned error combines all failures.
+func Send(ctx context.Context, client *http.Client, hooks []Config, payload Payload) error {
+	if client == nil {
+		client = &http.Client{Timeout: timeout}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	var errs []error
+
+	for _, hook := range hooks {
+		if !hook.Wants(payload.Profile) {
+			continue
+		}
+
+		if err := send(ctx, client, hook, body); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", hook.URL, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func send(ctx context.Context, client *http.Client, hook Config, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if hook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(hook.Secret, body))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the signature of the body, as sent in the SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
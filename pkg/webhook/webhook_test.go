@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.xrstf.de/kube-api.ninja/pkg/changelog"
+)
+
+func TestSend(t *testing.T) {
+	received := map[string]Payload{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.URL.Path == "/signed" && r.Header.Get(SignatureHeader) != Sign("s3cr3t", body) {
+			t.Errorf("Invalid signature %q.", r.Header.Get(SignatureHeader))
+		}
+
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		payload := Payload{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+
+		received[r.URL.Path] = payload
+	}))
+	defer srv.Close()
+
+	hooks := []Config{
+		{URL: srv.URL + "/broken"},
+		{URL: srv.URL + "/signed", Secret: "s3cr3t"},
+		{URL: srv.URL + "/other", Profiles: []string{"next"}},
+	}
+
+	payload := Payload{
+		Profile: "full",
+		URL:     "https://kube-api.ninja/",
+		Date:    time.Date(2023, time.December, 5, 0, 0, 0, 0, time.UTC),
+		Changes: []changelog.Change{{Type: changelog.ReleaseAdded, Message: "Added release 1.29."}},
+	}
+
+	if err := Send(context.Background(), srv.Client(), hooks, payload); err == nil {
+		t.Error("Expected the broken webhook to cause an error.")
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("Expected exactly one payload to be received, got %v.", received)
+	}
+
+	if got := received["/signed"]; got.Profile != "full" || len(got.Changes) != 1 {
+		t.Errorf("Unexpected payload %+v.", got)
+	}
+}
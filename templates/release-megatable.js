@@ -37,12 +37,19 @@ function showReleaseInfoPopover(link) {
   let cell = link.closest('th');
   let release = cell.dataset.release;
   let template = document.querySelector('.release-popover-template').cloneNode(true);
-  let now = new Date();
+  // historic snapshots are rendered relative to their snapshot date
+  let now = document.body.dataset.asOf ? new Date(document.body.dataset.asOf) : new Date();
+
+  let projected = cell.dataset.projected === 'true';
 
   let releaseDate = 'TBD';
   if (cell.dataset.releaseDate) {
     let parsed = new Date(cell.dataset.releaseDate);
     releaseDate = parsed.toDateString() + dateDiffString(now, parsed);
+
+    if (projected) {
+      releaseDate = `~${parsed.toDateString()} (projected)`;
+    }
   }
 
   let eolDate = 'TBD';
@@ -51,11 +58,26 @@ function showReleaseInfoPopover(link) {
     eolDate = parsed.toDateString() + dateDiffString(now, parsed);
   }
 
-  let latestVersion = '(unreleased)';
+  let latestVersion = projected ? '(projected)' : '(unreleased)';
   if (cell.dataset.latestVersion) {
     latestVersion = cell.dataset.latestVersion;
   }
 
+  let clientGo = cell.dataset.clientGo || 'n/a';
+  let controllerRuntime = cell.dataset.controllerRuntime || 'n/a';
+  let containerd = cell.dataset.containerd || 'n/a';
+  let crio = cell.dataset.crio || 'n/a';
+
+  let dockershim = 'n/a';
+  switch (cell.dataset.dockershim) {
+    case 'true':
+      dockershim = 'built-in';
+      break;
+    case 'false':
+      dockershim = 'removed';
+      break;
+  }
+
   // Hide additional infos if the release isn't out yet;
   // do not rely on the browser date, as some release information
   // depends on buildtime data and so making it client-dependent
@@ -66,8 +88,50 @@ function showReleaseInfoPopover(link) {
   template.querySelector('.release-date').innerText = releaseDate;
   template.querySelector('.latest-version').innerText = latestVersion;
   template.querySelector('.eol-date').innerText = eolDate;
+  template.querySelector('div.patch-cadence').innerText = cell.dataset.patchCadence;
+  template.querySelector('li.patch-cadence').classList.toggle('hidden', !cell.dataset.patchCadence);
+  template.querySelector('.client-go').innerText = clientGo;
+  template.querySelector('.controller-runtime').innerText = controllerRuntime;
+  template.querySelector('.containerd').innerText = containerd;
+  template.querySelector('.crio').innerText = crio;
+  template.querySelector('.dockershim').innerText = dockershim;
+
+  let highlights = template.querySelector('.release-highlights');
+  (cell.dataset.highlights || '').split('\n').filter(Boolean).forEach(function(highlight) {
+    let item = document.createElement('li');
+    item.innerText = highlight;
+    highlights.appendChild(item);
+  });
+  highlights.closest('li').classList.toggle('hidden', highlights.children.length === 0);
+
+  let providers = template.querySelector('.release-providers');
+  (cell.dataset.providers || '').split('\n').filter(Boolean).forEach(function(line) {
+    let [provider, available, supportedUntil] = line.split('|');
+    let item = document.createElement('li');
+    item.innerText = `${provider}: available since ${available}, supported until ${supportedUntil || 'TBD'}`;
+    providers.appendChild(item);
+  });
+  providers.closest('li').classList.toggle('hidden', providers.children.length === 0);
+
+  let advisories = template.querySelector('.release-advisories');
+  (cell.dataset.advisories || '').split('\n').filter(Boolean).forEach(function(line) {
+    let [id, severity, fixedIn, url] = line.split('|');
+    let link = document.createElement('a');
+    link.href = url;
+    link.target = '_blank';
+    link.className = 'external';
+    link.innerText = id;
+
+    let item = document.createElement('li');
+    item.appendChild(link);
+    item.append(` (${severity}, ` + (fixedIn ? `fixed in ${fixedIn})` : 'unfixed)'));
+    advisories.appendChild(item);
+  });
+  advisories.closest('li').classList.toggle('hidden', advisories.children.length === 0);
 
   template.querySelector('.release-documentation').href = `apidocs/${release}/`;
+  template.querySelector('.release-whats-new').href = `whats-new-${release}.html`;
+  template.querySelector('li.whats-new').classList.toggle('hidden', projected);
   template.querySelector('.release-changelog').href = `https://github.com/kubernetes/kubernetes/blob/master/CHANGELOG/CHANGELOG-${release}.md`;
   template.querySelector('.release-gitbranch').href = `https://github.com/kubernetes/kubernetes/tree/release-${release}`;
 
@@ -258,14 +322,55 @@ function updateROIState() {
   let unremarkable = megatable.querySelectorAll('tbody tr.apigroup.' + selectedRoiClass).length === 0;
   megatable.classList.toggle('unremarkable', isSelected && unremarkable);
 
+  updateDescriptions(selectedRelease);
+  updateReferenceLinks(selectedRelease);
+
   // re-set columns from the archive view mode
   updateArchiveViewState();
 }
 
+// resources whose description changed over time show the description of the
+// selected release instead of the most recent one
+function updateDescriptions(selectedRelease) {
+  megatable.querySelectorAll('tr.apiresource').forEach(function(row) {
+    let name = row.querySelector('th.name span[title]');
+    if (name === null) {
+      return;
+    }
+
+    if (name.dataset.description === undefined) {
+      name.dataset.description = name.title;
+    }
+
+    let cell = selectedRelease != '' ? row.querySelector('td.rel-' + selectedRelease.replace('.', '-') + '[data-description]') : null;
+    name.title = cell !== null ? cell.dataset.description : name.dataset.description;
+  });
+}
+
 if (selector !== null) {
   selector.addEventListener('change', updateROIState);
 }
 
+// links to the official API reference point to the selected release, as long
+// as the resource is served (and documented) in it
+function updateReferenceLinks(selectedRelease) {
+  megatable.querySelectorAll('tr.apiresource a.reference').forEach(function(link) {
+    if (link.dataset.href === undefined) {
+      link.dataset.href = link.href;
+    }
+
+    let served = selectedRelease != '' && link.closest('tr').querySelector('td.rel-' + selectedRelease.replace('.', '-') + '.a10y-exists:not(.release-projected):not(.release-prerelease)') !== null;
+    if (!served) {
+      link.href = link.dataset.href;
+      return;
+    }
+
+    link.href = link.dataset.href
+      .replace(/\/\/v\d+-\d+\.docs\./, '//v' + selectedRelease.replace('.', '-') + '.docs.')
+      .replace(/\/v\d+\.\d+\/#/, '/v' + selectedRelease + '/#');
+  });
+}
+
 // handle archiveView switch being toggled
 let archiveViewSwitch = document.querySelector('#archiveViewSwitch');
 
@@ -285,3 +390,36 @@ if (archiveViewSwitch !== null) {
   updateROIState();
   updateArchiveViewState();
 }
+
+// permalinks point to the row of a resource, which must be expanded first
+function showLinkedResource() {
+  let row = window.location.hash ? document.getElementById(window.location.hash.substring(1)) : null;
+  if (row === null || !row.classList.contains('apiresource')) {
+    return;
+  }
+
+  let apigroupBody  = row.closest('tbody');
+  let apiversionRow = apigroupBody.querySelector('tr.apiversion[data-apiversion="' + row.dataset.apiversion + '"]');
+
+  apigroupBody.classList.remove('collapsed');
+  apigroupBody.querySelector('tr.apigroup .toggle .icons').innerText = collapseIcon;
+
+  apiversionRow.classList.remove('collapsed');
+  apiversionRow.querySelector('.toggle .icons').innerText = collapseIcon;
+  updateAPIResourcesVisibility(apiversionRow);
+
+  if (archiveViewSwitch !== null && (row.classList.contains('archived') || apigroupBody.classList.contains('archived')) && !archiveViewSwitch.checked) {
+    archiveViewSwitch.checked = true;
+    updateArchiveViewState();
+  }
+
+  megatable.querySelectorAll('tr.linked').forEach(function(node) {
+    node.classList.remove('linked');
+  });
+
+  row.classList.add('linked');
+  row.scrollIntoView({block: 'center'});
+}
+
+window.addEventListener('hashchange', showLinkedResource);
+showLinkedResource();